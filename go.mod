@@ -7,6 +7,8 @@ require (
 	github.com/googleapis/go-sql-spanner v1.16.0
 	github.com/hakadoriya/z.go v0.0.1-0.20250309175519-1433e6247667
 	github.com/lib/pq v1.10.9
+	golang.org/x/term v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -3,6 +3,9 @@ package apperr
 import (
 	"errors"
 	"fmt"
+	"io"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/redact"
 )
 
 var (
@@ -10,10 +13,52 @@ var (
 	ErrCanceled                           = errors.New("canceled")
 	ErrDialectIsEmpty                     = errors.New("dialect is empty")
 	ErrDDLTagGoAnnotationNotFoundInSource = errors.New("go-ddl-tag annotation not found in source")
+	ErrOneArgumentRequired                = errors.New("one argument required")
 	ErrTwoArgumentsRequired               = errors.New("two arguments required")
 	ErrBothArgumentsIsDSN                 = errors.New("both arguments is dsn")
 	ErrBothArgumentsAreNotDSNOrSQLFile    = errors.New("both arguments are not dsn or sql file")
+	ErrOneOrMoreArgumentsRequired         = errors.New("one or more arguments required")
+	ErrNotFormatted                       = errors.New("not formatted")
+	ErrCommentsLost                       = errors.New("comments lost")
+	ErrOneOrTwoArgumentsRequired          = errors.New("one or two arguments required")
+	ErrLintFindingsFound                  = errors.New("lint findings of error severity found")
+	ErrUnsafeMigration                    = errors.New("unsafe migration found")
+	ErrInvalidPhase                       = errors.New("invalid phase")
+	ErrShadowDatabaseNotEmpty             = errors.New("shadow database is not empty")
+	ErrShadowVerificationFailed           = errors.New("shadow verification failed")
+	ErrNotConverged                       = errors.New("schema not converged")
+	ErrLocked                             = errors.New("locked by another ddlctl apply")
+	ErrUnfinishedApply                    = errors.New("unfinished apply found")
+	ErrNoApplyToResume                    = errors.New("no apply to resume")
+	ErrApplyStateMismatch                 = errors.New("apply state is for another database")
+	ErrInvalidHooks                       = errors.New("invalid hooks")
+	ErrHookFailed                         = errors.New("hook failed")
+	ErrProjectFileNotFound                = errors.New("project file not found")
+	ErrInvalidProjectFile                 = errors.New("invalid project file")
+	ErrEnvironmentNotFound                = errors.New("environment not found")
+	ErrInvalidDSNReference                = errors.New("invalid dsn reference")
+	ErrInvalidTypeOverride                = errors.New("invalid type override")
+	ErrInvalidOutput                      = errors.New("invalid output")
 )
 
 //nolint:gochecknoglobals
-var Errorf = fmt.Errorf
+var Errorf = WithRedaction(fmt.Errorf)
+
+// WithRedaction returns errorf that masks the credentials, such as the passwords in the DSNs, in the messages of the errors.
+func WithRedaction(errorf func(format string, a ...any) error) func(format string, a ...any) error {
+	return func(format string, a ...any) error {
+		return &redactedError{err: errorf(format, a...)}
+	}
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string { return redact.String(e.err.Error()) }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// Format formats the error in the same way as the wrapped error, such as with the stack trace for %+v.
+func (e *redactedError) Format(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, redact.String(fmt.Sprintf(fmt.FormatString(s, verb), e.err)))
+}
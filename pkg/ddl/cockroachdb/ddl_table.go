@@ -6,12 +6,18 @@ import (
 
 	"github.com/hakadoriya/z.go/stringz"
 
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
 )
 
 type Constraint interface {
 	isConstraint()
 	GetName() *Ident
+	// GetComments returns the comments that precede the constraint in CREATE TABLE.
+	GetComments() []string
+	// GetTrailingComment returns the comment on the same line after the constraint in CREATE TABLE.
+	GetTrailingComment() string
+	setTrailingComment(comment string)
 	GoString() string
 	String() string
 	StringForDiff() string
@@ -43,15 +49,22 @@ func (constraints Constraints) Append(constraint Constraint) Constraints {
 
 // PrimaryKeyConstraint represents a PRIMARY KEY constraint.
 type PrimaryKeyConstraint struct {
-	Name    *Ident
-	Columns []*ColumnIdent
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Columns         []*ColumnIdent
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*PrimaryKeyConstraint)(nil)
 
-func (*PrimaryKeyConstraint) isConstraint()      {}
-func (c *PrimaryKeyConstraint) GetName() *Ident  { return c.Name }
-func (c *PrimaryKeyConstraint) GoString() string { return internal.GoString(*c) }
+func (*PrimaryKeyConstraint) isConstraint()                       {}
+func (c *PrimaryKeyConstraint) GetName() *Ident                   { return c.Name }
+func (c *PrimaryKeyConstraint) GetComments() []string             { return c.Comments }
+func (c *PrimaryKeyConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *PrimaryKeyConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *PrimaryKeyConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *PrimaryKeyConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -81,18 +94,25 @@ func (c *PrimaryKeyConstraint) StringForDiff() string {
 
 // ForeignKeyConstraint represents a FOREIGN KEY constraint.
 type ForeignKeyConstraint struct {
-	Name       *Ident
-	Columns    []*ColumnIdent
-	Ref        *Ident
-	RefColumns []*ColumnIdent
-	OnAction   string
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Columns         []*ColumnIdent
+	Ref             *Ident
+	RefColumns      []*ColumnIdent
+	OnAction        string
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*ForeignKeyConstraint)(nil)
 
-func (*ForeignKeyConstraint) isConstraint()      {}
-func (c *ForeignKeyConstraint) GetName() *Ident  { return c.Name }
-func (c *ForeignKeyConstraint) GoString() string { return internal.GoString(*c) }
+func (*ForeignKeyConstraint) isConstraint()                       {}
+func (c *ForeignKeyConstraint) GetName() *Ident                   { return c.Name }
+func (c *ForeignKeyConstraint) GetComments() []string             { return c.Comments }
+func (c *ForeignKeyConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *ForeignKeyConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *ForeignKeyConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *ForeignKeyConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -139,18 +159,25 @@ func (c *ForeignKeyConstraint) StringForDiff() string {
 
 // IndexConstraint represents a UNIQUE constraint. //diff:ignore-line-postgres-cockroach.
 type IndexConstraint struct { //diff:ignore-line-postgres-cockroach
+	Comments         []string
+	TrailingComment  string
 	Name             *Ident
 	Unique           bool //diff:ignore-line-postgres-cockroach
 	UsingPreColumns  *Using
 	Columns          []*ColumnIdent
 	UsingPostColumns *Using
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*IndexConstraint)(nil) //diff:ignore-line-postgres-cockroach
 
-func (*IndexConstraint) isConstraint()      {}                               //diff:ignore-line-postgres-cockroach
-func (c *IndexConstraint) GetName() *Ident  { return c.Name }                //diff:ignore-line-postgres-cockroach
-func (c *IndexConstraint) GoString() string { return internal.GoString(*c) } //diff:ignore-line-postgres-cockroach
+func (*IndexConstraint) isConstraint()                       {}                //diff:ignore-line-postgres-cockroach
+func (c *IndexConstraint) GetName() *Ident                   { return c.Name } //diff:ignore-line-postgres-cockroach
+func (c *IndexConstraint) GetComments() []string             { return c.Comments }
+func (c *IndexConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *IndexConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *IndexConstraint) GoString() string                  { return internal.GoString(*c) } //diff:ignore-line-postgres-cockroach
 func (c *IndexConstraint) String() string { //diff:ignore-line-postgres-cockroach
 	var str string
 	if c.Unique { //diff:ignore-line-postgres-cockroach
@@ -196,15 +223,22 @@ func (c *IndexConstraint) StringForDiff() string { //diff:ignore-line-postgres-c
 
 // CheckConstraint represents a CHECK constraint.
 type CheckConstraint struct {
-	Name *Ident
-	Expr *Expr
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Expr            *Expr
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*CheckConstraint)(nil)
 
-func (*CheckConstraint) isConstraint()      {}
-func (c *CheckConstraint) GetName() *Ident  { return c.Name }
-func (c *CheckConstraint) GoString() string { return internal.GoString(*c) }
+func (*CheckConstraint) isConstraint()                       {}
+func (c *CheckConstraint) GetName() *Ident                   { return c.Name }
+func (c *CheckConstraint) GetComments() []string             { return c.Comments }
+func (c *CheckConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *CheckConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *CheckConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *CheckConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -274,12 +308,18 @@ func (t *ObjectName) StringForDiff() string {
 }
 
 type Column struct {
-	Name       *Ident
-	DataType   *DataType
-	Default    *Default
-	NotNull    bool
-	NotVisible bool
-	As         *As //diff:ignore-line-postgres-cockroach
+	// Comments is the comments that precede the column definition.
+	Comments []string
+	// TrailingComment is the comment on the same line after the column definition.
+	TrailingComment string
+	Name            *Ident
+	DataType        *DataType
+	Default         *Default
+	NotNull         bool
+	NotVisible      bool
+	As              *As //diff:ignore-line-postgres-cockroach
+	// Pos is the position of the column name.
+	Pos ddl.Position
 }
 
 type Default struct {
@@ -14,6 +14,9 @@ func Test_isStmt(t *testing.T) {
 	(&AlterTableStmt{}).isStmt()
 	(&CreateIndexStmt{}).isStmt()
 	(&DropIndexStmt{}).isStmt()
+	(&CreateTypeStmt{}).isStmt()
+	(&AlterTypeStmt{}).isStmt()
+	(&DropTypeStmt{}).isStmt()
 }
 
 func TestIdent_String(t *testing.T) {
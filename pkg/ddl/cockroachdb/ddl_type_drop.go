@@ -0,0 +1,42 @@
+package cockroachdb
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.cockroachlabs.com/docs/stable/drop-type //diff:ignore-line-postgres-cockroach
+
+var _ Stmt = (*DropTypeStmt)(nil)
+
+type DropTypeStmt struct {
+	Comment  string
+	IfExists bool
+	Name     *ObjectName
+}
+
+func (s *DropTypeStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *DropTypeStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "DROP TYPE "
+	if s.IfExists {
+		str += "IF EXISTS "
+	}
+	str += s.Name.String() + ";\n"
+	return str
+}
+
+func (*DropTypeStmt) isStmt()            {}
+func (s *DropTypeStmt) GoString() string { return internal.GoString(*s) }
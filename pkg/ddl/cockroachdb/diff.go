@@ -1,7 +1,9 @@
 package cockroachdb
 
 import (
+	"errors"
 	"reflect"
+	"sort"
 
 	"github.com/hakadoriya/z.go/diffz/simplediffz"
 	"github.com/hakadoriya/z.go/panicz"
@@ -13,14 +15,15 @@ import (
 
 //nolint:funlen,cyclop,gocognit
 func Diff(before, after *DDL) (*DDL, error) {
+	before, after = withoutRawStmts(before), withoutRawStmts(after)
 	result := &DDL{}
 
 	switch {
 	case before == nil && after != nil:
-		result.Stmts = append(result.Stmts, after.Stmts...)
+		result.Stmts = append(result.Stmts, sortStmtsForCreate(after.Stmts)...)
 		return result, nil
 	case before != nil && after == nil:
-		for _, stmt := range before.Stmts {
+		for _, stmt := range sortStmtsForDrop(before.Stmts) {
 			switch s := stmt.(type) {
 			case *CreateTableStmt:
 				result.Stmts = append(result.Stmts, &DropTableStmt{
@@ -30,6 +33,10 @@ func Diff(before, after *DDL) (*DDL, error) {
 				result.Stmts = append(result.Stmts, &DropIndexStmt{
 					Name: s.Name,
 				})
+			case *CreateTypeStmt:
+				result.Stmts = append(result.Stmts, &DropTypeStmt{
+					Name: s.Name,
+				})
 			default:
 				return nil, apperr.Errorf("%s: %T: %w", s.GetNameForDiff(), s, ddl.ErrNotSupported)
 			}
@@ -39,9 +46,28 @@ func Diff(before, after *DDL) (*DDL, error) {
 		return nil, ddl.ErrNoDifference
 	}
 
+	// CREATE TYPE, ALTER TYPE
+	for _, stmt := range sortStmtsForCreate(after.Stmts) {
+		afterStmt, ok := stmt.(*CreateTypeStmt)
+		if !ok {
+			continue
+		}
+		beforeStmt, _ := findStmtByTypeAndName(afterStmt, before.Stmts).(*CreateTypeStmt)
+		stmts, err := DiffCreateType(beforeStmt, afterStmt)
+		if err != nil {
+			if errors.Is(err, ddl.ErrNoDifference) {
+				continue
+			}
+			return nil, apperr.Errorf("DiffCreateType: %w", err)
+		}
+		result.Stmts = append(result.Stmts, stmts.Stmts...)
+	}
+
 	// DROP TABLE table_name;
 	for _, stmt := range onlyLeftStmt(before, after) {
 		switch beforeStmt := stmt.(type) {
+		case *CreateTypeStmt:
+			// MEMO: dropped after the tables that use them
 		case *CreateTableStmt:
 			result.Stmts = append(result.Stmts, &DropTableStmt{
 				Name: beforeStmt.Name,
@@ -58,6 +84,8 @@ func Diff(before, after *DDL) (*DDL, error) {
 	// CREATE TABLE table_name
 	for _, stmt := range onlyLeftStmt(after, before) {
 		switch afterStmt := stmt.(type) {
+		case *CreateTypeStmt:
+			// MEMO: created before the tables that use them
 		case *CreateTableStmt:
 			result.Stmts = append(result.Stmts, afterStmt)
 		case *CreateIndexStmt:
@@ -97,6 +125,15 @@ func Diff(before, after *DDL) (*DDL, error) {
 		}
 	}
 
+	// DROP TYPE
+	for _, stmt := range onlyLeftStmt(before, after) {
+		if beforeStmt, ok := stmt.(*CreateTypeStmt); ok {
+			result.Stmts = append(result.Stmts, &DropTypeStmt{
+				Name: beforeStmt.Name,
+			})
+		}
+	}
+
 	if len(result.Stmts) == 0 {
 		return nil, ddl.ErrNoDifference
 	}
@@ -104,6 +141,29 @@ func Diff(before, after *DDL) (*DDL, error) {
 	return result, nil
 }
 
+// createOrder returns the order in which stmt should be created,
+// so that types are created before the tables that use them.
+func createOrder(stmt Stmt) int {
+	switch stmt.(type) {
+	case *CreateTypeStmt:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func sortStmtsForCreate(stmts []Stmt) []Stmt {
+	sorted := append(make([]Stmt, 0, len(stmts)), stmts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return createOrder(sorted[i]) < createOrder(sorted[j]) })
+	return sorted
+}
+
+func sortStmtsForDrop(stmts []Stmt) []Stmt {
+	sorted := append(make([]Stmt, 0, len(stmts)), stmts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return createOrder(sorted[i]) > createOrder(sorted[j]) })
+	return sorted
+}
+
 func onlyLeftStmt(left, right *DDL) []Stmt {
 	result := make([]Stmt, 0)
 
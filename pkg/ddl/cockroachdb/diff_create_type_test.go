@@ -0,0 +1,97 @@
+package cockroachdb
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+//nolint:paralleltest,tparallel
+func TestDiffCreateType(t *testing.T) {
+	parse := func(t *testing.T, s string) *CreateTypeStmt {
+		t.Helper()
+
+		d, err := NewParser(NewLexer(s)).Parse()
+		require.NoError(t, err)
+		return d.Stmts[0].(*CreateTypeStmt) //nolint:forcetypeassert
+	}
+
+	t.Run("failure,ddl.ErrNoDifference", func(t *testing.T) {
+		t.Parallel()
+
+		before := parse(t, `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');`)
+		after := parse(t, `CREATE TYPE "mood" AS ENUM ('sad', 'ok', 'happy');`)
+
+		actual, err := DiffCreateType(before, after)
+		assert.ErrorIs(t, err, ddl.ErrNoDifference)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("failure,ddl.ErrNotSupported,DROP_VALUE", func(t *testing.T) {
+		t.Parallel()
+
+		before := parse(t, `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');`)
+		after := parse(t, `CREATE TYPE mood AS ENUM ('sad', 'happy');`)
+
+		actual, err := DiffCreateType(before, after)
+		assert.ErrorIs(t, err, ddl.ErrNotSupported)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("success,CREATE_TYPE", func(t *testing.T) {
+		t.Parallel()
+
+		after := parse(t, `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');`)
+
+		actual, err := DiffCreateType(nil, after)
+		require.NoError(t, err)
+		assert.Equal(t, "CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');\n", actual.String())
+	})
+
+	t.Run("success,DROP_TYPE", func(t *testing.T) {
+		t.Parallel()
+
+		before := parse(t, `CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy');`)
+
+		actual, err := DiffCreateType(before, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "DROP TYPE public.mood;\n", actual.String())
+	})
+
+	t.Run("success,ADD_VALUE_BEFORE_FIRST", func(t *testing.T) {
+		t.Parallel()
+
+		before := parse(t, `CREATE TYPE mood AS ENUM ('sad', 'happy');`)
+		after := parse(t, `CREATE TYPE mood AS ENUM ('very_sad', 'awful', 'sad', 'happy');`)
+
+		expected := `-- -CREATE TYPE mood AS ENUM ('sad', 'happy');
+-- +CREATE TYPE mood AS ENUM ('very_sad', 'awful', 'sad', 'happy');
+--  
+ALTER TYPE mood ADD VALUE 'very_sad' BEFORE 'sad';
+ALTER TYPE mood ADD VALUE 'awful' AFTER 'very_sad';
+`
+		actual, err := DiffCreateType(before, after)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual.String())
+	})
+
+	t.Run("success,RENAME_VALUE", func(t *testing.T) {
+		t.Parallel()
+
+		before := parse(t, `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');`)
+		after := parse(t, `CREATE TYPE mood AS ENUM ('unhappy', 'ok', 'glad');`)
+
+		expected := `-- -CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+-- +CREATE TYPE mood AS ENUM ('unhappy', 'ok', 'glad');
+--  
+ALTER TYPE mood RENAME VALUE 'sad' TO 'unhappy';
+ALTER TYPE mood RENAME VALUE 'happy' TO 'glad';
+`
+		actual, err := DiffCreateType(before, after)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual.String())
+	})
+}
@@ -22,6 +22,18 @@ func TestDiff(t *testing.T) {
 		require.ErrorIs(t, err, ddl.ErrNoDifference)
 	})
 
+	t.Run("failure,ddl.ErrNoDifference,RawStmt", func(t *testing.T) {
+		t.Parallel()
+
+		before := &DDL{Stmts: []Stmt{&RawStmt{Raw: "GRANT SELECT ON users TO readonly"}}}
+		after := &DDL{Stmts: []Stmt{&RawStmt{Raw: "GRANT SELECT ON users TO readwrite"}}}
+		_, err := Diff(before, after)
+		require.ErrorIs(t, err, ddl.ErrNoDifference)
+		result, err := Diff(nil, after)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(result.Stmts))
+	})
+
 	t.Run("failure,ddl.ErrNotSupported,DropTableStmt", func(t *testing.T) {
 		t.Parallel()
 
@@ -397,6 +409,37 @@ CREATE UNIQUE INDEX IF NOT EXISTS public.users_idx_by_username ON users (usernam
 		t.Logf("✅: %s: actual: %%s: \n%s", t.Name(), actual)
 	})
 
+	t.Run("success,TYPE", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+CREATE TYPE color AS ENUM ('red', 'green');
+CREATE TABLE public.users ( id UUID NOT NULL, mood mood NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE public.users ( id UUID NOT NULL, mood mood NOT NULL, status status NOT NULL, PRIMARY KEY (id) );
+CREATE TYPE status AS ENUM ('active', 'inactive');
+CREATE TYPE mood AS ENUM ('sad', 'fine', 'happy', 'joyful');`)).Parse()
+		require.NoError(t, err)
+
+		expected := `CREATE TYPE status AS ENUM ('active', 'inactive');
+-- -CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+-- +CREATE TYPE mood AS ENUM ('sad', 'fine', 'happy', 'joyful');
+--  
+ALTER TYPE mood RENAME VALUE 'ok' TO 'fine';
+ALTER TYPE mood ADD VALUE 'joyful' AFTER 'happy';
+-- -
+-- +status status NOT NULL
+ALTER TABLE public.users ADD COLUMN status status NOT NULL;
+DROP TYPE color;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
 	t.Run("success,VARCHAR(10)->VARCHAR(11)", func(t *testing.T) {
 		t.Parallel()
 
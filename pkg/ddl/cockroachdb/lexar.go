@@ -2,6 +2,9 @@ package cockroachdb
 
 import (
 	"strings"
+	"unicode/utf8"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 )
 
 // MEMO: https://www.postgresql.jp/docs/11/datatype.html
@@ -10,6 +13,11 @@ import (
 type Token struct {
 	Type    TokenType
 	Literal Literal
+	Pos     ddl.Position
+	// Comments is the comments that precede the token, without "--". An empty comment represents a blank line.
+	Comments []string
+	// HasTrailingComment reports whether Comments[0] is on the same line as the previous token.
+	HasTrailingComment bool
 }
 
 type Literal struct {
@@ -65,6 +73,7 @@ const (
 	TOKEN_TABLE TokenType = "TABLE"
 	TOKEN_INDEX TokenType = "INDEX"
 	TOKEN_VIEW  TokenType = "VIEW"
+	TOKEN_TYPE  TokenType = "TYPE"
 
 	// OTHER.
 	TOKEN_IF     TokenType = "IF"
@@ -72,6 +81,7 @@ const (
 	TOKEN_USING  TokenType = "USING"
 	TOKEN_ON     TokenType = "ON"
 	TOKEN_TO     TokenType = "TO"
+	TOKEN_ENUM   TokenType = "ENUM"
 
 	// DATA TYPE.
 	TOKEN_BOOL              TokenType = "BOOL" //diff:ignore-line-postgres-cockroach
@@ -168,6 +178,8 @@ func lookupIdent(ident string) TokenType {
 		return TOKEN_INDEX
 	case "VIEW":
 		return TOKEN_VIEW
+	case "TYPE":
+		return TOKEN_TYPE
 	case "IF":
 		return TOKEN_IF
 	case "EXISTS":
@@ -178,6 +190,8 @@ func lookupIdent(ident string) TokenType {
 		return TOKEN_ON
 	case "TO":
 		return TOKEN_TO
+	case "ENUM":
+		return TOKEN_ENUM
 	case "BOOLEAN", "BOOL":
 		return TOKEN_BOOL //diff:ignore-line-postgres-cockroach
 	case "INT2", "SMALLINT": //diff:ignore-line-postgres-cockroach
@@ -281,14 +295,19 @@ func lookupIdent(ident string) TokenType {
 // Lexer はSQL文をトークンに分割するレキサーです。
 type Lexer struct {
 	input        string
-	position     int  // 現在の位置
-	readPosition int  // 次の位置
-	ch           byte // 現在の文字
+	position     int      // 現在の位置
+	readPosition int      // 次の位置
+	ch           byte     // 現在の文字
+	line         int      // 現在の行
+	lineOffset   int      // 現在の行の先頭の位置
+	comments     []string // 次のトークンの前のコメント
+	trailing     bool     // comments[0] が直前のトークンと同じ行にあるか
+	lastLine     int      // 直前のトークンの行
 }
 
 // NewLexer は新しいLexerを生成します。
 func NewLexer(input string) *Lexer {
-	l := &Lexer{input: input}
+	l := &Lexer{input: input, line: 1}
 
 	// 1文字読み込む
 	l.readChar()
@@ -298,6 +317,10 @@ func NewLexer(input string) *Lexer {
 
 // readChar は入力から次の文字を読み込みます。
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.lineOffset = l.readPosition
+	}
 	if l.readPosition >= len(l.input) {
 		// 終端に達したら0を返す
 		l.ch = 0
@@ -315,17 +338,45 @@ func (l *Lexer) readChar() {
 func (l *Lexer) NextToken() Token {
 	var tok Token
 
+	line := l.line
 	l.skipWhitespace()
+	if n := len(l.comments); n > 0 && l.comments[n-1] != "" && l.line-line > 1 {
+		// コメントの後の空行は空のコメントとして保持する
+		l.comments = append(l.comments, "")
+	}
 
 	if l.ch == '-' && l.peekChar() == '-' {
-		l.skipComment()
+		if len(l.comments) == 0 && l.line == l.lastLine {
+			l.trailing = true
+		}
+		l.comments = append(l.comments, l.readComment())
 		return l.NextToken()
 	}
 
+	pos := l.pos()
+	comments, trailing := l.comments, l.trailing
+	l.comments, l.trailing = nil, false
+
 	switch l.ch {
 	case '"', '\'':
 		tok.Type = TOKEN_IDENT
-		tok.Literal = Literal{Str: l.readQuotedLiteral(l.ch)}
+		tok.Literal = Literal{Str: l.readQuotedLiteral(l.ch, l.ch == '\'' && l.isEscapeString())}
+		if l.ch == 0 {
+			// 引用符が閉じられていない
+			tok.Type = TOKEN_ILLEGAL
+		}
+	case '$':
+		tag := l.dollarQuoteTag()
+		if tag == "" {
+			tok = newToken(TOKEN_ILLEGAL, l.ch)
+			break
+		}
+		tok.Type = TOKEN_IDENT
+		tok.Literal = Literal{Str: l.readDollarQuotedLiteral(tag)}
+		if l.ch == 0 {
+			// ドル引用符が閉じられていない
+			tok.Type = TOKEN_ILLEGAL
+		}
 	case '|':
 		if l.peekChar() == '|' {
 			ch := l.ch
@@ -377,26 +428,88 @@ func (l *Lexer) NextToken() Token {
 			lit := l.readIdentifier()
 			tok.Type = lookupIdent(lit)
 			tok.Literal = Literal{Str: lit}
+			tok.Pos = pos
+			tok.Comments = comments
+			tok.HasTrailingComment = trailing
+			l.lastLine = l.line
 			return tok
 		}
 		tok = newToken(TOKEN_ILLEGAL, l.ch)
 	}
 
 	l.readChar()
+	tok.Pos = pos
+	tok.Comments = comments
+	tok.HasTrailingComment = trailing
+	l.lastLine = l.line
 	return tok
 }
 
+// pos は現在の文字の位置を返します。
+func (l *Lexer) pos() ddl.Position {
+	// 終端に達したら position は入力の長さを超えることがある
+	offset := min(l.position, len(l.input))
+	return ddl.Position{
+		Offset: offset,
+		Line:   l.line,
+		Column: utf8.RuneCountInString(l.input[l.lineOffset:offset]) + 1,
+	}
+}
+
 // readQuotedLiteral はクォーテーションで囲まれた文字列を読み込みます。
-func (l *Lexer) readQuotedLiteral(quote byte) string {
+// backslash が true の場合、バックスラッシュでエスケープされたクォーテーションでは終わりません。
+func (l *Lexer) readQuotedLiteral(quote byte, backslash bool) string {
 	// position := l.position + 1 // クォーテーションの次の文字から開始
 	position := l.position // クォーテーションの文字から開始
 	for {
 		l.readChar()
+		if backslash && l.ch == '\\' {
+			l.readChar() // エスケープされた文字
+			if l.ch == 0 {
+				break
+			}
+			continue
+		}
 		if l.ch == quote || l.ch == 0 {
 			break
 		}
 	}
-	return l.input[position : l.position+1]
+	return l.input[position:min(l.position+1, len(l.input))]
+}
+
+// isEscapeString は現在のクォーテーションが E'...' のエスケープ文字列の始まりかどうかを返します。
+func (l *Lexer) isEscapeString() bool {
+	p := l.position
+	return p >= 1 && (l.input[p-1] == 'E' || l.input[p-1] == 'e') && (p < 2 || !isLiteral(l.input[p-2]))
+}
+
+// dollarQuoteTag は現在の位置から始まるドル引用符 ($$ または $tag$) を返します。ドル引用符でない場合は空文字列を返します。
+func (l *Lexer) dollarQuoteTag() string {
+	for i := l.position + 1; i < len(l.input); i++ {
+		ch := l.input[i]
+		switch {
+		case ch == '$':
+			return l.input[l.position : i+1]
+		case ch == '_' || 'A' <= ch && ch <= 'Z' || 'a' <= ch && ch <= 'z' || ch >= utf8.RuneSelf:
+		case '0' <= ch && ch <= '9' && i > l.position+1:
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// readDollarQuotedLiteral はドル引用符 tag で囲まれた文字列を読み込みます。
+func (l *Lexer) readDollarQuotedLiteral(tag string) string {
+	position := l.position
+	end := len(l.input)
+	if i := strings.Index(l.input[position+len(tag):], tag); i >= 0 {
+		end = position + len(tag) + i + len(tag) - 1 // 閉じるドル引用符の最後の $
+	}
+	for l.position < end && l.ch != 0 {
+		l.readChar()
+	}
+	return l.input[position:min(l.position+1, len(l.input))]
 }
 
 // peekChar は次の文字を覗き見ますが、現在の位置は進めません。
@@ -413,7 +526,7 @@ func newToken(tokenType TokenType, ch byte) Token {
 
 func (l *Lexer) readIdentifier() string {
 	position := l.position
-	for isLiteral(l.ch) {
+	for isLiteral(l.ch) || l.ch == '$' { // 識別子の 2 文字目以降には $ を使える
 		l.readChar()
 	}
 	str := l.input[position:l.position]
@@ -426,7 +539,8 @@ func isLiteral(ch byte) bool {
 		'a' <= ch && ch <= 'z' ||
 		'0' <= ch && ch <= '9' ||
 		ch == '_' ||
-		ch == '.'
+		ch == '.' ||
+		ch >= utf8.RuneSelf // 非ASCII文字 (UTF-8 のマルチバイト文字の一部)
 }
 
 func (l *Lexer) skipWhitespace() (skipped bool) {
@@ -437,8 +551,12 @@ func (l *Lexer) skipWhitespace() (skipped bool) {
 	return skipped
 }
 
-func (l *Lexer) skipComment() {
+// readComment はコメントを読み込み、先頭の "--" と空白を除いた内容を返します。
+func (l *Lexer) readComment() string {
+	position := l.position
 	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
+	comment := strings.TrimPrefix(l.input[position:min(l.position, len(l.input))], "--")
+	return strings.TrimSpace(comment)
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 )
 
 func Test_lookupIdent(t *testing.T) {
@@ -31,10 +33,12 @@ func Test_lookupIdent(t *testing.T) {
 		{name: "success,TABLE", input: "TABLE", want: TOKEN_TABLE},
 		{name: "success,INDEX", input: "INDEX", want: TOKEN_INDEX},
 		{name: "success,VIEW", input: "VIEW", want: TOKEN_VIEW},
+		{name: "success,TYPE", input: "TYPE", want: TOKEN_TYPE},
 		{name: "success,IF", input: "IF", want: TOKEN_IF},
 		{name: "success,EXISTS", input: "EXISTS", want: TOKEN_EXISTS},
 		{name: "success,ON", input: "ON", want: TOKEN_ON},
 		{name: "success,TO", input: "TO", want: TOKEN_TO},
+		{name: "success,ENUM", input: "ENUM", want: TOKEN_ENUM},
 		{name: "success,BOOL", input: "BOOL", want: TOKEN_BOOL},
 		{name: "success,BOOLEAN", input: "BOOLEAN", want: TOKEN_BOOL},
 		{name: "success,SMALLINT", input: "SMALLINT", want: TOKEN_INT2},
@@ -113,64 +117,64 @@ func TestLex(t *testing.T) {
     UNIQUE ("email")
 );`,
 			want: []Token{
-				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}},
-				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}},
-				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_UUID, Literal: Literal{Str: "UUID"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}},
-				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}},
-				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}},
-				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}},
+				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}, Pos: ddl.Position{Offset: 0, Line: 1, Column: 1}},
+				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}, Pos: ddl.Position{Offset: 7, Line: 1, Column: 8}},
+				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}, Pos: ddl.Position{Offset: 13, Line: 1, Column: 14}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 16, Line: 1, Column: 17}},
+				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}, Pos: ddl.Position{Offset: 20, Line: 1, Column: 21}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}, Pos: ddl.Position{Offset: 27, Line: 1, Column: 28}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 35, Line: 1, Column: 36}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 41, Line: 2, Column: 5}},
+				{Type: TOKEN_UUID, Literal: Literal{Str: "UUID"}, Pos: ddl.Position{Offset: 54, Line: 2, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 67, Line: 2, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 71, Line: 2, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 75, Line: 2, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}, Pos: ddl.Position{Offset: 81, Line: 3, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 94, Line: 3, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 101, Line: 3, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 102, Line: 3, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 105, Line: 3, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 107, Line: 3, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 111, Line: 3, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 115, Line: 3, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 121, Line: 4, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 134, Line: 4, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 141, Line: 4, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 142, Line: 4, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 145, Line: 4, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 147, Line: 4, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 151, Line: 4, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 155, Line: 4, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}, Pos: ddl.Position{Offset: 161, Line: 5, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 174, Line: 5, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 181, Line: 5, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 182, Line: 5, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 185, Line: 5, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 187, Line: 5, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 191, Line: 5, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 195, Line: 5, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}, Pos: ddl.Position{Offset: 201, Line: 6, Column: 5}},
+				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}, Pos: ddl.Position{Offset: 214, Line: 6, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 228, Line: 6, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 232, Line: 6, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 236, Line: 6, Column: 40}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}, Pos: ddl.Position{Offset: 242, Line: 7, Column: 5}},
+				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}, Pos: ddl.Position{Offset: 255, Line: 7, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 269, Line: 7, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 273, Line: 7, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 277, Line: 7, Column: 40}},
+				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}, Pos: ddl.Position{Offset: 283, Line: 8, Column: 5}},
+				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}, Pos: ddl.Position{Offset: 291, Line: 8, Column: 13}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 295, Line: 8, Column: 17}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 296, Line: 8, Column: 18}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 305, Line: 8, Column: 27}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 306, Line: 8, Column: 28}},
+				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}, Pos: ddl.Position{Offset: 312, Line: 9, Column: 5}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 319, Line: 9, Column: 12}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 320, Line: 9, Column: 13}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 327, Line: 9, Column: 20}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 329, Line: 10, Column: 1}},
+				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}, Pos: ddl.Position{Offset: 330, Line: 10, Column: 2}},
 			},
 		},
 	}
@@ -220,6 +224,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "|"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -228,6 +233,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: ":"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -236,6 +242,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "!"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 	}
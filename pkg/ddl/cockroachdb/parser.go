@@ -4,12 +4,14 @@ package cockroachdb
 // MEMO: https://www.postgresql.jp/docs/11/ddl-constraints.html
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/hakadoriya/z.go/pathz/filepathz"
-	"github.com/hakadoriya/z.go/stringz"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
 
@@ -46,45 +48,108 @@ func NewIdent(name, quotationMark, raw string) *Ident {
 	}
 }
 
+//nolint:gochecknoglobals
+var plainIdentRegex = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// newDerivedIdent returns the identifier of name derived from other identifiers, such as a constraint name derived from the table name.
+// It is quoted unless name is a plain identifier, so that the result of String can be parsed again.
+func newDerivedIdent(name string) *Ident {
+	if plainIdentRegex.MatchString(name) {
+		return NewRawIdent(name)
+	}
+	return NewIdent(name, `"`, `"`+name+`"`)
+}
+
 // Parser はSQL文を解析するパーサーです。
 type Parser struct {
 	l            *Lexer
 	currentToken Token
 	peekToken    Token
+	lenient      bool
+	warnings     []*ddl.ParseError
+	// collecting reports whether nextToken collects the comments of the tokens into collected.
+	collecting bool
+	collected  []string
+}
+
+type ParserOption interface {
+	apply(p *Parser)
+}
+
+// ParserUseLenient makes Parse keep the statements that it does not support, such as GRANT, as RawStmt
+// and report them as Warnings, instead of returning errors.
+func ParserUseLenient(lenient bool) ParserOption { //nolint:ireturn
+	return &parserUseLenient{
+		lenient: lenient,
+	}
+}
+
+type parserUseLenient struct {
+	lenient bool
+}
+
+func (o *parserUseLenient) apply(p *Parser) {
+	p.lenient = o.lenient
 }
 
 // NewParser は新しいParserを生成します。
-func NewParser(l *Lexer) *Parser {
+func NewParser(l *Lexer, opts ...ParserOption) *Parser {
 	p := &Parser{
 		l: l,
 	}
 
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
 	return p
 }
 
+// Warnings は lenient モードで読み飛ばした文の警告を返します。
+func (p *Parser) Warnings() []*ddl.ParseError {
+	return p.warnings
+}
+
 // nextToken は次のトークンを読み込みます。
 func (p *Parser) nextToken() {
 	p.currentToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+	if p.collecting {
+		p.collected = append(p.collected, p.currentToken.Comments...)
+	}
 
 	_, file, line, _ := runtime.Caller(1)
 	logs.Trace.Printf("🪲: nextToken: caller=%s:%d currentToken: %#v, peekToken: %#v", filepathz.ExtractShortPath(file), line, p.currentToken, p.peekToken)
 }
 
 // Parse はSQL文を解析します。
+// 解析できない文があっても次の文から解析を続け、すべてのエラーを ddl.ParseErrors として返します。
 func (p *Parser) Parse() (*DDL, error) { //nolint:ireturn
 	p.nextToken() // current = ""
 	p.nextToken() // current = CREATE or ALTER or ...
 
 	d := &DDL{}
+	var errs ddl.ParseErrors
+
+	// MEMO: the comments separated from the first statement by a blank line are not the comments of the statement.
+	for i := len(p.currentToken.Comments) - 1; i >= 0 && p.currentToken.Type != TOKEN_EOF; i-- {
+		if p.currentToken.Comments[i] == "" {
+			d.Header, p.currentToken.Comments = p.currentToken.Comments[:i], p.currentToken.Comments[i+1:]
+			break
+		}
+	}
 
 LabelDDL:
 	for {
 		switch p.currentToken.Type { //nolint:exhaustive
 		case TOKEN_CREATE:
+			start := p.currentToken
 			stmt, err := p.parseCreateStatement()
 			if err != nil {
-				return nil, apperr.Errorf("parseCreateStatement: %w", err)
+				if err := p.recoverStmt(d, start, apperr.Errorf("parseCreateStatement: %w", err)); err != nil {
+					errs = append(errs, err)
+				}
+				break
 			}
 			d.Stmts = append(d.Stmts, stmt)
 		case TOKEN_CLOSE_PAREN:
@@ -92,17 +157,85 @@ LabelDDL:
 		case TOKEN_SEMICOLON:
 			// do nothing
 		case TOKEN_EOF:
+			d.Comments = p.currentToken.Comments
 			break LabelDDL
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			if err := p.recoverStmt(d, p.currentToken, p.newParseError(p.currentToken, ddl.ErrUnsupportedStatement)); err != nil {
+				errs = append(errs, err)
+			}
 		}
 
 		p.nextToken()
 	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return d, nil
 }
 
+// recoverStmt は解析できなかった文を ; または EOF まで読み飛ばします。
+// lenient モードではサポートしていない文を RawStmt として d に追加して警告とし、それ以外の場合は err を返します。
+func (p *Parser) recoverStmt(d *DDL, start Token, err error) error {
+	end := start.Pos.Offset + len(start.Literal.Str)
+	for !p.isCurrentToken(TOKEN_SEMICOLON) && !p.isCurrentToken(TOKEN_EOF) {
+		end = max(end, p.currentToken.Pos.Offset+len(p.currentToken.Literal.Str))
+		p.nextToken()
+	}
+
+	var parseErr *ddl.ParseError
+	if !p.lenient || !errors.Is(err, ddl.ErrUnsupportedStatement) || !errors.As(err, &parseErr) {
+		return err
+	}
+	p.warnings = append(p.warnings, parseErr)
+	d.Stmts = append(d.Stmts, &RawStmt{
+		Comment: strings.Join(start.Comments, "\n"),
+		Raw:     p.l.input[start.Pos.Offset:end],
+	})
+	return nil
+}
+
+// trailingComment returns the comment on the same line after the current token, which is "," or ")" after an element of CREATE TABLE,
+// and removes it from the comments of the token, so that it is not the comment that precedes the next element.
+func (p *Parser) trailingComment() string {
+	for _, token := range []*Token{&p.currentToken, &p.peekToken} {
+		if token.HasTrailingComment && len(token.Comments) > 0 {
+			comment := token.Comments[0]
+			token.Comments, token.HasTrailingComment = token.Comments[1:], false
+			return comment
+		}
+		if token.Type != TOKEN_COMMA {
+			break
+		}
+	}
+	return ""
+}
+
+// collectComments starts to collect the comments of the tokens after the current one, which is the first token of an element of CREATE TABLE.
+func (p *Parser) collectComments() {
+	p.collecting, p.collected = true, nil
+}
+
+// innerComments stops collecting the comments, and returns the ones inside the element without the blank lines.
+// The comments of the current token, which is "," or ")" after the element, are not inside the element,
+// because they are the trailing comment of the element or the ones that precede the next element.
+func (p *Parser) innerComments() []string {
+	p.collecting = false
+	collected := p.collected[:max(0, len(p.collected)-len(p.currentToken.Comments))]
+	p.collected = nil
+
+	var comments []string
+	for _, comment := range collected {
+		if comment != "" {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
+
 func (p *Parser) parseCreateStatement() (Stmt, error) { //nolint:ireturn
+	comment := strings.Join(p.currentToken.Comments, "\n")
+	pos := p.currentToken.Pos
 	p.nextToken() // current = TABLE or INDEX or ...
 
 	switch p.currentToken.Type { //nolint:exhaustive
@@ -111,15 +244,26 @@ func (p *Parser) parseCreateStatement() (Stmt, error) { //nolint:ireturn
 		if err != nil {
 			return nil, apperr.Errorf("parseCreateTableStmt: %w", err)
 		}
+		stmt.Comment = comment
+		stmt.Pos = pos
 		return stmt, nil
 	case TOKEN_INDEX, TOKEN_UNIQUE:
 		stmt, err := p.parseCreateIndexStmt()
 		if err != nil {
 			return nil, apperr.Errorf("parseCreateIndexStmt: %w", err)
 		}
+		stmt.Comment = comment
+		stmt.Pos = pos
+		return stmt, nil
+	case TOKEN_TYPE:
+		stmt, err := p.parseCreateTypeStmt()
+		if err != nil {
+			return nil, apperr.Errorf("parseCreateTypeStmt: %w", err)
+		}
+		stmt.Comment = comment
 		return stmt, nil
 	default:
-		return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, p.newParseError(p.currentToken, ddl.ErrUnsupportedStatement)
 	}
 }
 
@@ -161,11 +305,19 @@ func (p *Parser) parseCreateTableStmt() (*CreateTableStmt, error) {
 LabelColumns:
 	for {
 		switch { //nolint:exhaustive
-		case p.isCurrentToken(TOKEN_IDENT):
+		case isIdent(p.currentToken.Type):
+			comments := p.currentToken.Comments
+			pos := p.currentToken.Pos
+			p.collectComments()
 			column, constraints, err := p.parseColumn(createTableStmt.Name.Name)
+			inner := p.innerComments()
 			if err != nil {
 				return nil, apperr.Errorf(errFmtPrefix+"parseColumn: %w", err)
 			}
+			// MEMO: the comments inside the column definition, such as the one before NOT NULL on the next line, precede the column.
+			column.Comments = append(slices.Clip(comments), inner...)
+			column.TrailingComment = p.trailingComment()
+			column.Pos = pos
 			createTableStmt.Columns = append(createTableStmt.Columns, column)
 			if len(constraints) > 0 {
 				for _, c := range constraints {
@@ -177,21 +329,25 @@ LabelColumns:
 			if err != nil {
 				return nil, apperr.Errorf(errFmtPrefix+"parseTableConstraint: %w", err)
 			}
+			constraint.setTrailingComment(p.trailingComment())
 			createTableStmt.Constraints = createTableStmt.Constraints.Append(constraint)
 		case p.isCurrentToken(TOKEN_COMMA):
+			// MEMO: the comments before "," except the trailing comment of the previous element precede the next element.
+			p.peekToken.Comments = append(slices.Clip(p.currentToken.Comments), p.peekToken.Comments...)
 			p.nextToken()
 			continue
 		case p.isCurrentToken(TOKEN_SEMICOLON):
 			break LabelColumns
 		case p.isCurrentToken(TOKEN_CLOSE_PAREN):
+			createTableStmt.Footer = p.currentToken.Comments
 			switch p.peekToken.Type { //nolint:exhaustive
 			case TOKEN_SEMICOLON, TOKEN_EOF:
 				break LabelColumns
 			default:
-				return nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedPeekToken)
+				return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.peekToken, ddl.ErrUnexpectedPeekToken))
 			}
 		default:
-			return nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 		}
 	}
 
@@ -276,13 +432,57 @@ func (p *Parser) parseCreateIndexStmt() (*CreateIndexStmt, error) {
 	return createIndexStmt, nil
 }
 
+func (p *Parser) parseCreateTypeStmt() (*CreateTypeStmt, error) {
+	createTypeStmt := &CreateTypeStmt{}
+
+	p.nextToken() // current = type_name
+	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkCurrentToken: %w", err)
+	}
+
+	createTypeStmt.Name = NewObjectName(p.currentToken.Literal.Str)
+	errFmtPrefix := fmt.Sprintf("type_name=%s: ", createTypeStmt.Name.StringForDiff())
+
+	if err := p.checkPeekToken(TOKEN_AS); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = AS
+	if err := p.checkPeekToken(TOKEN_ENUM); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = ENUM
+	if err := p.checkPeekToken(TOKEN_OPEN_PAREN); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = (
+
+	p.nextToken() // current = 'value'
+
+LabelValues:
+	for {
+		switch p.currentToken.Type { //nolint:exhaustive
+		case TOKEN_IDENT:
+			createTypeStmt.Values = append(createTypeStmt.Values, NewRawIdent(p.currentToken.Literal.Str))
+		case TOKEN_COMMA:
+			// do nothing
+		case TOKEN_CLOSE_PAREN:
+			break LabelValues
+		default:
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+		}
+		p.nextToken()
+	}
+
+	return createTypeStmt, nil
+}
+
 //nolint:funlen,cyclop
 func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 	column := &Column{}
 	constraints := make(Constraints, 0)
 
-	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
-		return nil, nil, apperr.Errorf("checkCurrentToken: %w", err)
+	if !isIdent(p.currentToken.Type) {
+		return nil, nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 	}
 
 	column.Name = NewRawIdent(p.currentToken.Literal.Str)
@@ -291,7 +491,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 	p.nextToken() // current = DATA_TYPE
 
 	switch { //nolint:exhaustive
-	case isDataType(p.currentToken.Type):
+	case isDataType(p.currentToken.Type), p.isCurrentToken(TOKEN_IDENT): // MEMO: TOKEN_IDENT is a user-defined type such as ENUM
 		dataType, err := p.parseDataType()
 		if err != nil {
 			return nil, nil, apperr.Errorf(errFmtPrefix+"parseDataType: %w", err)
@@ -311,7 +511,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 					p.nextToken() // current = VISIBLE
 					column.NotVisible = true
 				default:
-					return nil, nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedPeekToken)
+					return nil, nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.peekToken, ddl.ErrUnexpectedPeekToken))
 				}
 			case TOKEN_NULL:
 				column.NotNull = false
@@ -348,7 +548,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 			}
 		}
 	default:
-		return nil, nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 	}
 
 	return column, constraints, nil
@@ -370,7 +570,7 @@ LabelDefault:
 			}
 			def.Value = def.Value.Append(ids...)
 			continue
-		case TOKEN_NOT, TOKEN_COMMA, TOKEN_CLOSE_PAREN:
+		case TOKEN_NOT, TOKEN_COMMA, TOKEN_CLOSE_PAREN, TOKEN_SEMICOLON, TOKEN_EOF:
 			break LabelDefault
 		default:
 			if isReservedValue(p.currentToken.Type) {
@@ -384,14 +584,14 @@ LabelDefault:
 				continue
 			}
 			if isDataType(p.currentToken.Type) {
-				def.Value.Idents = append(def.Value.Idents, NewRawIdent(p.currentToken.Literal.Str))
+				def.Value = def.Value.Append(NewRawIdent(p.currentToken.Literal.Str))
 				p.nextToken()
 				continue
 			}
 			if isConstraint(p.currentToken.Type) {
 				break LabelDefault
 			}
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -439,7 +639,7 @@ LabelAs:
 			if isConstraint(p.currentToken.Type) {
 				break LabelAs
 			}
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -471,7 +671,7 @@ LabelAs:
 		case TOKEN_NOT, TOKEN_COMMA, TOKEN_CLOSE_PAREN, TOKEN_VIRTUAL:
 			break LabelAs
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -513,7 +713,7 @@ LabelExpr:
 			}
 			idents = append(idents, NewRawIdent(value))
 		case TOKEN_EOF:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		default:
 			if isReservedValue(p.currentToken.Type) {
 				idents = append(idents, NewRawIdent(p.currentToken.Type.String()))
@@ -541,7 +741,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = KEY
 			constraints = constraints.Append(&PrimaryKeyConstraint{
-				Name:    NewRawIdent(tableName.StringForDiff() + "_pkey"),
+				Name:    newDerivedIdent(tableName.StringForDiff() + "_pkey"),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			})
 		case TOKEN_REFERENCES:
@@ -550,7 +750,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = table_name
 			constraint := &ForeignKeyConstraint{
-				Name:    NewRawIdent(fmt.Sprintf("%s_%s_fkey", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name:    newDerivedIdent(fmt.Sprintf("%s_%s_fkey", tableName.StringForDiff(), column.Name.StringForDiff())),
 				Ref:     NewRawIdent(p.currentToken.Literal.Str),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			}
@@ -584,10 +784,14 @@ LabelConstraints:
 			}
 			constraint.RefColumns = idents
 			constraints = constraints.Append(constraint)
+			// MEMO: without ON DELETE or ON UPDATE, the current token is already the one after the column constraint.
+			if constraint.OnAction == "" {
+				continue
+			}
 		case TOKEN_UNIQUE:
 			constraints = constraints.Append(&IndexConstraint{ //diff:ignore-line-postgres-cockroach
 				Unique:  true, //diff:ignore-line-postgres-cockroach
-				Name:    NewRawIdent(fmt.Sprintf("%s_unique_%s", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name:    newDerivedIdent(fmt.Sprintf("%s_unique_%s", tableName.StringForDiff(), column.Name.StringForDiff())),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			})
 		case TOKEN_CHECK:
@@ -596,7 +800,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = (
 			constraint := &CheckConstraint{
-				Name: NewRawIdent(fmt.Sprintf("%s_%s_check", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name: newDerivedIdent(fmt.Sprintf("%s_%s_check", tableName.StringForDiff(), column.Name.StringForDiff())),
 			}
 			idents, err := p.parseExpr()
 			if err != nil {
@@ -607,7 +811,7 @@ LabelConstraints:
 		case TOKEN_IDENT, TOKEN_COMMA, TOKEN_CLOSE_PAREN:
 			break LabelConstraints
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -618,11 +822,13 @@ LabelConstraints:
 
 //nolint:funlen,cyclop,gocognit
 func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //nolint:ireturn
+	comments := p.currentToken.Comments
+	pos := p.currentToken.Pos
 	var constraintName *Ident
 	if p.isCurrentToken(TOKEN_CONSTRAINT) {
 		p.nextToken() // current = constraint_name
 		if p.currentToken.Type != TOKEN_IDENT {
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 		constraintName = NewRawIdent(p.currentToken.Literal.Str)
 		p.nextToken() // current = PRIMARY or CHECK //diff:ignore-line-postgres-cockroach
@@ -643,11 +849,13 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 			return nil, apperr.Errorf("parseColumnIdents: %w", err)
 		}
 		if constraintName == nil {
-			constraintName = NewRawIdent(tableName.StringForDiff() + "_pkey")
+			constraintName = newDerivedIdent(tableName.StringForDiff() + "_pkey")
 		}
 		return &PrimaryKeyConstraint{
-			Name:    constraintName,
-			Columns: idents,
+			Comments: comments,
+			Pos:      pos,
+			Name:     constraintName,
+			Columns:  idents,
 		}, nil
 	case TOKEN_FOREIGN:
 		if err := p.checkPeekToken(TOKEN_KEY); err != nil {
@@ -694,6 +902,7 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 				p.nextToken()                                     // current = ACTION
 				onAction += " " + p.currentToken.Literal.String() // current = ACTION
 			}
+			p.nextToken() // current = , or )
 		}
 		if constraintName == nil {
 			name := tableName.StringForDiff()
@@ -701,9 +910,11 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 				name += "_" + ident.StringForDiff()
 			}
 			name += "_fkey"
-			constraintName = NewRawIdent(name)
+			constraintName = newDerivedIdent(name)
 		}
 		return &ForeignKeyConstraint{
+			Comments:   comments,
+			Pos:        pos,
 			Name:       constraintName,
 			Columns:    idents,
 			Ref:        refName,
@@ -733,6 +944,8 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 		if err != nil {
 			return nil, apperr.Errorf("parseColumnIdents: %w", err)
 		}
+		c.Comments = comments
+		c.Pos = pos
 		c.Name = constraintName
 		c.Columns = idents
 		if p.isCurrentToken(TOKEN_USING) {
@@ -744,8 +957,28 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 			p.nextToken() // current = (
 		}
 		return c, nil
+	case TOKEN_CHECK:
+		if err := p.checkPeekToken(TOKEN_OPEN_PAREN); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = (
+		idents, err := p.parseExpr()
+		if err != nil {
+			return nil, apperr.Errorf("parseExpr: %w", err)
+		}
+		if constraintName == nil {
+			// MEMO: the database names an unnamed table CHECK constraint after the columns in the expression,
+			// but the columns cannot be distinguished from the other identifiers here.
+			constraintName = newDerivedIdent(tableName.StringForDiff() + "_check")
+		}
+		return &CheckConstraint{
+			Comments: comments,
+			Pos:      pos,
+			Name:     constraintName,
+			Expr:     (*Expr)(nil).Append(idents...),
+		}, nil
 	default:
-		return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 	}
 }
 
@@ -831,7 +1064,10 @@ LabelIdents:
 			p.nextToken()
 			break LabelIdents
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			if !isIdent(p.currentToken.Type) {
+				return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
+			}
+			idents = append(idents, &ColumnIdent{Ident: NewRawIdent(p.currentToken.Literal.Str)})
 		}
 		p.nextToken()
 	}
@@ -852,7 +1088,7 @@ LabelIdents:
 		case TOKEN_CLOSE_PAREN:
 			break LabelIdents
 		case TOKEN_EOF, TOKEN_ILLEGAL:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		default:
 			idents = append(idents, NewRawIdent(p.currentToken.Literal.Str))
 		}
@@ -901,6 +1137,19 @@ func isDataType(tokenType TokenType) bool {
 	}
 }
 
+// isIdent reports whether tokenType can be used as an identifier such as a column name.
+// Non-reserved keywords such as TYPE are often used as column names.
+func isIdent(tokenType TokenType) bool {
+	switch tokenType { //nolint:exhaustive
+	case TOKEN_IDENT,
+		TOKEN_TYPE,
+		TOKEN_ENUM:
+		return true
+	default:
+		return false
+	}
+}
+
 func isConstraint(tokenType TokenType) bool {
 	switch tokenType { //nolint:exhaustive
 	case TOKEN_CONSTRAINT,
@@ -930,7 +1179,7 @@ func (p *Parser) checkCurrentToken(expectedTypes ...TokenType) error {
 			return nil
 		}
 	}
-	return apperr.Errorf("currentToken=%#v, peekToken=%#v: expected=%v, but got=%v: %w", p.currentToken, p.peekToken, stringz.JoinStringers(",", expectedTypes...), p.currentToken.Type, ddl.ErrUnexpectedCurrentToken)
+	return p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken, expectedTypes...)
 }
 
 func (p *Parser) isPeekToken(expectedTypes ...TokenType) bool {
@@ -948,5 +1197,20 @@ func (p *Parser) checkPeekToken(expectedTypes ...TokenType) error {
 			return nil
 		}
 	}
-	return apperr.Errorf("currentToken=%#v, peekToken=%#v: expected=%v, but got=%v: %w", p.currentToken, p.peekToken, stringz.JoinStringers(",", expectedTypes...), p.peekToken.Type, ddl.ErrUnexpectedPeekToken)
+	return p.newParseError(p.peekToken, ddl.ErrUnexpectedPeekToken, expectedTypes...)
+}
+
+// newParseError returns the error for the unexpected token, with its position and the expected token types.
+func (p *Parser) newParseError(token Token, err error, expectedTypes ...TokenType) *ddl.ParseError {
+	expected := make([]string, 0, len(expectedTypes))
+	for _, expectedType := range expectedTypes {
+		expected = append(expected, expectedType.String())
+	}
+	return &ddl.ParseError{
+		Position: token.Pos,
+		Token:    token.Literal.Str,
+		Expected: expected,
+		Source:   p.l.input,
+		Err:      err,
+	}
 }
@@ -52,6 +52,48 @@ CREATE TABLE "users" (
 		t.Logf("✅: %s: actual: %%s: \n%s", t.Name(), actualDDL)
 	})
 
+	t.Run("success,REFERENCES_before_table_constraint", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE users (id INT NOT NULL, group_id INT REFERENCES groups (id), PRIMARY KEY (id));`
+		expected := `CREATE TABLE users (
+    id INT NOT NULL,
+    group_id INT,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id)
+);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,Pos", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE users (
+    id INT NOT NULL,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX users_idx_id ON users (id);`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		table := actual.Stmts[0].(*CreateTableStmt) //nolint:forcetypeassert
+		assert.Equal(t, "1:1", table.Pos.String())
+		assert.Equal(t, "2:5", table.Columns[0].Pos.String())
+		constraint := table.Constraints[0].(*PrimaryKeyConstraint) //nolint:forcetypeassert
+		assert.Equal(t, "3:5", constraint.Pos.String())
+		index := actual.Stmts[1].(*CreateIndexStmt) //nolint:forcetypeassert
+		assert.Equal(t, "6:1", index.Pos.String())
+	})
+
 	t.Run("success,complex_defaults", func(t *testing.T) {
 		l := NewLexer(`-- table: complex_defaults
 CREATE TABLE IF NOT EXISTS complex_defaults (
@@ -70,7 +112,9 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 		actualDDL, err := p.Parse()
 		require.NoError(t, err)
 
-		const expected = `CREATE TABLE IF NOT EXISTS complex_defaults (
+		const expected = `-- table: complex_defaults
+CREATE TABLE IF NOT EXISTS complex_defaults (
+    -- id is the primary key.
     id SERIAL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
@@ -152,6 +196,25 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 		})
 	}
 
+	t.Run("success,CREATE_TYPE", func(t *testing.T) {
+		l := NewLexer(`CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy'); CREATE TABLE public.users (id UUID NOT NULL, type STRING, mood public.mood NOT NULL, PRIMARY KEY (id));`)
+		p := NewParser(l)
+		actualDDL, err := p.Parse()
+		require.NoError(t, err)
+
+		const expected = `CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy');
+CREATE TABLE public.users (
+    id UUID NOT NULL,
+    type STRING,
+    mood public.mood NOT NULL,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+`
+		if !assert.Equal(t, expected, actualDDL.String()) {
+			t.Fail()
+		}
+	})
+
 	failureTests := []struct {
 		name    string
 		input   string
@@ -427,6 +490,31 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 			input:   `CREATE INDEX users_idx_username ON users USING btree (NOT)`,
 			wantErr: ddl.ErrUnexpectedCurrentToken,
 		},
+		{
+			name:    "failure,CREATE_TYPE_INVALID",
+			input:   `CREATE TYPE NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_INVALID",
+			input:   `CREATE TYPE mood NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_AS_INVALID",
+			input:   `CREATE TYPE mood AS NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_AS_ENUM_INVALID",
+			input:   `CREATE TYPE mood AS ENUM NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_AS_ENUM_OPEN_PAREN_INVALID",
+			input:   `CREATE TYPE mood AS ENUM ('sad', NOT)`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
 	}
 
 	for _, tt := range failureTests {
@@ -437,6 +525,71 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 			require.ErrorIs(t, err, tt.wantErr)
 		})
 	}
+
+	t.Run("failure,ParseErrors", func(t *testing.T) {
+		t.Parallel()
+
+		input := "CREATE TABLE a (id INT NOT NOT);\nCREATE TABLE b (id UUID);\nCREATE TABLE c (id INT NOT NOT);\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnexpectedPeekToken)
+		parseErrs := ddl.AsParseErrors(err)
+		if !assert.Equal(t, 2, len(parseErrs)) {
+			t.FailNow()
+		}
+		assert.Equal(t, 1, parseErrs[0].Position.Line)
+		assert.Equal(t, 3, parseErrs[1].Position.Line)
+	})
+
+	t.Run("success,lenient", func(t *testing.T) {
+		t.Parallel()
+
+		input := "-- read only\nGRANT SELECT ON TABLE users TO readonly;\nCREATE TABLE users (id UUID NOT NULL);\nCREATE ROLE readonly;\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnsupportedStatement)
+
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Comment: "read only", Raw: "GRANT SELECT ON TABLE users TO readonly"}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: "CREATE ROLE readonly"}, d.Stmts[2])
+		if !assert.Equal(t, 2, len(p.Warnings())) {
+			t.FailNow()
+		}
+		assert.Equal(t, "GRANT", p.Warnings()[0].Token)
+		assert.Equal(t, "ROLE", p.Warnings()[1].Token)
+		require.ErrorIs(t, p.Warnings()[1], ddl.ErrUnsupportedStatement)
+	})
+
+	t.Run("success,lenient,function_body", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at := now(); -- not a statement;
+  RETURN NEW;
+END;
+$$ LANGUAGE PLpgSQL;
+CREATE TABLE users (id UUID NOT NULL);
+CREATE FUNCTION f() RETURNS STRING AS $body$ SELECT e'it\'s; ok' $body$ LANGUAGE SQL;
+`
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at := now(); -- not a statement;
+  RETURN NEW;
+END;
+$$ LANGUAGE PLpgSQL`}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION f() RETURNS STRING AS $body$ SELECT e'it\'s; ok' $body$ LANGUAGE SQL`}, d.Stmts[2])
+		assert.Equal(t, 2, len(p.Warnings()))
+	})
 }
 
 func TestParser_parseColumn(t *testing.T) {
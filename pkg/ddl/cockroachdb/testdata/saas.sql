@@ -0,0 +1,38 @@
+-- A dump of the schema of a multi-tenant SaaS application.
+CREATE TYPE public.plan AS ENUM ('free', 'team', 'enterprise');
+
+CREATE TABLE public.tenants (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    plan public.plan NOT NULL DEFAULT 'free',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT tenants_pkey PRIMARY KEY (id),
+    UNIQUE INDEX tenants_name_unique (name)
+);
+
+CREATE TABLE public.users (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    display_name TEXT,
+    age INTEGER CHECK (age >= 0),
+    is_admin BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES public.tenants (id) ON DELETE CASCADE,
+    UNIQUE INDEX users_email_unique (tenant_id, email)
+);
+
+CREATE INDEX users_idx_on_tenant_id_created_at ON public.users (tenant_id, created_at);
+
+CREATE TABLE public.invoices (
+    id BIGINT NOT NULL DEFAULT unique_rowid(),
+    tenant_id UUID NOT NULL REFERENCES public.tenants (id),
+    amount NUMERIC(12, 2) NOT NULL,
+    issued_on DATE NOT NULL,
+    note TEXT DEFAULT '',
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_unique_tenant_id_issued_on ON public.invoices (tenant_id, issued_on);
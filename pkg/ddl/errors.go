@@ -2,6 +2,7 @@ package ddl
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
@@ -10,4 +11,6 @@ var (
 	ErrNoDifference            = errors.New("no difference")
 	ErrNotSupported            = errors.New("not supported")
 	ErrAlterOptionNotSupported = errors.New("alter option not supported")
+	// ErrUnsupportedStatement wraps ErrUnexpectedCurrentToken, because an unsupported statement has been reported as an unexpected token.
+	ErrUnsupportedStatement = fmt.Errorf("%w: unsupported statement", ErrUnexpectedCurrentToken)
 )
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FuzzSeeds returns the seeds for the fuzz targets in dir: the string literals that contain CREATE in the *_test.go files,
+// which are the fixtures of the tests, and the contents of the testdata/*.sql files, which are dumps of real-world schemas.
+func FuzzSeeds(dir string) ([]string, error) {
+	seeds := make([]string, 0)
+
+	testFiles, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	fset := token.NewFileSet()
+	for _, testFile := range testFiles {
+		file, err := parser.ParseFile(fset, testFile, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			lit, ok := node.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			if s, err := strconv.Unquote(lit.Value); err == nil && strings.Contains(strings.ToUpper(s), "CREATE") {
+				seeds = append(seeds, s)
+			}
+			return true
+		})
+	}
+
+	dumps, err := filepath.Glob(filepath.Join(dir, "testdata", "*.sql"))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	for _, dump := range dumps {
+		b, err := os.ReadFile(dump)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		seeds = append(seeds, string(b))
+	}
+
+	return seeds, nil
+}
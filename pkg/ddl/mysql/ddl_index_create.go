@@ -5,6 +5,7 @@ import (
 
 	"github.com/hakadoriya/z.go/stringz"
 
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
 )
 
@@ -21,6 +22,8 @@ type CreateIndexStmt struct {
 	TableName   *ObjectName
 	Using       []*Ident
 	Columns     []*ColumnIdent
+	// Pos is the position of the CREATE keyword.
+	Pos ddl.Position
 }
 
 func (s *CreateIndexStmt) GetNameForDiff() string {
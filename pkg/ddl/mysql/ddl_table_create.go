@@ -1,8 +1,10 @@
 package mysql
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
 )
 
@@ -18,14 +20,24 @@ type CreateTableStmt struct {
 	Columns     []*Column
 	Constraints Constraints
 	Options     Options
+	// Footer is the comments before the closing parenthesis.
+	Footer []string
+	// Pos is the position of the CREATE keyword.
+	Pos ddl.Position
 }
 
 func (s *CreateTableStmt) GetNameForDiff() string {
 	return s.Name.StringForDiff()
 }
 
-//nolint:cyclop
 func (s *CreateTableStmt) String() string {
+	return s.string(0)
+}
+
+// string returns the statement with the column names padded to columnNameWidth characters.
+//
+//nolint:cyclop,gocognit
+func (s *CreateTableStmt) string(columnNameWidth int) string {
 	var str string
 	if s.Comment != "" {
 		comments := strings.Split(s.Comment, "\n")
@@ -43,24 +55,37 @@ func (s *CreateTableStmt) String() string {
 	lastIndex := len(s.Columns) - 1
 	hasConstraint := len(s.Constraints) > 0
 	for i, v := range s.Columns {
+		for _, comment := range v.Comments {
+			if comment != "" {
+				str += Indent + CommentPrefix + comment + "\n"
+			}
+		}
 		str += Indent
-		str += v.String()
+		str += fmt.Sprintf("%-*s", columnNameWidth, v.Name.String()) + strings.TrimPrefix(v.String(), v.Name.String())
 		if i != lastIndex || hasConstraint {
-			str += ",\n"
-		} else {
-			str += "\n"
+			str += ","
 		}
+		str += trailingCommentString(v.TrailingComment) + "\n"
 	}
 	if len(s.Constraints) > 0 {
 		lastConstraint := len(s.Constraints) - 1
 		for i, v := range s.Constraints {
+			for _, comment := range v.GetComments() {
+				if comment != "" {
+					str += Indent + CommentPrefix + comment + "\n"
+				}
+			}
 			str += Indent
 			str += v.String()
 			if i != lastConstraint {
-				str += ",\n"
-			} else {
-				str += "\n"
+				str += ","
 			}
+			str += trailingCommentString(v.GetTrailingComment()) + "\n"
+		}
+	}
+	for _, comment := range s.Footer {
+		if comment != "" {
+			str += Indent + CommentPrefix + comment + "\n"
 		}
 	}
 	str += ")"
@@ -74,3 +99,11 @@ func (s *CreateTableStmt) String() string {
 
 func (*CreateTableStmt) isStmt()            {}
 func (s *CreateTableStmt) GoString() string { return internal.GoString(*s) }
+
+// trailingCommentString returns comment to be written on the same line after an element of CREATE TABLE.
+func trailingCommentString(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	return " " + CommentPrefix + comment
+}
@@ -13,6 +13,7 @@ import (
 
 //nolint:funlen,cyclop,gocognit
 func Diff(before, after *DDL) (*DDL, error) {
+	before, after = withoutRawStmts(before), withoutRawStmts(after)
 	result := &DDL{}
 
 	switch {
@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+func stringForDiff(d *DDL) string {
+	var str string
+	for _, stmt := range d.Stmts {
+		if s, ok := stmt.(interface{ StringForDiff() string }); ok {
+			str += s.StringForDiff()
+			continue
+		}
+		str += stmt.String()
+	}
+	return str
+}
+
+// FuzzParse checks that Parse(ddl.String()) yields the DDL equal to ddl for diff, and that Diff does not panic.
+func FuzzParse(f *testing.F) {
+	seeds, err := internal.FuzzSeeds(".")
+	if err != nil {
+		f.Fatalf("❌: internal.FuzzSeeds: %v", err)
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		before, err := NewParser(NewLexer(input)).Parse()
+		if err != nil {
+			return
+		}
+
+		after, err := NewParser(NewLexer(before.String())).Parse()
+		if err != nil {
+			t.Fatalf("❌: Parse(String()): %v\ninput:\n%s\nString():\n%s", err, input, before)
+		}
+		if expected, actual := stringForDiff(before), stringForDiff(after); expected != actual {
+			t.Fatalf("❌: StringForDiff: not equal:\ninput:\n%s\nexpected:\n%s\nactual:\n%s", input, expected, actual)
+		}
+
+		if result, err := Diff(before, after); !errors.Is(err, ddl.ErrNoDifference) {
+			t.Fatalf("❌: Diff: expected ErrNoDifference, but got err=%v:\ninput:\n%s\nresult:\n%s", err, input, result)
+		}
+		_, _ = Diff(&DDL{}, before)
+		_, _ = Diff(before, &DDL{})
+		_, _ = Diff(nil, before)
+		_, _ = Diff(before, nil)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 )
 
 func Test_lookupIdent(t *testing.T) {
@@ -114,67 +116,67 @@ func TestLex(t *testing.T) {
     UNIQUE ("email")
 );`,
 			want: []Token{
-				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}},
-				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}},
-				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "36"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}},
-				{Type: TOKEN_DATETIME, Literal: Literal{Str: "DATETIME"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}},
-				{Type: TOKEN_DATETIME, Literal: Literal{Str: "DATETIME"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}},
-				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}},
+				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}, Pos: ddl.Position{Offset: 0, Line: 1, Column: 1}},
+				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}, Pos: ddl.Position{Offset: 7, Line: 1, Column: 8}},
+				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}, Pos: ddl.Position{Offset: 13, Line: 1, Column: 14}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 16, Line: 1, Column: 17}},
+				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}, Pos: ddl.Position{Offset: 20, Line: 1, Column: 21}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}, Pos: ddl.Position{Offset: 27, Line: 1, Column: 28}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 35, Line: 1, Column: 36}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 41, Line: 2, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 54, Line: 2, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 61, Line: 2, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "36"}, Pos: ddl.Position{Offset: 62, Line: 2, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 64, Line: 2, Column: 28}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 67, Line: 2, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 71, Line: 2, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 75, Line: 2, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}, Pos: ddl.Position{Offset: 81, Line: 3, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 94, Line: 3, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 101, Line: 3, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 102, Line: 3, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 105, Line: 3, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 107, Line: 3, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 111, Line: 3, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 115, Line: 3, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 121, Line: 4, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 134, Line: 4, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 141, Line: 4, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 142, Line: 4, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 145, Line: 4, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 147, Line: 4, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 151, Line: 4, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 155, Line: 4, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}, Pos: ddl.Position{Offset: 161, Line: 5, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 174, Line: 5, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 181, Line: 5, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 182, Line: 5, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 185, Line: 5, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 187, Line: 5, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 191, Line: 5, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 195, Line: 5, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}, Pos: ddl.Position{Offset: 201, Line: 6, Column: 5}},
+				{Type: TOKEN_DATETIME, Literal: Literal{Str: "DATETIME"}, Pos: ddl.Position{Offset: 214, Line: 6, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 227, Line: 6, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 231, Line: 6, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 235, Line: 6, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}, Pos: ddl.Position{Offset: 241, Line: 7, Column: 5}},
+				{Type: TOKEN_DATETIME, Literal: Literal{Str: "DATETIME"}, Pos: ddl.Position{Offset: 254, Line: 7, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 267, Line: 7, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 271, Line: 7, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 275, Line: 7, Column: 39}},
+				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}, Pos: ddl.Position{Offset: 281, Line: 8, Column: 5}},
+				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}, Pos: ddl.Position{Offset: 289, Line: 8, Column: 13}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 293, Line: 8, Column: 17}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 294, Line: 8, Column: 18}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 303, Line: 8, Column: 27}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 304, Line: 8, Column: 28}},
+				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}, Pos: ddl.Position{Offset: 310, Line: 9, Column: 5}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 317, Line: 9, Column: 12}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 318, Line: 9, Column: 13}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 325, Line: 9, Column: 20}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 327, Line: 10, Column: 1}},
+				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}, Pos: ddl.Position{Offset: 328, Line: 10, Column: 2}},
 			},
 		},
 	}
@@ -224,6 +226,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_MINUS,
 				Literal: Literal{Str: "-"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -232,6 +235,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "|"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -240,6 +244,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: ":"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -248,6 +253,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "!"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 	}
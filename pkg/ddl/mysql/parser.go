@@ -3,12 +3,14 @@ package mysql
 // MEMO: https://dev.mysql.com/doc/refman/8.0/en/create-table-check-constraints.html
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/hakadoriya/z.go/pathz/filepathz"
-	"github.com/hakadoriya/z.go/stringz"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
 
@@ -45,45 +47,108 @@ func NewIdent(name, quotationMark, raw string) *Ident {
 	}
 }
 
+//nolint:gochecknoglobals
+var plainIdentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// newDerivedIdent returns the identifier of name derived from other identifiers, such as a constraint name derived from the table name.
+// It is quoted unless name is a plain identifier, so that the result of String can be parsed again.
+func newDerivedIdent(name string) *Ident {
+	if plainIdentRegex.MatchString(name) {
+		return NewRawIdent(name)
+	}
+	return NewIdent(name, "`", "`"+name+"`")
+}
+
 // Parser はSQL文を解析するパーサーです。
 type Parser struct {
 	l            *Lexer
 	currentToken Token
 	peekToken    Token
+	lenient      bool
+	warnings     []*ddl.ParseError
+	// collecting reports whether nextToken collects the comments of the tokens into collected.
+	collecting bool
+	collected  []string
+}
+
+type ParserOption interface {
+	apply(p *Parser)
+}
+
+// ParserUseLenient makes Parse keep the statements that it does not support, such as GRANT, as RawStmt
+// and report them as Warnings, instead of returning errors.
+func ParserUseLenient(lenient bool) ParserOption { //nolint:ireturn
+	return &parserUseLenient{
+		lenient: lenient,
+	}
+}
+
+type parserUseLenient struct {
+	lenient bool
+}
+
+func (o *parserUseLenient) apply(p *Parser) {
+	p.lenient = o.lenient
 }
 
 // NewParser は新しいParserを生成します。
-func NewParser(l *Lexer) *Parser {
+func NewParser(l *Lexer, opts ...ParserOption) *Parser {
 	p := &Parser{
 		l: l,
 	}
 
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
 	return p
 }
 
+// Warnings は lenient モードで読み飛ばした文の警告を返します。
+func (p *Parser) Warnings() []*ddl.ParseError {
+	return p.warnings
+}
+
 // nextToken は次のトークンを読み込みます。
 func (p *Parser) nextToken() {
 	p.currentToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+	if p.collecting {
+		p.collected = append(p.collected, p.currentToken.Comments...)
+	}
 
 	_, file, line, _ := runtime.Caller(1)
 	logs.Trace.Printf("🪲: nextToken: caller=%s:%d currentToken: %#v, peekToken: %#v", filepathz.ExtractShortPath(file), line, p.currentToken, p.peekToken)
 }
 
 // Parse はSQL文を解析します。
+// 解析できない文があっても次の文から解析を続け、すべてのエラーを ddl.ParseErrors として返します。
 func (p *Parser) Parse() (*DDL, error) { //nolint:ireturn
 	p.nextToken() // current = ""
 	p.nextToken() // current = CREATE or ALTER or ...
 
 	d := &DDL{}
+	var errs ddl.ParseErrors
+
+	// MEMO: the comments separated from the first statement by a blank line are not the comments of the statement.
+	for i := len(p.currentToken.Comments) - 1; i >= 0 && p.currentToken.Type != TOKEN_EOF; i-- {
+		if p.currentToken.Comments[i] == "" {
+			d.Header, p.currentToken.Comments = p.currentToken.Comments[:i], p.currentToken.Comments[i+1:]
+			break
+		}
+	}
 
 LabelDDL:
 	for {
 		switch p.currentToken.Type { //nolint:exhaustive
 		case TOKEN_CREATE:
+			start := p.currentToken
 			stmt, err := p.parseCreateStatement()
 			if err != nil {
-				return nil, apperr.Errorf("parseCreateStatement: %w", err)
+				if err := p.recoverStmt(d, start, apperr.Errorf("parseCreateStatement: %w", err)); err != nil {
+					errs = append(errs, err)
+				}
+				break
 			}
 			d.Stmts = append(d.Stmts, stmt)
 		case TOKEN_CLOSE_PAREN:
@@ -91,17 +156,85 @@ LabelDDL:
 		case TOKEN_SEMICOLON:
 			// do nothing
 		case TOKEN_EOF:
+			d.Comments = p.currentToken.Comments
 			break LabelDDL
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			if err := p.recoverStmt(d, p.currentToken, p.newParseError(p.currentToken, ddl.ErrUnsupportedStatement)); err != nil {
+				errs = append(errs, err)
+			}
 		}
 
 		p.nextToken()
 	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return d, nil
 }
 
+// recoverStmt は解析できなかった文を ; または EOF まで読み飛ばします。
+// lenient モードではサポートしていない文を RawStmt として d に追加して警告とし、それ以外の場合は err を返します。
+func (p *Parser) recoverStmt(d *DDL, start Token, err error) error {
+	end := start.Pos.Offset + len(start.Literal.Str)
+	for !p.isCurrentToken(TOKEN_SEMICOLON) && !p.isCurrentToken(TOKEN_EOF) {
+		end = max(end, p.currentToken.Pos.Offset+len(p.currentToken.Literal.Str))
+		p.nextToken()
+	}
+
+	var parseErr *ddl.ParseError
+	if !p.lenient || !errors.Is(err, ddl.ErrUnsupportedStatement) || !errors.As(err, &parseErr) {
+		return err
+	}
+	p.warnings = append(p.warnings, parseErr)
+	d.Stmts = append(d.Stmts, &RawStmt{
+		Comment: strings.Join(start.Comments, "\n"),
+		Raw:     p.l.input[start.Pos.Offset:end],
+	})
+	return nil
+}
+
+// trailingComment returns the comment on the same line after the current token, which is "," or ")" after an element of CREATE TABLE,
+// and removes it from the comments of the token, so that it is not the comment that precedes the next element.
+func (p *Parser) trailingComment() string {
+	for _, token := range []*Token{&p.currentToken, &p.peekToken} {
+		if token.HasTrailingComment && len(token.Comments) > 0 {
+			comment := token.Comments[0]
+			token.Comments, token.HasTrailingComment = token.Comments[1:], false
+			return comment
+		}
+		if token.Type != TOKEN_COMMA {
+			break
+		}
+	}
+	return ""
+}
+
+// collectComments starts to collect the comments of the tokens after the current one, which is the first token of an element of CREATE TABLE.
+func (p *Parser) collectComments() {
+	p.collecting, p.collected = true, nil
+}
+
+// innerComments stops collecting the comments, and returns the ones inside the element without the blank lines.
+// The comments of the current token, which is "," or ")" after the element, are not inside the element,
+// because they are the trailing comment of the element or the ones that precede the next element.
+func (p *Parser) innerComments() []string {
+	p.collecting = false
+	collected := p.collected[:max(0, len(p.collected)-len(p.currentToken.Comments))]
+	p.collected = nil
+
+	var comments []string
+	for _, comment := range collected {
+		if comment != "" {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
+
 func (p *Parser) parseCreateStatement() (Stmt, error) { //nolint:ireturn
+	comment := strings.Join(p.currentToken.Comments, "\n")
+	pos := p.currentToken.Pos
 	p.nextToken() // current = TABLE or INDEX or ...
 
 	switch p.currentToken.Type { //nolint:exhaustive
@@ -110,15 +243,19 @@ func (p *Parser) parseCreateStatement() (Stmt, error) { //nolint:ireturn
 		if err != nil {
 			return nil, apperr.Errorf("parseCreateTableStmt: %w", err)
 		}
+		stmt.Comment = comment
+		stmt.Pos = pos
 		return stmt, nil
 	case TOKEN_INDEX, TOKEN_UNIQUE:
 		stmt, err := p.parseCreateIndexStmt()
 		if err != nil {
 			return nil, apperr.Errorf("parseCreateIndexStmt: %w", err)
 		}
+		stmt.Comment = comment
+		stmt.Pos = pos
 		return stmt, nil
 	default:
-		return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, p.newParseError(p.currentToken, ddl.ErrUnsupportedStatement)
 	}
 }
 
@@ -161,10 +298,18 @@ LabelColumns:
 	for {
 		switch { //nolint:exhaustive
 		case p.isCurrentToken(TOKEN_IDENT):
+			comments := p.currentToken.Comments
+			pos := p.currentToken.Pos
+			p.collectComments()
 			column, constraints, err := p.parseColumn(createTableStmt.Name.Name)
+			inner := p.innerComments()
 			if err != nil {
 				return nil, apperr.Errorf(errFmtPrefix+"parseColumn: %w", err)
 			}
+			// MEMO: the comments inside the column definition, such as the one before NOT NULL on the next line, precede the column.
+			column.Comments = append(slices.Clip(comments), inner...)
+			column.TrailingComment = p.trailingComment()
+			column.Pos = pos
 			createTableStmt.Columns = append(createTableStmt.Columns, column)
 			if len(constraints) > 0 {
 				for _, c := range constraints {
@@ -176,15 +321,19 @@ LabelColumns:
 			if err != nil {
 				return nil, apperr.Errorf(errFmtPrefix+"parseTableConstraint: %w", err)
 			}
+			constraint.setTrailingComment(p.trailingComment())
 			createTableStmt.Constraints = createTableStmt.Constraints.Append(constraint)
 		case p.isCurrentToken(TOKEN_COMMA):
+			// MEMO: the comments before "," except the trailing comment of the previous element precede the next element.
+			p.peekToken.Comments = append(slices.Clip(p.currentToken.Comments), p.peekToken.Comments...)
 			p.nextToken()
 			continue
 		case p.isCurrentToken(TOKEN_CLOSE_PAREN):
+			createTableStmt.Footer = p.currentToken.Comments
 			p.nextToken()
 			break LabelColumns
 		default:
-			return nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 		}
 	}
 
@@ -254,7 +403,7 @@ LabelTableOptions:
 		case TOKEN_SEMICOLON, TOKEN_EOF:
 			break LabelTableOptions
 		default:
-			return nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 		}
 		createTableStmt.Options = append(createTableStmt.Options, opt)
 		p.nextToken()
@@ -383,7 +532,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 				case TOKEN_CASCADE, TOKEN_RESTRICT, TOKEN_CURRENT_TIMESTAMP:
 					column.OnAction += " " + p.currentToken.Literal.String()
 				default:
-					return nil, nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+					return nil, nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 				}
 			case TOKEN_CHARACTER:
 				p.nextToken() // current = SET
@@ -420,7 +569,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 			p.nextToken() // current = COMMA or CLOSE_PAREN
 		}
 	default:
-		return nil, nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 	}
 
 	return column, constraints, nil
@@ -458,14 +607,14 @@ LabelDefault:
 			// 	continue
 			// }
 			// if isDataType(p.currentToken.Type) {
-			// 	def.Value.Idents = append(def.Value.Idents, NewRawIdent(p.currentToken.Literal.Str))
+			// 	def.Value = def.Value.Append(NewRawIdent(p.currentToken.Literal.Str))
 			// 	p.nextToken()
 			// 	continue
 			// }
 			if isConstraint(p.currentToken.Type) {
 				break LabelDefault
 			}
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -507,7 +656,7 @@ LabelExpr:
 			}
 			idents = append(idents, NewRawIdent(value))
 		case TOKEN_EOF:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		default:
 			if isReservedValue(p.currentToken.Type) {
 				idents = append(idents, NewRawIdent(p.currentToken.Type.String()))
@@ -544,7 +693,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = table_name
 			constraint := &ForeignKeyConstraint{
-				Name:    NewRawIdent(fmt.Sprintf("%s_%s_fkey", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name:    newDerivedIdent(fmt.Sprintf("%s_%s_fkey", tableName.StringForDiff(), column.Name.StringForDiff())),
 				Ref:     NewRawIdent(p.currentToken.Literal.Str),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			}
@@ -571,10 +720,12 @@ LabelConstraints:
 
 			constraint.RefColumns = idents
 			constraints = constraints.Append(constraint)
+			// MEMO: the current token is already the one after the column constraint.
+			continue
 		case TOKEN_UNIQUE:
 			constraints = constraints.Append(&IndexConstraint{
 				Unique:  true,
-				Name:    NewRawIdent(fmt.Sprintf("%s_unique_%s", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name:    newDerivedIdent(fmt.Sprintf("%s_unique_%s", tableName.StringForDiff(), column.Name.StringForDiff())),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			})
 		case TOKEN_CHECK:
@@ -583,7 +734,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = (
 			constraint := &CheckConstraint{
-				Name: NewRawIdent(fmt.Sprintf("%s_%s_check", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name: newDerivedIdent(fmt.Sprintf("%s_%s_check", tableName.StringForDiff(), column.Name.StringForDiff())),
 			}
 			idents, err := p.parseExpr()
 			if err != nil {
@@ -594,7 +745,7 @@ LabelConstraints:
 		case TOKEN_IDENT, TOKEN_COMMA, TOKEN_CLOSE_PAREN, TOKEN_COMMENT:
 			break LabelConstraints
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -605,11 +756,13 @@ LabelConstraints:
 
 //nolint:funlen,cyclop,gocognit
 func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //nolint:ireturn
+	comments := p.currentToken.Comments
+	pos := p.currentToken.Pos
 	var constraintName *Ident
 	if p.isCurrentToken(TOKEN_CONSTRAINT) {
 		p.nextToken() // current = constraint_name
 		if p.currentToken.Type != TOKEN_IDENT {
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 		constraintName = NewRawIdent(p.currentToken.Literal.Str)
 		p.nextToken() // current = PRIMARY or CHECK
@@ -630,8 +783,10 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 			return nil, apperr.Errorf("parseColumnIdents: %w", err)
 		}
 		return &PrimaryKeyConstraint{
-			Name:    NewRawIdent("PRIMARY KEY"),
-			Columns: idents,
+			Comments: comments,
+			Pos:      pos,
+			Name:     NewRawIdent("PRIMARY KEY"),
+			Columns:  idents,
 		}, nil
 	case TOKEN_FOREIGN:
 		if err := p.checkPeekToken(TOKEN_KEY); err != nil {
@@ -684,9 +839,11 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 				name += "_" + ident.StringForDiff()
 			}
 			name += "_fkey"
-			constraintName = NewRawIdent(name)
+			constraintName = newDerivedIdent(name)
 		}
 		return &ForeignKeyConstraint{
+			Comments:   comments,
+			Pos:        pos,
 			Name:       constraintName,
 			Columns:    idents,
 			Ref:        refName,
@@ -716,6 +873,8 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 		if err != nil {
 			return nil, apperr.Errorf("parseColumnIdents: %w", err)
 		}
+		c.Comments = comments
+		c.Pos = pos
 		c.Name = constraintName
 		c.Columns = idents
 		return c, nil
@@ -731,13 +890,15 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 		}
 		if constraintName == nil {
 			// TODO: handle CONSTRAINT name
-			constraintName = NewRawIdent(tableName.StringForDiff() + "_chk")
+			constraintName = newDerivedIdent(tableName.StringForDiff() + "_chk")
 		}
+		constraint.Comments = comments
+		constraint.Pos = pos
 		constraint.Name = constraintName
 		constraint.Expr = constraint.Expr.Append(idents...)
 		return constraint, nil
 	default:
-		return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 	}
 }
 
@@ -825,7 +986,7 @@ LabelIdents:
 			p.nextToken()
 			break LabelIdents
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 		p.nextToken()
 	}
@@ -874,7 +1035,7 @@ LabelIdents:
 		case TOKEN_CLOSE_PAREN:
 			break LabelIdents
 		case TOKEN_EOF, TOKEN_ILLEGAL:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		default:
 			idents = append(idents, NewRawIdent(p.currentToken.Literal.Str))
 		}
@@ -958,7 +1119,7 @@ func (p *Parser) checkCurrentToken(expectedTypes ...TokenType) error {
 			return nil
 		}
 	}
-	return apperr.Errorf("currentToken=%#v, peekToken=%#v: expected=%v, but got=%v: %w", p.currentToken, p.peekToken, stringz.JoinStringers(",", expectedTypes...), p.currentToken.Type, ddl.ErrUnexpectedCurrentToken)
+	return p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken, expectedTypes...)
 }
 
 func (p *Parser) isPeekToken(expectedTypes ...TokenType) bool {
@@ -976,5 +1137,20 @@ func (p *Parser) checkPeekToken(expectedTypes ...TokenType) error {
 			return nil
 		}
 	}
-	return apperr.Errorf("currentToken=%#v, peekToken=%#v: expected=%v, but got=%v: %w", p.currentToken, p.peekToken, stringz.JoinStringers(",", expectedTypes...), p.peekToken.Type, ddl.ErrUnexpectedPeekToken)
+	return p.newParseError(p.peekToken, ddl.ErrUnexpectedPeekToken, expectedTypes...)
+}
+
+// newParseError returns the error for the unexpected token, with its position and the expected token types.
+func (p *Parser) newParseError(token Token, err error, expectedTypes ...TokenType) *ddl.ParseError {
+	expected := make([]string, 0, len(expectedTypes))
+	for _, expectedType := range expectedTypes {
+		expected = append(expected, expectedType.String())
+	}
+	return &ddl.ParseError{
+		Position: token.Pos,
+		Token:    token.Literal.Str,
+		Expected: expected,
+		Source:   p.l.input,
+		Err:      err,
+	}
 }
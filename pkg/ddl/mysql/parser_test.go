@@ -56,6 +56,48 @@ CREATE TABLE ` + "`" + `users` + "`" + ` (
 		t.Logf("✅: %s: actual: %%s: \n%s", t.Name(), actual)
 	})
 
+	t.Run("success,REFERENCES_before_table_constraint", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE users (id INT NOT NULL, group_id INT REFERENCES groups (id), PRIMARY KEY (id));`
+		expected := `CREATE TABLE users (
+    id INT NOT NULL,
+    group_id INT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id)
+);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,Pos", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE users (
+    id INT NOT NULL,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX users_idx_id ON users (id);`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		table := actual.Stmts[0].(*CreateTableStmt) //nolint:forcetypeassert
+		assert.Equal(t, "1:1", table.Pos.String())
+		assert.Equal(t, "2:5", table.Columns[0].Pos.String())
+		constraint := table.Constraints[0].(*PrimaryKeyConstraint) //nolint:forcetypeassert
+		assert.Equal(t, "3:5", constraint.Pos.String())
+		index := actual.Stmts[1].(*CreateIndexStmt) //nolint:forcetypeassert
+		assert.Equal(t, "6:1", index.Pos.String())
+	})
+
 	t.Run("success,complex_defaults", func(t *testing.T) {
 		// t.Parallel()
 
@@ -79,7 +121,9 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 		actual, err := p.Parse()
 		require.NoError(t, err)
 
-		expected := `CREATE TABLE IF NOT EXISTS complex_defaults (
+		expected := `-- table: complex_defaults
+CREATE TABLE IF NOT EXISTS complex_defaults (
+    -- id is the primary key.
     id INT NOT NULL,
     name VARCHAR(255) NOT NULL,
     age INT NULL DEFAULT 25,
@@ -539,6 +583,61 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 			require.ErrorIs(t, err, tt.wantErr)
 		})
 	}
+
+	t.Run("failure,ParseErrors", func(t *testing.T) {
+		t.Parallel()
+
+		input := "CREATE TABLE a (id INT NOT NOT);\nCREATE TABLE b (id INT);\nCREATE TABLE c (id INT NOT NOT);\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnexpectedPeekToken)
+		parseErrs := ddl.AsParseErrors(err)
+		if !assert.Equal(t, 2, len(parseErrs)) {
+			t.FailNow()
+		}
+		assert.Equal(t, 1, parseErrs[0].Position.Line)
+		assert.Equal(t, 3, parseErrs[1].Position.Line)
+	})
+
+	t.Run("success,lenient", func(t *testing.T) {
+		t.Parallel()
+
+		input := "-- read only\nGRANT SELECT ON users TO readonly;\nCREATE TABLE users (id INT NOT NULL);\nCREATE TRIGGER t BEFORE INSERT ON users FOR EACH ROW SET NEW.id = 1;\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnsupportedStatement)
+
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Comment: "read only", Raw: "GRANT SELECT ON users TO readonly"}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: "CREATE TRIGGER t BEFORE INSERT ON users FOR EACH ROW SET NEW.id = 1"}, d.Stmts[2])
+		if !assert.Equal(t, 2, len(p.Warnings())) {
+			t.FailNow()
+		}
+		assert.Equal(t, "GRANT", p.Warnings()[0].Token)
+		assert.Equal(t, "TRIGGER", p.Warnings()[1].Token)
+		require.ErrorIs(t, p.Warnings()[1], ddl.ErrUnsupportedStatement)
+	})
+
+	t.Run("success,lenient,function_body", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE FUNCTION hello(s CHAR(20)) RETURNS CHAR(50) DETERMINISTIC RETURN CONCAT('Hello; ', s, '\'s!');
+CREATE TABLE users (id INT NOT NULL);
+CREATE FUNCTION quoted() RETURNS TEXT DETERMINISTIC RETURN "say \"hi\"; bye";
+`
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION hello(s CHAR(20)) RETURNS CHAR(50) DETERMINISTIC RETURN CONCAT('Hello; ', s, '\'s!')`}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION quoted() RETURNS TEXT DETERMINISTIC RETURN "say \"hi\"; bye"`}, d.Stmts[2])
+		assert.Equal(t, 2, len(p.Warnings()))
+	})
 }
 
 func TestParser_parseColumn(t *testing.T) {
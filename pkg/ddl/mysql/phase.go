@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// PhasedDDL is the result of Diff split into the phases of the expand/contract migration.
+type PhasedDDL struct {
+	Expand   *DDL
+	Migrate  *DDL
+	Contract *DDL
+}
+
+// Phase returns the DDL of phase, or nil if phase is unknown.
+func (p *PhasedDDL) Phase(phase ddl.Phase) *DDL {
+	switch phase {
+	case ddl.PhaseExpand:
+		return p.Expand
+	case ddl.PhaseMigrate:
+		return p.Migrate
+	case ddl.PhaseContract:
+		return p.Contract
+	default:
+		return nil
+	}
+}
+
+// SplitPhases splits result, which is the result of Diff, into the phases of the expand/contract migration,
+// keeping the order of the statements in each phase except for the indexes in Expand, which come last.
+//
+// The additive statements go to Expand, and the destructive or restrictive ones, which the application before the deploy may not work with, go to Contract.
+// A statement that recreates an object dropped in result goes to Contract too, because the object still exists in Expand.
+// ADD COLUMN ... NOT NULL without a default is split into ADD COLUMN in Expand and MODIFY ... NOT NULL in Contract,
+// and Migrate has the template of the backfill between them, which is to be edited and run by hand.
+// Migrate also has the templates for the columns added to a table whose column is dropped, which are likely to replace it.
+//
+//nolint:cyclop,funlen
+func SplitPhases(result *DDL) *PhasedDDL {
+	phased := &PhasedDDL{Expand: &DDL{}, Migrate: &DDL{}, Contract: &DDL{}}
+	if result == nil {
+		return phased
+	}
+
+	dropped := make(map[string]bool)
+	droppedColumnTables := make(map[string]bool)
+	for _, stmt := range result.Stmts {
+		switch s := stmt.(type) {
+		case *DropTableStmt, *DropIndexStmt:
+			dropped[s.GetNameForDiff()] = true
+		case *AlterTableStmt:
+			if _, ok := s.Action.(*DropColumn); ok {
+				droppedColumnTables[s.GetNameForDiff()] = true
+			}
+		}
+	}
+
+	expand := func(stmt Stmt) { phased.Expand.Stmts = append(phased.Expand.Stmts, stmt) }
+	contract := func(stmt Stmt) { phased.Contract.Stmts = append(phased.Contract.Stmts, stmt) }
+	// MEMO: the indexes in Expand are created after the other statements, because they may be on the columns added in Expand.
+	indexes := make([]Stmt, 0)
+
+	for _, stmt := range result.Stmts {
+		switch s := stmt.(type) {
+		case *CreateTableStmt:
+			if dropped[s.GetNameForDiff()] {
+				contract(s)
+				continue
+			}
+			expand(s)
+		case *CreateIndexStmt:
+			// MEMO: a new unique index rejects the duplicates that the application before the deploy may write.
+			if s.Unique || dropped[s.GetNameForDiff()] {
+				contract(s)
+				continue
+			}
+			indexes = append(indexes, s)
+		case *AlterTableStmt:
+			a, ok := s.Action.(*AddColumn)
+			if !ok {
+				contract(s)
+				continue
+			}
+			if !a.Column.NotNull || a.Column.Default != nil || a.Column.AutoIncrement {
+				expand(s)
+				if droppedColumnTables[s.GetNameForDiff()] {
+					phased.Migrate.Stmts = append(phased.Migrate.Stmts, backfillTemplate(s.Name, a.Column.Name, "TODO: backfill "+a.Column.Name.String()+" from the dropped column of "+s.Name.String()+" if it replaces one"))
+				}
+				continue
+			}
+			column := *a.Column
+			column.NotNull = false
+			expand(&AlterTableStmt{Comment: s.Comment, Indent: s.Indent, Name: s.Name, Action: &AddColumn{Column: &column}})
+			phased.Migrate.Stmts = append(phased.Migrate.Stmts, backfillTemplate(s.Name, a.Column.Name, "TODO: backfill "+a.Column.Name.String()+" before the contract phase, which sets NOT NULL"))
+			// MEMO: MODIFY needs the whole column definition, otherwise the omitted attributes are reset.
+			contract(&AlterTableStmt{Name: s.Name, Action: &ModifyColumn{
+				Name:          a.Column.Name,
+				DataType:      a.Column.DataType,
+				CharacterSet:  a.Column.CharacterSet,
+				Collate:       a.Column.Collate,
+				NotNull:       true,
+				AutoIncrement: a.Column.AutoIncrement,
+				Default:       a.Column.Default,
+				OnAction:      a.Column.OnAction,
+				Comment:       a.Column.Comment,
+			}})
+		default:
+			contract(s)
+		}
+	}
+	phased.Expand.Stmts = append(phased.Expand.Stmts, indexes...)
+
+	return phased
+}
+
+// backfillTemplate returns the template of UPDATE to backfill column, which is not valid SQL until <value> is replaced.
+func backfillTemplate(table *ObjectName, column *Ident, comment string) *RawStmt {
+	return &RawStmt{
+		Comment: comment,
+		Raw:     "UPDATE " + table.String() + " SET " + column.String() + " = <value> WHERE " + column.String() + " IS NULL",
+	}
+}
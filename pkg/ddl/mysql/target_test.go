@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestFilterTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,dependency", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, PRIMARY KEY (id));
+CREATE INDEX users_idx_name ON users (name);
+CREATE TABLE logs (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE teams (id INTEGER NOT NULL, PRIMARY KEY (id));
+CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, team_id INTEGER, PRIMARY KEY (id), CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id));
+CREATE TABLE logs (id INTEGER, message TEXT);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"USERS"})
+		require.NoError(t, err)
+		assert.Equal(t, `DROP INDEX users_idx_name;
+-- -
+-- +team_id INTEGER NULL
+ALTER TABLE users ADD COLUMN team_id INTEGER NULL;
+-- -
+-- +CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id)
+ALTER TABLE users ADD CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id);
+`, filtered.String())
+		assert.Equal(t, []string{`"CREATE TABLE teams (" is skipped, but "ALTER TABLE users ADD CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id);" depends on it`}, warnings)
+	})
+
+	t.Run("success,glob", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER);
+CREATE TABLE order_items (id INTEGER);
+CREATE INDEX order_items_idx_id ON order_items (id);
+CREATE TABLE orders (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"order_*"})
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE order_items (
+    id INTEGER NULL
+);
+CREATE INDEX order_items_idx_id ON order_items (id);
+`, filtered.String())
+		assert.Equal(t, 0, len(warnings))
+	})
+
+	t.Run("failure,pattern", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+
+		_, _, err = FilterTargets(nil, result, []string{"users["})
+		require.Error(t, err)
+	})
+}
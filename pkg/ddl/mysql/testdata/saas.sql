@@ -0,0 +1,34 @@
+-- A dump of the schema of a multi-tenant SaaS application.
+CREATE TABLE `tenants` (
+    `id` VARCHAR(36) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `plan` VARCHAR(16) NOT NULL DEFAULT 'free',
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `tenants_name_unique` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `users` (
+    `id` VARCHAR(36) NOT NULL,
+    `tenant_id` VARCHAR(36) NOT NULL,
+    `email` VARCHAR(255) NOT NULL,
+    `display_name` TEXT,
+    `age` INT,
+    `is_admin` TINYINT(1) NOT NULL DEFAULT 0,
+    `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `users_email_unique` (`tenant_id`, `email`),
+    KEY `users_idx_on_tenant_id_created_at` (`tenant_id`, `created_at`),
+    CONSTRAINT `users_tenant_id_fkey` FOREIGN KEY (`tenant_id`) REFERENCES `tenants` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `invoices` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `tenant_id` VARCHAR(36) NOT NULL,
+    `amount` DECIMAL(12, 2) NOT NULL,
+    `issued_on` DATE NOT NULL,
+    `note` VARCHAR(255) DEFAULT '',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `invoices_unique_tenant_id_issued_on` (`tenant_id`, `issued_on`)
+) ENGINE=InnoDB AUTO_INCREMENT=1000 DEFAULT CHARSET=utf8mb4;
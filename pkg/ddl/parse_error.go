@@ -0,0 +1,123 @@
+package ddl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position represents a position in the source.
+type Position struct {
+	// Offset is the byte offset, starting at 0.
+	Offset int
+	// Line is the line number, starting at 1.
+	Line int
+	// Column is the column number in characters, starting at 1.
+	Column int
+}
+
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// ParseError is the error of a statement that cannot be parsed.
+// It wraps ErrUnexpectedCurrentToken or ErrUnexpectedPeekToken,
+// or ErrUnsupportedStatement for the warnings of a statement skipped in the lenient mode.
+type ParseError struct {
+	// Filename is the name of the file the source is read from. It is empty unless the caller sets it.
+	Filename string
+	// Position is the position of the unexpected token.
+	Position Position
+	// Token is the literal of the unexpected token.
+	Token string
+	// Expected is the list of the token types expected instead, if known.
+	Expected []string
+	// Source is the whole source being parsed.
+	Source string
+	Err    error
+}
+
+// Location returns the location of the error in the form of file:line:column, which editors can jump to.
+func (e *ParseError) Location() string {
+	if e.Filename == "" {
+		return e.Position.String()
+	}
+	return e.Filename + ":" + e.Position.String()
+}
+
+func (e *ParseError) Error() string {
+	str := e.Location() + ": " + e.Err.Error()
+	if e.Token == "" {
+		str += " EOF"
+	} else {
+		str += " " + strconv.Quote(e.Token)
+	}
+	if len(e.Expected) > 0 {
+		str += ", expected " + strings.Join(e.Expected, " or ")
+	}
+	return str
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Excerpt returns the line of the source where the error occurred, with a caret pointing to the column.
+func (e *ParseError) Excerpt() string {
+	lines := strings.Split(e.Source, "\n")
+	if e.Position.Line < 1 || e.Position.Line > len(lines) {
+		return ""
+	}
+	line := strings.TrimRight(lines[e.Position.Line-1], "\r")
+
+	// MEMO: keep tabs in the padding, so that the caret is aligned with the line.
+	var padding string
+	for i, r := range line {
+		if utf8.RuneCountInString(line[:i]) >= e.Position.Column-1 {
+			break
+		}
+		if r == '\t' {
+			padding += "\t"
+		} else {
+			padding += " "
+		}
+	}
+
+	number := strconv.Itoa(e.Position.Line)
+	return fmt.Sprintf("%s | %s\n%s | %s^\n", number, line, strings.Repeat(" ", len(number)), padding)
+}
+
+// ParseErrors is the error returned by the parsers when one or more statements cannot be parsed.
+// Each error wraps a *ParseError.
+type ParseErrors []error
+
+func (e ParseErrors) Error() string {
+	strs := make([]string, 0, len(e))
+	for _, err := range e {
+		strs = append(strs, err.Error())
+	}
+	return strings.Join(strs, "\n")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	return e
+}
+
+// AsParseErrors returns the *ParseError of each statement that err reports, in order of appearance.
+func AsParseErrors(err error) []*ParseError {
+	var errs ParseErrors
+	if !errors.As(err, &errs) {
+		errs = ParseErrors{err}
+	}
+
+	parseErrs := make([]*ParseError, 0, len(errs))
+	for _, e := range errs {
+		var parseErr *ParseError
+		if errors.As(e, &parseErr) {
+			parseErrs = append(parseErrs, parseErr)
+		}
+	}
+	return parseErrs
+}
@@ -0,0 +1,19 @@
+package ddl
+
+// Phase is a phase of the expand/contract migration, which splits a schema change around the deploy of the application.
+type Phase string
+
+const (
+	// PhaseExpand is the additive changes, which are safe to apply before the deploy.
+	PhaseExpand Phase = "expand"
+	// PhaseMigrate is the data migration between expand and contract, such as backfills.
+	// It is generated as the templates to be edited and run by hand.
+	PhaseMigrate Phase = "migrate"
+	// PhaseContract is the destructive or restrictive changes, which are applied after the deploy.
+	PhaseContract Phase = "contract"
+)
+
+// AllPhases returns the phases in the order to be applied.
+func AllPhases() []Phase {
+	return []Phase{PhaseExpand, PhaseMigrate, PhaseContract}
+}
@@ -26,9 +26,12 @@ const (
 type Object string
 
 const (
-	ObjectTable Object = "TABLE"
-	ObjectIndex Object = "INDEX"
-	ObjectView  Object = "VIEW"
+	ObjectTable     Object = "TABLE"
+	ObjectIndex     Object = "INDEX"
+	ObjectView      Object = "VIEW"
+	ObjectType      Object = "TYPE"
+	ObjectDomain    Object = "DOMAIN"
+	ObjectExtension Object = "EXTENSION"
 )
 
 type Action string
@@ -47,14 +50,39 @@ type Stmt interface {
 }
 
 type DDL struct {
-	Stmts []Stmt
+	// Header is the comments at the beginning, which are separated from the first statement by a blank line.
+	Header []string
+	Stmts  []Stmt
+	// Comments is the comments after the last statement.
+	Comments []string
 }
 
 func (d *DDL) String() string {
 	if d == nil {
 		return ""
 	}
-	return stringz.JoinStringers("", d.Stmts...)
+	str := headerString(d.Header) + stringz.JoinStringers("", d.Stmts...)
+	for _, comment := range d.Comments {
+		if comment != "" {
+			str += CommentPrefix + comment + "\n"
+		}
+	}
+	return str
+}
+
+// headerString returns header followed by a blank line, or "" if header is empty.
+func headerString(header []string) string {
+	var str string
+	for _, comment := range header {
+		if comment != "" {
+			str += CommentPrefix + comment
+		}
+		str += "\n"
+	}
+	if str != "" {
+		str += "\n"
+	}
+	return str
 }
 
 type Ident struct {
@@ -117,9 +145,13 @@ func (s *DataType) StringForDiff() string {
 		return ""
 	}
 	var str string
-	if s.Type != "" {
+	switch {
+	case s.Type == TOKEN_IDENT:
+		// MEMO: user-defined types such as ENUM or DOMAIN are compared by name.
+		str += NewRawIdent(s.Name).StringForDiff()
+	case s.Type != "":
 		str += string(s.Type)
-	} else {
+	default:
 		str += string(TOKEN_ILLEGAL)
 	}
 
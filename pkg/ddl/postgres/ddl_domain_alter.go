@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-alterdomain.html
+
+var _ Stmt = (*AlterDomainStmt)(nil)
+
+type AlterDomainStmt struct {
+	Comment string
+	Name    *ObjectName
+	Action  AlterDomainAction
+}
+
+func (*AlterDomainStmt) isStmt() {}
+
+func (s *AlterDomainStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+//nolint:cyclop
+func (s *AlterDomainStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "ALTER DOMAIN "
+	str += s.Name.String() + " "
+	switch a := s.Action.(type) {
+	case *DomainSetDefault:
+		str += "SET " + a.Default.String()
+	case *DomainDropDefault:
+		str += "DROP DEFAULT"
+	case *DomainSetNotNull:
+		str += "SET NOT NULL"
+	case *DomainDropNotNull:
+		str += "DROP NOT NULL"
+	case *AddConstraint:
+		str += "ADD " + a.Constraint.String()
+		if a.NotValid {
+			str += " NOT VALID"
+		}
+	case *DropConstraint:
+		str += "DROP CONSTRAINT " + a.Name.String()
+	case *DomainRenameTo:
+		str += "RENAME TO " + a.NewName.String()
+	}
+
+	return str + ";\n"
+}
+
+func (s *AlterDomainStmt) GoString() string { return internal.GoString(*s) }
+
+type AlterDomainAction interface {
+	isAlterDomainAction()
+	GoString() string
+}
+
+// DomainSetDefault represents ALTER DOMAIN domain_name SET DEFAULT.
+type DomainSetDefault struct {
+	Default *Default
+}
+
+func (*DomainSetDefault) isAlterDomainAction() {}
+
+func (s *DomainSetDefault) GoString() string { return internal.GoString(*s) }
+
+// DomainDropDefault represents ALTER DOMAIN domain_name DROP DEFAULT.
+type DomainDropDefault struct{}
+
+func (*DomainDropDefault) isAlterDomainAction() {}
+
+func (s *DomainDropDefault) GoString() string { return internal.GoString(*s) }
+
+// DomainSetNotNull represents ALTER DOMAIN domain_name SET NOT NULL.
+type DomainSetNotNull struct{}
+
+func (*DomainSetNotNull) isAlterDomainAction() {}
+
+func (s *DomainSetNotNull) GoString() string { return internal.GoString(*s) }
+
+// DomainDropNotNull represents ALTER DOMAIN domain_name DROP NOT NULL.
+type DomainDropNotNull struct{}
+
+func (*DomainDropNotNull) isAlterDomainAction() {}
+
+func (s *DomainDropNotNull) GoString() string { return internal.GoString(*s) }
+
+// DomainRenameTo represents ALTER DOMAIN domain_name RENAME TO new_name.
+type DomainRenameTo struct {
+	NewName *Ident
+}
+
+func (*DomainRenameTo) isAlterDomainAction() {}
+
+func (s *DomainRenameTo) GoString() string { return internal.GoString(*s) }
+
+// AddConstraint also represents ALTER DOMAIN domain_name ADD CONSTRAINT.
+func (*AddConstraint) isAlterDomainAction() {}
+
+// DropConstraint also represents ALTER DOMAIN domain_name DROP CONSTRAINT.
+func (*DropConstraint) isAlterDomainAction() {}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-createdomain.html
+
+var _ Stmt = (*CreateDomainStmt)(nil)
+
+type CreateDomainStmt struct {
+	Comment     string
+	Name        *ObjectName
+	DataType    *DataType
+	Default     *Default
+	NotNull     bool
+	Constraints Constraints
+}
+
+func (s *CreateDomainStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *CreateDomainStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "CREATE DOMAIN " + s.Name.String() + " AS " + s.DataType.String()
+	if d := s.Default.String(); d != "" {
+		str += " " + d
+	}
+	if s.NotNull {
+		str += " NOT NULL"
+	}
+	for _, c := range s.Constraints {
+		str += " " + c.String()
+	}
+	str += ";\n"
+	return str
+}
+
+func (s *CreateDomainStmt) StringForDiff() string {
+	str := "CREATE DOMAIN " + s.Name.StringForDiff() + " AS " + s.DataType.StringForDiff()
+	if d := s.Default.StringForDiff(); d != "" {
+		str += " " + d
+	}
+	if s.NotNull {
+		str += " NOT NULL"
+	}
+	for _, c := range s.Constraints {
+		str += " " + c.StringForDiff()
+	}
+	str += ";\n"
+	return str
+}
+
+func (*CreateDomainStmt) isStmt()            {}
+func (s *CreateDomainStmt) GoString() string { return internal.GoString(*s) }
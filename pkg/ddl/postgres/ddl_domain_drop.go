@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-dropdomain.html
+
+var _ Stmt = (*DropDomainStmt)(nil)
+
+type DropDomainStmt struct {
+	Comment  string
+	IfExists bool
+	Name     *ObjectName
+}
+
+func (s *DropDomainStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *DropDomainStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "DROP DOMAIN "
+	if s.IfExists {
+		str += "IF EXISTS "
+	}
+	str += s.Name.String() + ";\n"
+	return str
+}
+
+func (*DropDomainStmt) isStmt()            {}
+func (s *DropDomainStmt) GoString() string { return internal.GoString(*s) }
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-alterextension.html
+
+var _ Stmt = (*AlterExtensionStmt)(nil)
+
+type AlterExtensionStmt struct {
+	Comment string
+	Name    *Ident
+	Action  AlterExtensionAction
+}
+
+func (*AlterExtensionStmt) isStmt() {}
+
+func (s *AlterExtensionStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *AlterExtensionStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "ALTER EXTENSION "
+	str += s.Name.String() + " "
+	switch a := s.Action.(type) {
+	case *UpdateTo:
+		str += "UPDATE TO " + a.Version.String()
+	case *SetSchema:
+		str += "SET SCHEMA " + a.Schema.String()
+	}
+
+	return str + ";\n"
+}
+
+func (s *AlterExtensionStmt) GoString() string { return internal.GoString(*s) }
+
+type AlterExtensionAction interface {
+	isAlterExtensionAction()
+	GoString() string
+}
+
+// UpdateTo represents ALTER EXTENSION extension_name UPDATE TO.
+type UpdateTo struct {
+	Version *Ident
+}
+
+func (*UpdateTo) isAlterExtensionAction() {}
+
+func (s *UpdateTo) GoString() string { return internal.GoString(*s) }
+
+// SetSchema represents ALTER EXTENSION extension_name SET SCHEMA.
+type SetSchema struct {
+	Schema *Ident
+}
+
+func (*SetSchema) isAlterExtensionAction() {}
+
+func (s *SetSchema) GoString() string { return internal.GoString(*s) }
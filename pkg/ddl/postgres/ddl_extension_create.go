@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-createextension.html
+
+var _ Stmt = (*CreateExtensionStmt)(nil)
+
+type CreateExtensionStmt struct {
+	Comment     string
+	IfNotExists bool
+	Name        *Ident
+	Schema      *Ident
+	Version     *Ident
+	Cascade     bool
+}
+
+func (s *CreateExtensionStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *CreateExtensionStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "CREATE EXTENSION "
+	if s.IfNotExists {
+		str += "IF NOT EXISTS "
+	}
+	str += s.Name.String()
+	if s.Schema != nil || s.Version != nil {
+		str += " WITH"
+	}
+	if s.Schema != nil {
+		str += " SCHEMA " + s.Schema.String()
+	}
+	if s.Version != nil {
+		str += " VERSION " + s.Version.String()
+	}
+	if s.Cascade {
+		str += " CASCADE"
+	}
+	str += ";\n"
+	return str
+}
+
+func (*CreateExtensionStmt) isStmt()            {}
+func (s *CreateExtensionStmt) GoString() string { return internal.GoString(*s) }
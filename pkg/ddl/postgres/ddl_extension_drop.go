@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-dropextension.html
+
+var _ Stmt = (*DropExtensionStmt)(nil)
+
+type DropExtensionStmt struct {
+	Comment  string
+	IfExists bool
+	Name     *Ident
+}
+
+func (s *DropExtensionStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *DropExtensionStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "DROP EXTENSION "
+	if s.IfExists {
+		str += "IF EXISTS "
+	}
+	str += s.Name.String() + ";\n"
+	return str
+}
+
+func (*DropExtensionStmt) isStmt()            {}
+func (s *DropExtensionStmt) GoString() string { return internal.GoString(*s) }
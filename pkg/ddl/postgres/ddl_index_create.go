@@ -5,6 +5,7 @@ import (
 
 	"github.com/hakadoriya/z.go/stringz"
 
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
 )
 
@@ -13,13 +14,16 @@ import (
 var _ Stmt = (*CreateIndexStmt)(nil)
 
 type CreateIndexStmt struct {
-	Comment     string
-	Unique      bool
-	IfNotExists bool
-	Name        *Ident
-	TableName   *ObjectName
-	Using       []*Ident
-	Columns     []*ColumnIdent
+	Comment      string
+	Unique       bool
+	Concurrently bool
+	IfNotExists  bool
+	Name         *Ident
+	TableName    *ObjectName
+	Using        []*Ident
+	Columns      []*ColumnIdent
+	// Pos is the position of the CREATE keyword.
+	Pos ddl.Position
 }
 
 func (s *CreateIndexStmt) GetNameForDiff() string {
@@ -41,6 +45,9 @@ func (s *CreateIndexStmt) String() string {
 		str += "UNIQUE "
 	}
 	str += "INDEX "
+	if s.Concurrently {
+		str += "CONCURRENTLY "
+	}
 	if s.IfNotExists {
 		str += "IF NOT EXISTS "
 	}
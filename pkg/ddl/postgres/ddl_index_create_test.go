@@ -48,4 +48,24 @@ CREATE INDEX IF NOT EXISTS "test" ON "users" USING btree ("id");
 
 		t.Logf("✅: %s: stmt: %#v", t.Name(), stmt)
 	})
+	t.Run("success,CONCURRENTLY", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &CreateIndexStmt{
+			Concurrently: true,
+			IfNotExists:  true,
+			Name:         &Ident{Name: "test", QuotationMark: `"`, Raw: `"test"`},
+			TableName:    &ObjectName{Name: &Ident{Name: "users", QuotationMark: `"`, Raw: `"users"`}},
+			Columns: []*ColumnIdent{
+				{
+					Ident: &Ident{Name: "id", QuotationMark: `"`, Raw: `"id"`},
+				},
+			},
+		}
+		expected := `CREATE INDEX CONCURRENTLY IF NOT EXISTS "test" ON "users" ("id");
+`
+		actual := stmt.String()
+
+		require.Equal(t, expected, actual)
+	})
 }
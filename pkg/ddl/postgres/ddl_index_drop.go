@@ -11,9 +11,10 @@ import (
 var _ Stmt = (*DropIndexStmt)(nil)
 
 type DropIndexStmt struct {
-	Comment  string
-	IfExists bool
-	Name     *Ident
+	Comment      string
+	Concurrently bool
+	IfExists     bool
+	Name         *Ident
 }
 
 func (s *DropIndexStmt) GetNameForDiff() string {
@@ -31,6 +32,9 @@ func (s *DropIndexStmt) String() string {
 		}
 	}
 	str += "DROP INDEX "
+	if s.Concurrently {
+		str += "CONCURRENTLY "
+	}
 	if s.IfExists {
 		str += "IF EXISTS "
 	}
@@ -40,4 +40,18 @@ DROP INDEX IF EXISTS "test";
 
 		t.Logf("✅: %s: stmt: %#v", t.Name(), stmt)
 	})
+	t.Run("success,CONCURRENTLY", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &DropIndexStmt{
+			Concurrently: true,
+			IfExists:     true,
+			Name:         &Ident{Name: "test", QuotationMark: `"`, Raw: `"test"`},
+		}
+		expected := `DROP INDEX CONCURRENTLY IF EXISTS "test";
+`
+		actual := stmt.String()
+
+		require.Equal(t, expected, actual)
+	})
 }
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+var _ Stmt = (*RawStmt)(nil)
+
+// RawStmt is a statement that the parser does not support, such as GRANT, kept as is in the lenient mode.
+// Diff ignores it.
+type RawStmt struct {
+	Comment string
+	Raw     string
+}
+
+func (s *RawStmt) GetNameForDiff() string {
+	return s.Raw
+}
+
+func (s *RawStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += s.Raw + ";\n"
+	return str
+}
+
+func (*RawStmt) isStmt()            {}
+func (s *RawStmt) GoString() string { return internal.GoString(*s) }
+
+// withoutRawStmts returns a copy of d without RawStmt.
+func withoutRawStmts(d *DDL) *DDL {
+	if d == nil {
+		return nil
+	}
+	result := *d
+	result.Stmts = make([]Stmt, 0, len(d.Stmts))
+	for _, stmt := range d.Stmts {
+		if _, ok := stmt.(*RawStmt); !ok {
+			result.Stmts = append(result.Stmts, stmt)
+		}
+	}
+	return &result
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-altersequence.html
+
+var _ Stmt = (*AlterSequenceStmt)(nil)
+
+// AlterSequenceStmt represents ALTER SEQUENCE sequence_name.
+type AlterSequenceStmt struct {
+	Comment string
+	Name    *ObjectName
+	Options SequenceOptions
+}
+
+func (*AlterSequenceStmt) isStmt() {}
+
+func (s *AlterSequenceStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *AlterSequenceStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "ALTER SEQUENCE "
+	str += s.Name.String() + " "
+	str += s.Options.String()
+
+	return str + ";\n"
+}
+
+func (s *AlterSequenceStmt) GoString() string { return internal.GoString(*s) }
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-createsequence.html
+
+var _ Stmt = (*CreateSequenceStmt)(nil)
+
+// CreateSequenceStmt represents CREATE SEQUENCE sequence_name.
+type CreateSequenceStmt struct {
+	Comment     string
+	IfNotExists bool
+	Name        *ObjectName
+	Options     SequenceOptions
+}
+
+func (s *CreateSequenceStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *CreateSequenceStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "CREATE SEQUENCE "
+	if s.IfNotExists {
+		str += "IF NOT EXISTS "
+	}
+	str += s.Name.String()
+	if len(s.Options) > 0 {
+		str += " " + s.Options.String()
+	}
+	str += ";\n"
+	return str
+}
+
+func (s *CreateSequenceStmt) StringForDiff() string {
+	str := "CREATE SEQUENCE " + s.Name.StringForDiff()
+	if len(s.Options) > 0 {
+		str += " " + s.Options.StringForDiff()
+	}
+	str += ";\n"
+	return str
+}
+
+func (*CreateSequenceStmt) isStmt()            {}
+func (s *CreateSequenceStmt) GoString() string { return internal.GoString(*s) }
+
+const (
+	SequenceOptionAs          = "AS"
+	SequenceOptionIncrementBy = "INCREMENT BY"
+	SequenceOptionMinValue    = "MINVALUE"
+	SequenceOptionMaxValue    = "MAXVALUE"
+	SequenceOptionStartWith   = "START WITH"
+	SequenceOptionCache       = "CACHE"
+	SequenceOptionCycle       = "CYCLE"
+	SequenceOptionOwnedBy     = "OWNED BY"
+
+	// sequenceOptionNoPrefix is the prefix of NO MINVALUE, NO MAXVALUE and NO CYCLE.
+	sequenceOptionNoPrefix = "NO "
+)
+
+// SequenceOption represents a sequence option such as INCREMENT BY 1 or NO CYCLE.
+type SequenceOption struct {
+	Name  string
+	Value *Ident
+}
+
+// Key returns the option name without NO, so that CYCLE and NO CYCLE are regarded as the same option.
+func (o *SequenceOption) Key() string {
+	return strings.TrimPrefix(o.Name, sequenceOptionNoPrefix)
+}
+
+func (o *SequenceOption) String() string {
+	if o.Value == nil {
+		return o.Name
+	}
+	return o.Name + " " + o.Value.String()
+}
+
+func (o *SequenceOption) StringForDiff() string {
+	if o.Value == nil {
+		return o.Name
+	}
+	return o.Name + " " + o.Value.StringForDiff()
+}
+
+func (o *SequenceOption) GoString() string { return internal.GoString(*o) }
+
+type SequenceOptions []*SequenceOption
+
+// Find returns the option whose Key is key, or nil.
+func (opts SequenceOptions) Find(key string) *SequenceOption {
+	for _, opt := range opts {
+		if opt.Key() == key {
+			return opt
+		}
+	}
+	return nil
+}
+
+func (opts SequenceOptions) String() string {
+	strs := make([]string, 0, len(opts))
+	for _, opt := range opts {
+		strs = append(strs, opt.String())
+	}
+	return strings.Join(strs, " ")
+}
+
+func (opts SequenceOptions) StringForDiff() string {
+	strs := make([]string, 0, len(opts))
+	for _, opt := range opts {
+		strs = append(strs, opt.StringForDiff())
+	}
+	return strings.Join(strs, " ")
+}
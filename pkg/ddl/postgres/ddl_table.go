@@ -5,12 +5,18 @@ import (
 
 	"github.com/hakadoriya/z.go/stringz"
 
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
 )
 
 type Constraint interface {
 	isConstraint()
 	GetName() *Ident
+	// GetComments returns the comments that precede the constraint in CREATE TABLE.
+	GetComments() []string
+	// GetTrailingComment returns the comment on the same line after the constraint in CREATE TABLE.
+	GetTrailingComment() string
+	setTrailingComment(comment string)
 	GoString() string
 	String() string
 	StringForDiff() string
@@ -31,15 +37,22 @@ func (constraints Constraints) Append(constraint Constraint) Constraints {
 
 // PrimaryKeyConstraint represents a PRIMARY KEY constraint.
 type PrimaryKeyConstraint struct {
-	Name    *Ident
-	Columns []*ColumnIdent
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Columns         []*ColumnIdent
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*PrimaryKeyConstraint)(nil)
 
-func (*PrimaryKeyConstraint) isConstraint()      {}
-func (c *PrimaryKeyConstraint) GetName() *Ident  { return c.Name }
-func (c *PrimaryKeyConstraint) GoString() string { return internal.GoString(*c) }
+func (*PrimaryKeyConstraint) isConstraint()                       {}
+func (c *PrimaryKeyConstraint) GetName() *Ident                   { return c.Name }
+func (c *PrimaryKeyConstraint) GetComments() []string             { return c.Comments }
+func (c *PrimaryKeyConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *PrimaryKeyConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *PrimaryKeyConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *PrimaryKeyConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -69,18 +82,25 @@ func (c *PrimaryKeyConstraint) StringForDiff() string {
 
 // ForeignKeyConstraint represents a FOREIGN KEY constraint.
 type ForeignKeyConstraint struct {
-	Name       *Ident
-	Columns    []*ColumnIdent
-	Ref        *Ident
-	RefColumns []*ColumnIdent
-	OnAction   string
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Columns         []*ColumnIdent
+	Ref             *Ident
+	RefColumns      []*ColumnIdent
+	OnAction        string
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*ForeignKeyConstraint)(nil)
 
-func (*ForeignKeyConstraint) isConstraint()      {}
-func (c *ForeignKeyConstraint) GetName() *Ident  { return c.Name }
-func (c *ForeignKeyConstraint) GoString() string { return internal.GoString(*c) }
+func (*ForeignKeyConstraint) isConstraint()                       {}
+func (c *ForeignKeyConstraint) GetName() *Ident                   { return c.Name }
+func (c *ForeignKeyConstraint) GetComments() []string             { return c.Comments }
+func (c *ForeignKeyConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *ForeignKeyConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *ForeignKeyConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *ForeignKeyConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -127,15 +147,22 @@ func (c *ForeignKeyConstraint) StringForDiff() string {
 
 // UniqueConstraint represents a UNIQUE constraint. //diff:ignore-line-postgres-cockroach.
 type UniqueConstraint struct { //diff:ignore-line-postgres-cockroach
-	Name    *Ident
-	Columns []*ColumnIdent
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Columns         []*ColumnIdent
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*UniqueConstraint)(nil) //diff:ignore-line-postgres-cockroach
 
-func (*UniqueConstraint) isConstraint()      {}                               //diff:ignore-line-postgres-cockroach
-func (c *UniqueConstraint) GetName() *Ident  { return c.Name }                //diff:ignore-line-postgres-cockroach
-func (c *UniqueConstraint) GoString() string { return internal.GoString(*c) } //diff:ignore-line-postgres-cockroach
+func (*UniqueConstraint) isConstraint()                       {}                //diff:ignore-line-postgres-cockroach
+func (c *UniqueConstraint) GetName() *Ident                   { return c.Name } //diff:ignore-line-postgres-cockroach
+func (c *UniqueConstraint) GetComments() []string             { return c.Comments }
+func (c *UniqueConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *UniqueConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *UniqueConstraint) GoString() string                  { return internal.GoString(*c) } //diff:ignore-line-postgres-cockroach
 func (c *UniqueConstraint) String() string { //diff:ignore-line-postgres-cockroach
 	var str string
 	if c.Name != nil { //diff:ignore-line-postgres-cockroach
@@ -165,15 +192,22 @@ func (c *UniqueConstraint) StringForDiff() string { //diff:ignore-line-postgres-
 
 // CheckConstraint represents a CHECK constraint.
 type CheckConstraint struct {
-	Name *Ident
-	Expr *Expr
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Expr            *Expr
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*CheckConstraint)(nil)
 
-func (*CheckConstraint) isConstraint()      {}
-func (c *CheckConstraint) GetName() *Ident  { return c.Name }
-func (c *CheckConstraint) GoString() string { return internal.GoString(*c) }
+func (*CheckConstraint) isConstraint()                       {}
+func (c *CheckConstraint) GetName() *Ident                   { return c.Name }
+func (c *CheckConstraint) GetComments() []string             { return c.Comments }
+func (c *CheckConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *CheckConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *CheckConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *CheckConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -243,12 +277,45 @@ func (t *ObjectName) StringForDiff() string {
 }
 
 type Column struct {
-	Name     *Ident
-	DataType *DataType
-	Default  *Default
-	NotNull  bool
+	// Comments is the comments that precede the column definition.
+	Comments []string
+	// TrailingComment is the comment on the same line after the column definition.
+	TrailingComment string
+	Name            *Ident
+	DataType        *DataType
+	Default         *Default
+	NotNull         bool
+	Identity        *Identity
+	// Pos is the position of the column name.
+	Pos ddl.Position
 }
 
+// Identity represents GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [ ( sequence_options ) ].
+type Identity struct {
+	Always  bool
+	Options SequenceOptions
+}
+
+func (i *Identity) String() string {
+	if i == nil {
+		return ""
+	}
+	str := "GENERATED " + i.generated() + " AS IDENTITY"
+	if len(i.Options) > 0 {
+		str += " (" + i.Options.String() + ")"
+	}
+	return str
+}
+
+func (i *Identity) generated() string {
+	if i.Always {
+		return "ALWAYS"
+	}
+	return "BY DEFAULT"
+}
+
+func (i *Identity) GoString() string { return internal.GoString(*i) }
+
 type Default struct {
 	Value *Expr
 }
@@ -330,6 +397,9 @@ func (c *Column) String() string {
 	if c.NotNull { //diff:ignore-line-postgres-cockroach
 		str += " NOT NULL" //diff:ignore-line-postgres-cockroach
 	}
+	if c.Identity != nil {
+		str += " " + c.Identity.String()
+	}
 	return str
 }
 
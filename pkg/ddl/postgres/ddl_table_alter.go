@@ -58,6 +58,21 @@ func (s *AlterTableStmt) String() string {
 		str += "ALTER COLUMN " + a.Name.String() + " SET NOT NULL"
 	case *AlterColumnDropNotNull:
 		str += "ALTER COLUMN " + a.Name.String() + " DROP NOT NULL"
+	case *AlterColumnAddIdentity:
+		str += "ALTER COLUMN " + a.Name.String() + " ADD " + a.Identity.String()
+	case *AlterColumnSetIdentity:
+		str += "ALTER COLUMN " + a.Name.String()
+		if a.SetGenerated {
+			str += " SET GENERATED " + (&Identity{Always: a.Always}).generated()
+		}
+		for _, opt := range a.Options {
+			str += " SET " + opt.String()
+		}
+	case *AlterColumnDropIdentity:
+		str += "ALTER COLUMN " + a.Name.String() + " DROP IDENTITY"
+		if a.IfExists {
+			str += " IF EXISTS"
+		}
 	case *AddConstraint:
 		str += "ADD " + a.Constraint.String()
 		if a.NotValid {
@@ -65,6 +80,8 @@ func (s *AlterTableStmt) String() string {
 		}
 	case *DropConstraint:
 		str += "DROP CONSTRAINT " + a.Name.String()
+	case *ValidateConstraint:
+		str += "VALIDATE CONSTRAINT " + a.Name.String()
 	case *AlterConstraint:
 		str += "ALTER CONSTRAINT " + a.Name.String() + " "
 		if a.Deferrable {
@@ -183,6 +200,38 @@ func (*AlterColumnDropNotNull) isAlterTableAction() {}
 
 func (s *AlterColumnDropNotNull) GoString() string { return internal.GoString(*s) }
 
+// AlterColumnAddIdentity represents ALTER TABLE table_name ALTER COLUMN column_name ADD GENERATED ... AS IDENTITY.
+type AlterColumnAddIdentity struct {
+	Name     *Ident
+	Identity *Identity
+}
+
+func (*AlterColumnAddIdentity) isAlterTableAction() {}
+
+func (s *AlterColumnAddIdentity) GoString() string { return internal.GoString(*s) }
+
+// AlterColumnSetIdentity represents ALTER TABLE table_name ALTER COLUMN column_name SET GENERATED ... or SET sequence_option.
+type AlterColumnSetIdentity struct {
+	Name         *Ident
+	SetGenerated bool
+	Always       bool
+	Options      SequenceOptions
+}
+
+func (*AlterColumnSetIdentity) isAlterTableAction() {}
+
+func (s *AlterColumnSetIdentity) GoString() string { return internal.GoString(*s) }
+
+// AlterColumnDropIdentity represents ALTER TABLE table_name ALTER COLUMN column_name DROP IDENTITY.
+type AlterColumnDropIdentity struct {
+	Name     *Ident
+	IfExists bool
+}
+
+func (*AlterColumnDropIdentity) isAlterTableAction() {}
+
+func (s *AlterColumnDropIdentity) GoString() string { return internal.GoString(*s) }
+
 // AddConstraint represents ALTER TABLE table_name ADD CONSTRAINT.
 type AddConstraint struct {
 	Constraint Constraint
@@ -202,6 +251,15 @@ func (*DropConstraint) isAlterTableAction() {}
 
 func (s *DropConstraint) GoString() string { return internal.GoString(*s) }
 
+// ValidateConstraint represents ALTER TABLE table_name VALIDATE CONSTRAINT.
+type ValidateConstraint struct {
+	Name *Ident
+}
+
+func (*ValidateConstraint) isAlterTableAction() {}
+
+func (s *ValidateConstraint) GoString() string { return internal.GoString(*s) }
+
 // AlterConstraint represents ALTER TABLE table_name ALTER CONSTRAINT.
 type AlterConstraint struct {
 	Name              *Ident
@@ -23,6 +23,7 @@ func Test_isAlterTableAction(t *testing.T) {
 	(&AlterColumnDropNotNull{}).isAlterTableAction()
 	(&AddConstraint{}).isAlterTableAction()
 	(&DropConstraint{}).isAlterTableAction()
+	(&ValidateConstraint{}).isAlterTableAction()
 	(&AlterConstraint{}).isAlterTableAction()
 }
 
@@ -260,6 +261,23 @@ ALTER TABLE "users" RENAME TO "accounts";
 		t.Logf("✅: %s: stmt: %#v", t.Name(), stmt)
 	})
 
+	t.Run("success,ValidateConstraint", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &AlterTableStmt{
+			Name:   &ObjectName{Name: &Ident{Name: "users", QuotationMark: `"`, Raw: `"users"`}},
+			Action: &ValidateConstraint{Name: &Ident{Name: "users_group_id_fkey", QuotationMark: `"`, Raw: `"users_group_id_fkey"`}},
+		}
+
+		expected := `ALTER TABLE "users" VALIDATE CONSTRAINT "users_group_id_fkey";` + "\n"
+		actual := stmt.String()
+
+		if !assert.Equal(t, expected, actual) {
+			assert.Equal(t, fmt.Sprintf("%#v", expected), fmt.Sprintf("%#v", actual))
+		}
+		t.Logf("✅: %s: stmt: %#v", t.Name(), stmt)
+	})
+
 	t.Run("success,AlterConstraint,DEFERRABLE", func(t *testing.T) {
 		t.Parallel()
 
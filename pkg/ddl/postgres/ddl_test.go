@@ -14,6 +14,21 @@ func Test_isStmt(t *testing.T) {
 	(&AlterTableStmt{}).isStmt()
 	(&CreateIndexStmt{}).isStmt()
 	(&DropIndexStmt{}).isStmt()
+	(&CreateTypeStmt{}).isStmt()
+	(&AlterTypeStmt{}).isStmt()
+	(&DropTypeStmt{}).isStmt()
+	(&CreateDomainStmt{}).isStmt()
+	(&AlterDomainStmt{}).isStmt()
+	(&DropDomainStmt{}).isStmt()
+	(&CreateExtensionStmt{}).isStmt()
+	(&AlterExtensionStmt{}).isStmt()
+	(&DropExtensionStmt{}).isStmt()
+	(&CreateSequenceStmt{}).isStmt()
+	(&AlterSequenceStmt{}).isStmt()
+	(&DropSequenceStmt{}).isStmt()
+	(&CreateViewStmt{}).isStmt()
+	(&DropViewStmt{}).isStmt()
+	(&RefreshMaterializedViewStmt{}).isStmt()
 }
 
 func TestIdent_String(t *testing.T) {
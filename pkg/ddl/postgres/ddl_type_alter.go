@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-altertype.html //diff:ignore-line-postgres-cockroach
+
+var _ Stmt = (*AlterTypeStmt)(nil)
+
+type AlterTypeStmt struct {
+	Comment string
+	Name    *ObjectName
+	Action  AlterTypeAction
+}
+
+func (*AlterTypeStmt) isStmt() {}
+
+func (s *AlterTypeStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *AlterTypeStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "ALTER TYPE "
+	str += s.Name.String() + " "
+	switch a := s.Action.(type) {
+	case *AddValue:
+		str += "ADD VALUE " + a.Value.String()
+		switch {
+		case a.Before != nil:
+			str += " BEFORE " + a.Before.String()
+		case a.After != nil:
+			str += " AFTER " + a.After.String()
+		}
+	case *RenameValue:
+		str += "RENAME VALUE " + a.Value.String() + " TO " + a.NewValue.String()
+	}
+
+	return str + ";\n"
+}
+
+func (s *AlterTypeStmt) GoString() string { return internal.GoString(*s) }
+
+type AlterTypeAction interface {
+	isAlterTypeAction()
+	GoString() string
+}
+
+// AddValue represents ALTER TYPE type_name ADD VALUE.
+type AddValue struct {
+	Value  *Ident
+	Before *Ident
+	After  *Ident
+}
+
+func (*AddValue) isAlterTypeAction() {}
+
+func (s *AddValue) GoString() string { return internal.GoString(*s) }
+
+// RenameValue represents ALTER TYPE type_name RENAME VALUE.
+type RenameValue struct {
+	Value    *Ident
+	NewValue *Ident
+}
+
+func (*RenameValue) isAlterTypeAction() {}
+
+func (s *RenameValue) GoString() string { return internal.GoString(*s) }
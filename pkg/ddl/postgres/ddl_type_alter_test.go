@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"testing"
+
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestAlterTypeStmt_GetNameForDiff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &AlterTypeStmt{Name: &ObjectName{Name: &Ident{Name: "mood", QuotationMark: `"`, Raw: `"mood"`}}}
+		expected := "mood"
+		actual := stmt.GetNameForDiff()
+
+		require.Equal(t, expected, actual)
+	})
+}
+
+func TestAlterTypeStmt_String(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,ADD_VALUE", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &AlterTypeStmt{
+			Comment: "test comment content",
+			Name:    &ObjectName{Name: &Ident{Name: "mood", QuotationMark: `"`, Raw: `"mood"`}},
+			Action:  &AddValue{Value: &Ident{Name: "ok", QuotationMark: `'`, Raw: `'ok'`}},
+		}
+		expected := `-- test comment content
+ALTER TYPE "mood" ADD VALUE 'ok';
+`
+		actual := stmt.String()
+
+		require.Equal(t, expected, actual)
+
+		t.Logf("✅: %s: stmt: %#v", t.Name(), stmt)
+	})
+
+	t.Run("success,ADD_VALUE_BEFORE", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &AlterTypeStmt{
+			Name: &ObjectName{Name: &Ident{Name: "mood", QuotationMark: `"`, Raw: `"mood"`}},
+			Action: &AddValue{
+				Value:  &Ident{Name: "ok", QuotationMark: `'`, Raw: `'ok'`},
+				Before: &Ident{Name: "happy", QuotationMark: `'`, Raw: `'happy'`},
+			},
+		}
+		expected := `ALTER TYPE "mood" ADD VALUE 'ok' BEFORE 'happy';
+`
+		actual := stmt.String()
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("success,ADD_VALUE_AFTER", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &AlterTypeStmt{
+			Name: &ObjectName{Name: &Ident{Name: "mood", QuotationMark: `"`, Raw: `"mood"`}},
+			Action: &AddValue{
+				Value: &Ident{Name: "ok", QuotationMark: `'`, Raw: `'ok'`},
+				After: &Ident{Name: "sad", QuotationMark: `'`, Raw: `'sad'`},
+			},
+		}
+		expected := `ALTER TYPE "mood" ADD VALUE 'ok' AFTER 'sad';
+`
+		actual := stmt.String()
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("success,RENAME_VALUE", func(t *testing.T) {
+		t.Parallel()
+
+		stmt := &AlterTypeStmt{
+			Name: &ObjectName{Name: &Ident{Name: "mood", QuotationMark: `"`, Raw: `"mood"`}},
+			Action: &RenameValue{
+				Value:    &Ident{Name: "ok", QuotationMark: `'`, Raw: `'ok'`},
+				NewValue: &Ident{Name: "fine", QuotationMark: `'`, Raw: `'fine'`},
+			},
+		}
+		expected := `ALTER TYPE "mood" RENAME VALUE 'ok' TO 'fine';
+`
+		actual := stmt.String()
+
+		require.Equal(t, expected, actual)
+	})
+}
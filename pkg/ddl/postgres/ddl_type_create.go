@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/z.go/stringz"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-createtype.html //diff:ignore-line-postgres-cockroach
+
+var _ Stmt = (*CreateTypeStmt)(nil)
+
+// CreateTypeStmt represents CREATE TYPE type_name AS ENUM.
+type CreateTypeStmt struct {
+	Comment string
+	Name    *ObjectName
+	Values  []*Ident
+}
+
+func (s *CreateTypeStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *CreateTypeStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "CREATE TYPE " + s.Name.String() + " AS ENUM"
+	str += " (" + stringz.JoinStringers(", ", s.Values...) + ");\n"
+	return str
+}
+
+func (s *CreateTypeStmt) StringForDiff() string {
+	str := "CREATE TYPE " + s.Name.StringForDiff() + " AS ENUM"
+	str += " ("
+	for i, v := range s.Values {
+		if i > 0 {
+			str += ", "
+		}
+		str += v.StringForDiff()
+	}
+	str += ");\n"
+	return str
+}
+
+func (*CreateTypeStmt) isStmt()            {}
+func (s *CreateTypeStmt) GoString() string { return internal.GoString(*s) }
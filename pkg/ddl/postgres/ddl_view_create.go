@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/z.go/stringz"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-createview.html
+// MEMO: https://www.postgresql.org/docs/current/sql-creatematerializedview.html
+
+var _ Stmt = (*CreateViewStmt)(nil)
+
+// CreateViewStmt represents CREATE [ OR REPLACE ] [ MATERIALIZED ] VIEW.
+// Query holds the query normalized by normalizeQuery.
+type CreateViewStmt struct {
+	Comment      string
+	OrReplace    bool
+	Materialized bool
+	IfNotExists  bool
+	Name         *ObjectName
+	Columns      []*Ident
+	Query        string
+	WithNoData   bool
+}
+
+func (s *CreateViewStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *CreateViewStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "CREATE "
+	if s.OrReplace {
+		str += "OR REPLACE "
+	}
+	if s.Materialized {
+		str += "MATERIALIZED "
+	}
+	str += "VIEW "
+	if s.IfNotExists {
+		str += "IF NOT EXISTS "
+	}
+	str += s.Name.String()
+	if len(s.Columns) > 0 {
+		str += " (" + stringz.JoinStringers(", ", s.Columns...) + ")"
+	}
+	str += " AS " + s.Query
+	if s.WithNoData {
+		str += " WITH NO DATA"
+	}
+	str += ";\n"
+	return str
+}
+
+// StringForDiff returns the definition of the view, without OR REPLACE, IF NOT EXISTS and WITH NO DATA.
+// The query is compared in the form of queryForDiff.
+func (s *CreateViewStmt) StringForDiff() string {
+	str := "CREATE "
+	if s.Materialized {
+		str += "MATERIALIZED "
+	}
+	str += "VIEW " + s.Name.StringForDiff()
+	if len(s.Columns) > 0 {
+		str += " ("
+		for i, v := range s.Columns {
+			if i > 0 {
+				str += ", "
+			}
+			str += v.StringForDiff()
+		}
+		str += ")"
+	}
+	str += " AS " + queryForDiff(s.Query) + ";\n"
+	return str
+}
+
+func (*CreateViewStmt) isStmt()            {}
+func (s *CreateViewStmt) GoString() string { return internal.GoString(*s) }
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-dropview.html
+// MEMO: https://www.postgresql.org/docs/current/sql-dropmaterializedview.html
+
+var _ Stmt = (*DropViewStmt)(nil)
+
+type DropViewStmt struct {
+	Comment      string
+	Materialized bool
+	IfExists     bool
+	Name         *ObjectName
+}
+
+func (s *DropViewStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *DropViewStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "DROP "
+	if s.Materialized {
+		str += "MATERIALIZED "
+	}
+	str += "VIEW "
+	if s.IfExists {
+		str += "IF EXISTS "
+	}
+	str += s.Name.String() + ";\n"
+	return str
+}
+
+func (*DropViewStmt) isStmt()            {}
+func (s *DropViewStmt) GoString() string { return internal.GoString(*s) }
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
+)
+
+// MEMO: https://www.postgresql.org/docs/current/sql-refreshmaterializedview.html
+
+var _ Stmt = (*RefreshMaterializedViewStmt)(nil)
+
+type RefreshMaterializedViewStmt struct {
+	Comment string
+	Name    *ObjectName
+}
+
+func (s *RefreshMaterializedViewStmt) GetNameForDiff() string {
+	return s.Name.StringForDiff()
+}
+
+func (s *RefreshMaterializedViewStmt) String() string {
+	var str string
+	if s.Comment != "" {
+		comments := strings.Split(s.Comment, "\n")
+		for i := range comments {
+			if comments[i] != "" {
+				str += CommentPrefix + comments[i] + "\n"
+			}
+		}
+	}
+	str += "REFRESH MATERIALIZED VIEW " + s.Name.String() + ";\n"
+	return str
+}
+
+func (*RefreshMaterializedViewStmt) isStmt()            {}
+func (s *RefreshMaterializedViewStmt) GoString() string { return internal.GoString(*s) }
@@ -1,7 +1,10 @@
 package postgres
 
 import (
+	"errors"
 	"reflect"
+	"slices"
+	"sort"
 
 	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
 	"github.com/hakadoriya/z.go/panicz"
@@ -11,16 +14,74 @@ import (
 	"github.com/hakadoriya/ddlctl/pkg/ddl"
 )
 
-//nolint:funlen,cyclop,gocognit
-func Diff(before, after *DDL) (*DDL, error) {
+type DiffConfig struct {
+	UseIndexConcurrently bool
+	UseSafeConstraints   bool
+}
+
+type DiffOption interface {
+	apply(c *DiffConfig)
+}
+
+// DiffUseIndexConcurrently makes Diff emit CREATE INDEX CONCURRENTLY and DROP INDEX CONCURRENTLY,
+// which do not block writes on the table. These statements cannot be run inside a transaction block.
+func DiffUseIndexConcurrently(concurrently bool) DiffOption { //nolint:ireturn
+	return &diffConfigUseIndexConcurrently{
+		useIndexConcurrently: concurrently,
+	}
+}
+
+type diffConfigUseIndexConcurrently struct {
+	useIndexConcurrently bool
+}
+
+func (o *diffConfigUseIndexConcurrently) apply(c *DiffConfig) {
+	c.UseIndexConcurrently = o.useIndexConcurrently
+}
+
+// DiffUseSafeConstraints makes Diff add FOREIGN KEY and CHECK constraints to existing tables as NOT VALID,
+// validate them with ALTER TABLE ... VALIDATE CONSTRAINT as a separate statement,
+// and SET NOT NULL via a validated CHECK (column IS NOT NULL) constraint.
+func DiffUseSafeConstraints(safe bool) DiffOption { //nolint:ireturn
+	return &diffConfigUseSafeConstraints{
+		useSafeConstraints: safe,
+	}
+}
+
+type diffConfigUseSafeConstraints struct {
+	useSafeConstraints bool
+}
+
+func (o *diffConfigUseSafeConstraints) apply(c *DiffConfig) {
+	c.UseSafeConstraints = o.useSafeConstraints
+}
+
+//nolint:funlen,cyclop,gocognit,gocyclo
+func Diff(before, after *DDL, opts ...DiffOption) (*DDL, error) {
+	config := &DiffConfig{}
+
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+
+	before, after = withoutRawStmts(before), withoutRawStmts(after)
 	result := &DDL{}
 
 	switch {
 	case before == nil && after != nil:
-		result.Stmts = append(result.Stmts, after.Stmts...)
+		stmts, ownedByStmts := splitSequenceOwnedBy(sortStmtsForCreate(after.Stmts))
+		for _, stmt := range stmts {
+			switch s := stmt.(type) {
+			case *CreateIndexStmt:
+				result.Stmts = append(result.Stmts, config.createIndexStmt(s))
+			default:
+				result.Stmts = append(result.Stmts, s)
+			}
+		}
+		result.Stmts = append(result.Stmts, ownedByStmts...)
 		return result, nil
 	case before != nil && after == nil:
-		for _, stmt := range before.Stmts {
+		for _, stmt := range sortStmtsForDrop(before.Stmts) {
 			switch s := stmt.(type) {
 			case *CreateTableStmt:
 				result.Stmts = append(result.Stmts, &DropTableStmt{
@@ -28,8 +89,32 @@ func Diff(before, after *DDL) (*DDL, error) {
 				})
 			case *CreateIndexStmt:
 				result.Stmts = append(result.Stmts, &DropIndexStmt{
+					Concurrently: config.UseIndexConcurrently,
+					Name:         s.Name,
+				})
+			case *CreateDomainStmt:
+				result.Stmts = append(result.Stmts, &DropDomainStmt{
+					Name: s.Name,
+				})
+			case *CreateTypeStmt:
+				result.Stmts = append(result.Stmts, &DropTypeStmt{
 					Name: s.Name,
 				})
+			case *CreateExtensionStmt:
+				result.Stmts = append(result.Stmts, &DropExtensionStmt{
+					Name: s.Name,
+				})
+			case *CreateSequenceStmt:
+				stmts, err := DiffCreateSequence(s, nil)
+				if err != nil {
+					return nil, apperr.Errorf("DiffCreateSequence: %w", err)
+				}
+				result.Stmts = append(result.Stmts, stmts.Stmts...)
+			case *CreateViewStmt:
+				result.Stmts = append(result.Stmts, &DropViewStmt{
+					Materialized: s.Materialized,
+					Name:         s.Name,
+				})
 			default:
 				return nil, apperr.Errorf("%s: %T: %w", s.GetNameForDiff(), s, ddl.ErrNotSupported)
 			}
@@ -39,16 +124,52 @@ func Diff(before, after *DDL) (*DDL, error) {
 		return nil, ddl.ErrNoDifference
 	}
 
+	// MEMO: PostgreSQL creates the sequence of a SERIAL column implicitly.
+	before, after = withoutSerialSequences(before, after), withoutSerialSequences(after, before)
+
+	// CREATE EXTENSION, CREATE TYPE, CREATE DOMAIN, CREATE SEQUENCE
+	// ALTER EXTENSION, ALTER TYPE, ALTER DOMAIN, ALTER SEQUENCE
+	ownedByStmts := make([]Stmt, 0)
+	retypedDomainStmts := make([]Stmt, 0)
+	for _, afterStmt := range sortStmtsForCreate(after.Stmts) {
+		if createOrder(afterStmt) >= createOrderOther {
+			continue
+		}
+		stmts, err := diffCreateOrderedStmt(findStmtByTypeAndName(afterStmt, before.Stmts), afterStmt)
+		if err != nil {
+			if errors.Is(err, ddl.ErrNoDifference) {
+				continue
+			}
+			return nil, apperr.Errorf("diffCreateOrderedStmt: %w", err)
+		}
+		createStmts, alterStmts := splitSequenceOwnedBy(stmts.Stmts)
+		createStmts, dropStmts := splitRetypedDomainDrop(createStmts)
+		result.Stmts = append(result.Stmts, createStmts...)
+		ownedByStmts = append(ownedByStmts, alterStmts...)
+		for _, dropStmt := range dropStmts {
+			retypedDomainStmts = append(retypedDomainStmts, retypeDomainColumns(before, after, afterStmt.(*CreateDomainStmt))...) //nolint:forcetypeassert
+			retypedDomainStmts = append(retypedDomainStmts, dropStmt)
+		}
+	}
+
+	// MEMO: views are dropped before and created after the statements for tables.
+	tableStmtsIndex := len(result.Stmts)
+
 	// DROP TABLE table_name;
 	for _, stmt := range onlyLeftStmt(before, after) {
 		switch beforeStmt := stmt.(type) {
+		case *CreateViewStmt:
+			// MEMO: dropped by diffViews
+		case *CreateExtensionStmt, *CreateTypeStmt, *CreateDomainStmt, *CreateSequenceStmt:
+			// MEMO: dropped after the tables that use them
 		case *CreateTableStmt:
 			result.Stmts = append(result.Stmts, &DropTableStmt{
 				Name: beforeStmt.Name,
 			})
 		case *CreateIndexStmt:
 			result.Stmts = append(result.Stmts, &DropIndexStmt{
-				Name: beforeStmt.Name,
+				Concurrently: config.UseIndexConcurrently,
+				Name:         beforeStmt.Name,
 			})
 		default:
 			return nil, apperr.Errorf("%s: %T: %w", beforeStmt.GetNameForDiff(), beforeStmt, ddl.ErrNotSupported)
@@ -58,10 +179,14 @@ func Diff(before, after *DDL) (*DDL, error) {
 	// CREATE TABLE table_name
 	for _, stmt := range onlyLeftStmt(after, before) {
 		switch afterStmt := stmt.(type) {
+		case *CreateViewStmt:
+			// MEMO: created by diffViews
+		case *CreateExtensionStmt, *CreateTypeStmt, *CreateDomainStmt, *CreateSequenceStmt:
+			// MEMO: created before the tables that use them
 		case *CreateTableStmt:
 			result.Stmts = append(result.Stmts, afterStmt)
 		case *CreateIndexStmt:
-			result.Stmts = append(result.Stmts, afterStmt)
+			result.Stmts = append(result.Stmts, config.createIndexStmt(afterStmt))
 		default:
 			return nil, apperr.Errorf("%s: %T: %w", afterStmt.GetNameForDiff(), afterStmt, ddl.ErrNotSupported)
 		}
@@ -74,7 +199,13 @@ func Diff(before, after *DDL) (*DDL, error) {
 		case *CreateTableStmt:
 			if afterStmt := findStmtByTypeAndName(beforeStmt, after.Stmts); afterStmt != nil {
 				afterStmt := afterStmt.(*CreateTableStmt) //nolint:forcetypeassert
-				alterStmt, err := DiffCreateTable(beforeStmt, afterStmt)
+				alterStmt, err := DiffCreateTable(
+					beforeStmt,
+					afterStmt,
+					DiffCreateTableUseAlterTableAddConstraintNotValid(config.UseSafeConstraints),
+					DiffCreateTableUseAlterTableValidateConstraint(config.UseSafeConstraints),
+					DiffCreateTableUseCheckConstraintForSetNotNull(config.UseSafeConstraints),
+				)
 				if err == nil {
 					result.Stmts = append(result.Stmts, alterStmt.Stmts...)
 				}
@@ -87,16 +218,44 @@ func Diff(before, after *DDL) (*DDL, error) {
 				if beforeStmt.StringForDiff() != afterStmt.StringForDiff() {
 					result.Stmts = append(result.Stmts,
 						&DropIndexStmt{
-							Comment: simplediff.Diff(beforeStmt.StringForDiff(), afterStmt.StringForDiff()).String(),
-							Name:    beforeStmt.Name,
+							Comment:      simplediff.Diff(beforeStmt.StringForDiff(), afterStmt.StringForDiff()).String(),
+							Concurrently: config.UseIndexConcurrently,
+							Name:         beforeStmt.Name,
 						},
-						afterStmt,
+						config.createIndexStmt(afterStmt),
 					)
 				}
 			}
 		}
 	}
 
+	// ALTER TABLE table_name ALTER COLUMN column_name SET DATA TYPE domain_name; DROP DOMAIN domain_name_ddlctl_old;
+	result.Stmts = append(result.Stmts, retypedDomainStmts...)
+
+	// DROP VIEW view_name; CREATE [OR REPLACE] VIEW view_name AS ...; REFRESH MATERIALIZED VIEW view_name;
+	dropViewStmts, createViewStmts := diffViews(before, after, result.Stmts[tableStmtsIndex:])
+	result.Stmts = slices.Insert(result.Stmts, tableStmtsIndex, dropViewStmts...)
+	result.Stmts = append(result.Stmts, createViewStmts...)
+
+	// ALTER SEQUENCE sequence_name OWNED BY table_name.column_name;
+	result.Stmts = append(result.Stmts, ownedByStmts...)
+
+	// DROP SEQUENCE, DROP DOMAIN, DROP TYPE, DROP EXTENSION
+	for _, stmt := range sortStmtsForDrop(onlyLeftStmt(before, after)) {
+		if createOrder(stmt) >= createOrderOther {
+			continue
+		}
+		// MEMO: the sequence of SERIAL that becomes an identity column is dropped by DiffCreateTable.
+		if s, ok := stmt.(*CreateSequenceStmt); ok && droppedSequence(result.Stmts, s.Name) {
+			continue
+		}
+		stmts, err := diffCreateOrderedStmt(stmt, nil)
+		if err != nil {
+			return nil, apperr.Errorf("diffCreateOrderedStmt: %w", err)
+		}
+		result.Stmts = append(result.Stmts, stmts.Stmts...)
+	}
+
 	if len(result.Stmts) == 0 {
 		return nil, ddl.ErrNoDifference
 	}
@@ -104,6 +263,211 @@ func Diff(before, after *DDL) (*DDL, error) {
 	return result, nil
 }
 
+const (
+	createOrderExtension = iota
+	createOrderType
+	createOrderDomain
+	createOrderSequence
+	createOrderOther
+	createOrderView
+)
+
+// createOrder returns the order in which stmt should be created,
+// so that extensions, types, domains and sequences are created before the tables that use them,
+// and views are created after them.
+func createOrder(stmt Stmt) int {
+	switch stmt.(type) {
+	case *CreateExtensionStmt:
+		return createOrderExtension
+	case *CreateTypeStmt:
+		return createOrderType
+	case *CreateDomainStmt:
+		return createOrderDomain
+	case *CreateSequenceStmt:
+		return createOrderSequence
+	case *CreateViewStmt:
+		return createOrderView
+	default:
+		return createOrderOther
+	}
+}
+
+func sortStmtsForCreate(stmts []Stmt) []Stmt {
+	sorted := append(make([]Stmt, 0, len(stmts)), stmts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return createOrder(sorted[i]) < createOrder(sorted[j]) })
+	sortViewStmtsByDependency(sorted, false)
+	return sorted
+}
+
+func sortStmtsForDrop(stmts []Stmt) []Stmt {
+	sorted := append(make([]Stmt, 0, len(stmts)), stmts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return createOrder(sorted[i]) > createOrder(sorted[j]) })
+	sortViewStmtsByDependency(sorted, true)
+	return sorted
+}
+
+// diffCreateOrderedStmt returns the difference of extensions, types, domains or sequences. before or after may be nil.
+func diffCreateOrderedStmt(before, after Stmt) (*DDL, error) {
+	switch s := firstNonNilStmt(after, before).(type) {
+	case *CreateExtensionStmt:
+		b, _ := before.(*CreateExtensionStmt)
+		a, _ := after.(*CreateExtensionStmt)
+		return DiffCreateExtension(b, a)
+	case *CreateTypeStmt:
+		b, _ := before.(*CreateTypeStmt)
+		a, _ := after.(*CreateTypeStmt)
+		return DiffCreateType(b, a)
+	case *CreateDomainStmt:
+		b, _ := before.(*CreateDomainStmt)
+		a, _ := after.(*CreateDomainStmt)
+		return DiffCreateDomain(b, a)
+	case *CreateSequenceStmt:
+		b, _ := before.(*CreateSequenceStmt)
+		a, _ := after.(*CreateSequenceStmt)
+		return DiffCreateSequence(b, a)
+	default:
+		return nil, apperr.Errorf("%s: %T: %w", s.GetNameForDiff(), s, ddl.ErrNotSupported)
+	}
+}
+
+// splitSequenceOwnedBy moves OWNED BY out of CREATE SEQUENCE and ALTER SEQUENCE in stmts into separate ALTER SEQUENCE statements,
+// because OWNED BY requires the table, which is created after the sequence.
+func splitSequenceOwnedBy(stmts []Stmt) (withoutOwnedBy []Stmt, ownedBy []Stmt) {
+	withoutOwnedBy = make([]Stmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *CreateSequenceStmt:
+			if option := s.Options.Find(SequenceOptionOwnedBy); option != nil {
+				c := *s
+				c.Options = withoutSequenceOption(s.Options, option)
+				withoutOwnedBy = append(withoutOwnedBy, &c)
+				ownedBy = append(ownedBy, &AlterSequenceStmt{Name: s.Name, Options: SequenceOptions{option}})
+				continue
+			}
+		case *AlterSequenceStmt:
+			if option := s.Options.Find(SequenceOptionOwnedBy); option != nil {
+				if len(s.Options) > 1 {
+					c := *s
+					c.Options = withoutSequenceOption(s.Options, option)
+					withoutOwnedBy = append(withoutOwnedBy, &c)
+				}
+				ownedBy = append(ownedBy, &AlterSequenceStmt{Name: s.Name, Options: SequenceOptions{option}})
+				continue
+			}
+		}
+		withoutOwnedBy = append(withoutOwnedBy, stmt)
+	}
+	return withoutOwnedBy, ownedBy
+}
+
+func withoutSequenceOption(options SequenceOptions, option *SequenceOption) SequenceOptions {
+	result := make(SequenceOptions, 0, len(options))
+	for _, o := range options {
+		if o != option {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// splitRetypedDomainDrop moves DROP DOMAIN of the domain renamed by DiffCreateDomain out of stmts,
+// because it is dropped after the columns are altered to the new domain.
+func splitRetypedDomainDrop(stmts []Stmt) (withoutDrop []Stmt, drop []Stmt) {
+	withoutDrop = make([]Stmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		if s, ok := stmt.(*DropDomainStmt); ok {
+			drop = append(drop, s)
+			continue
+		}
+		withoutDrop = append(withoutDrop, stmt)
+	}
+	return withoutDrop, drop
+}
+
+// retypeDomainColumns returns ALTER TABLE ... SET DATA TYPE of the columns of domain in both before and after,
+// which still use the renamed domain after its data type is changed.
+func retypeDomainColumns(before, after *DDL, domain *CreateDomainStmt) []Stmt {
+	isDomain := func(dataType *DataType) bool {
+		return dataType != nil && (dataType.Name == domain.Name.StringForDiff() || dataType.Name == domain.Name.Name.StringForDiff())
+	}
+
+	stmts := make([]Stmt, 0)
+	for _, stmt := range after.Stmts {
+		afterTable, ok := stmt.(*CreateTableStmt)
+		if !ok {
+			continue
+		}
+		beforeTable, ok := findStmtByTypeAndName(afterTable, before.Stmts).(*CreateTableStmt)
+		if !ok {
+			continue
+		}
+		for _, afterColumn := range afterTable.Columns {
+			beforeColumn := findColumnByName(afterColumn.Name.Name, beforeTable.Columns)
+			if beforeColumn == nil || !isDomain(afterColumn.DataType) || !isDomain(beforeColumn.DataType) {
+				continue
+			}
+			stmts = append(stmts, &AlterTableStmt{
+				Name:   afterTable.Name,
+				Action: &AlterColumnSetDataType{Name: afterColumn.Name, DataType: afterColumn.DataType},
+			})
+		}
+	}
+	return stmts
+}
+
+// withoutSerialSequences returns a copy of d without the sequences that PostgreSQL creates for the SERIAL columns in other,
+// so that a sequence shown from the database is not dropped when the source defines the column as SERIAL.
+func withoutSerialSequences(d, other *DDL) *DDL {
+	serialSequences := make(map[string]bool)
+	for _, stmt := range other.Stmts {
+		if s, ok := stmt.(*CreateTableStmt); ok {
+			for _, column := range s.Columns {
+				if isSerial(column) {
+					serialSequences[serialSequenceName(s.Name, column.Name).Name.StringForDiff()] = true
+				}
+			}
+		}
+	}
+
+	result := &DDL{Stmts: make([]Stmt, 0, len(d.Stmts))}
+	for _, stmt := range d.Stmts {
+		if s, ok := stmt.(*CreateSequenceStmt); ok && serialSequences[s.Name.Name.StringForDiff()] {
+			continue
+		}
+		result.Stmts = append(result.Stmts, stmt)
+	}
+	return result
+}
+
+// droppedSequence reports whether stmts has DROP SEQUENCE of name.
+func droppedSequence(stmts []Stmt, name *ObjectName) bool {
+	for _, stmt := range stmts {
+		if s, ok := stmt.(*DropSequenceStmt); ok && s.Name.StringForDiff() == name.StringForDiff() {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonNilStmt(stmts ...Stmt) Stmt { //nolint:ireturn
+	for _, stmt := range stmts {
+		if stmt != nil {
+			return stmt
+		}
+	}
+	return nil
+}
+
+// createIndexStmt returns a copy of stmt with CONCURRENTLY set if needed, so that the caller's AST is not modified.
+func (config *DiffConfig) createIndexStmt(stmt *CreateIndexStmt) *CreateIndexStmt {
+	if !config.UseIndexConcurrently || stmt.Concurrently {
+		return stmt
+	}
+	s := *stmt
+	s.Concurrently = true
+	return &s
+}
+
 func onlyLeftStmt(left, right *DDL) []Stmt {
 	result := make([]Stmt, 0)
 
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+//nolint:funlen,cyclop,gocognit
+func DiffCreateDomain(before, after *CreateDomainStmt) (*DDL, error) {
+	result := &DDL{}
+
+	switch {
+	case before == nil && after != nil:
+		// CREATE DOMAIN domain_name AS data_type ...;
+		result.Stmts = append(result.Stmts, after)
+		return result, nil
+	case before != nil && after == nil:
+		// DROP DOMAIN domain_name;
+		result.Stmts = append(result.Stmts, &DropDomainStmt{
+			Name: before.Name,
+		})
+		return result, nil
+	case (before == nil && after == nil) || before.StringForDiff() == after.StringForDiff():
+		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
+	}
+
+	if before.DataType.StringForDiff() != after.DataType.StringForDiff() {
+		// MEMO: ALTER DOMAIN cannot change the data type, and DROP DOMAIN fails while the columns use the domain.
+		// So the domain is renamed, and dropped after the columns are altered to the new domain, which Diff does.
+		// ALTER DOMAIN domain_name RENAME TO domain_name_ddlctl_old;
+		// CREATE DOMAIN domain_name AS data_type ...;
+		// DROP DOMAIN domain_name_ddlctl_old;
+		oldName := retypedDomainOldName(before.Name)
+		result.Stmts = append(result.Stmts,
+			&AlterDomainStmt{
+				Comment: simplediff.Diff(before.StringForDiff(), after.StringForDiff()).String(),
+				Name:    before.Name,
+				Action:  &DomainRenameTo{NewName: oldName.Name},
+			},
+			after,
+			&DropDomainStmt{
+				Name: oldName,
+			},
+		)
+		return result, nil
+	}
+
+	switch {
+	case before.Default != nil && after.Default == nil:
+		// ALTER DOMAIN domain_name DROP DEFAULT;
+		result.Stmts = append(result.Stmts, &AlterDomainStmt{
+			Comment: simplediff.Diff(before.Default.String(), "").String(),
+			Name:    after.Name,
+			Action:  &DomainDropDefault{},
+		})
+	case after.Default != nil && before.Default.StringForDiff() != after.Default.StringForDiff():
+		// ALTER DOMAIN domain_name SET DEFAULT default_value;
+		result.Stmts = append(result.Stmts, &AlterDomainStmt{
+			Comment: simplediff.Diff(before.Default.String(), after.Default.String()).String(),
+			Name:    after.Name,
+			Action:  &DomainSetDefault{Default: after.Default},
+		})
+	}
+
+	switch {
+	case before.NotNull && !after.NotNull:
+		// ALTER DOMAIN domain_name DROP NOT NULL;
+		result.Stmts = append(result.Stmts, &AlterDomainStmt{
+			Comment: simplediff.Diff("NOT NULL", "").String(),
+			Name:    after.Name,
+			Action:  &DomainDropNotNull{},
+		})
+	case !before.NotNull && after.NotNull:
+		// ALTER DOMAIN domain_name SET NOT NULL;
+		result.Stmts = append(result.Stmts, &AlterDomainStmt{
+			Comment: simplediff.Diff("", "NOT NULL").String(),
+			Name:    after.Name,
+			Action:  &DomainSetNotNull{},
+		})
+	}
+
+	for _, beforeConstraint := range before.Constraints {
+		afterConstraint := findConstraintByName(beforeConstraint.GetName().Name, after.Constraints)
+		if afterConstraint != nil && beforeConstraint.StringForDiff() == afterConstraint.StringForDiff() {
+			continue
+		}
+		// ALTER DOMAIN domain_name DROP CONSTRAINT constraint_name;
+		result.Stmts = append(result.Stmts, &AlterDomainStmt{
+			Comment: simplediff.Diff(beforeConstraint.String(), "").String(),
+			Name:    after.Name,
+			Action: &DropConstraint{
+				Name: beforeConstraint.GetName(),
+			},
+		})
+		if afterConstraint != nil {
+			// ALTER DOMAIN domain_name ADD CONSTRAINT constraint_name constraint;
+			result.Stmts = append(result.Stmts, &AlterDomainStmt{
+				Comment: simplediff.Diff("", afterConstraint.String()).String(),
+				Name:    after.Name,
+				Action: &AddConstraint{
+					Constraint: afterConstraint,
+				},
+			})
+		}
+	}
+
+	for _, afterConstraint := range onlyLeftConstraint(after.Constraints, before.Constraints) {
+		// ALTER DOMAIN domain_name ADD CONSTRAINT constraint_name constraint;
+		result.Stmts = append(result.Stmts, &AlterDomainStmt{
+			Comment: simplediff.Diff("", afterConstraint.String()).String(),
+			Name:    after.Name,
+			Action: &AddConstraint{
+				Constraint: afterConstraint,
+			},
+		})
+	}
+
+	if len(result.Stmts) == 0 {
+		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
+	}
+
+	return result, nil
+}
+
+// retypedDomainOldName returns the name that the domain of name is renamed to while its data type is changed.
+func retypedDomainOldName(name *ObjectName) *ObjectName {
+	ident := &Ident{
+		Name:          name.Name.Name + "_ddlctl_old",
+		QuotationMark: name.Name.QuotationMark,
+	}
+	ident.Raw = ident.QuotationMark + ident.Name + ident.QuotationMark
+	return &ObjectName{Schema: name.Schema, Name: ident}
+}
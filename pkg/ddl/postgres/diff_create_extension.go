@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"strings"
+
+	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// DiffCreateExtension returns the difference of extensions.
+// SCHEMA and VERSION are compared only if they are specified in after.
+func DiffCreateExtension(before, after *CreateExtensionStmt) (*DDL, error) {
+	result := &DDL{}
+
+	switch {
+	case before == nil && after != nil:
+		// CREATE EXTENSION extension_name;
+		result.Stmts = append(result.Stmts, after)
+		return result, nil
+	case before != nil && after == nil:
+		// DROP EXTENSION extension_name;
+		result.Stmts = append(result.Stmts, &DropExtensionStmt{
+			Name: before.Name,
+		})
+		return result, nil
+	case before == nil && after == nil:
+		return nil, ddl.ErrNoDifference
+	}
+
+	comment := simplediff.Diff(before.String(), after.String()).String()
+
+	if after.Schema != nil && before.Schema.StringForDiff() != after.Schema.StringForDiff() {
+		// ALTER EXTENSION extension_name SET SCHEMA schema_name;
+		result.Stmts = append(result.Stmts, &AlterExtensionStmt{
+			Comment: comment,
+			Name:    after.Name,
+			Action:  &SetSchema{Schema: after.Schema},
+		})
+	}
+
+	if after.Version != nil && extensionVersion(before.Version) != extensionVersion(after.Version) {
+		// ALTER EXTENSION extension_name UPDATE TO 'version';
+		result.Stmts = append(result.Stmts, &AlterExtensionStmt{
+			Comment: comment,
+			Name:    after.Name,
+			Action:  &UpdateTo{Version: after.Version},
+		})
+	}
+
+	if len(result.Stmts) == 0 {
+		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
+	}
+
+	return result, nil
+}
+
+// extensionVersion returns version without quotation marks, because VERSION accepts both '1.0' and 1.0.
+func extensionVersion(version *Ident) string {
+	return strings.Trim(version.StringForDiff(), `'`)
+}
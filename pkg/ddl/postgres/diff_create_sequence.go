@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"strings"
+
+	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// DiffCreateSequence returns the difference of sequences.
+// Options are compared only if they are specified in after, because omitted options keep their current values.
+func DiffCreateSequence(before, after *CreateSequenceStmt) (*DDL, error) {
+	result := &DDL{}
+
+	switch {
+	case before == nil && after != nil:
+		// CREATE SEQUENCE sequence_name;
+		result.Stmts = append(result.Stmts, after)
+		return result, nil
+	case before != nil && after == nil:
+		// DROP SEQUENCE sequence_name;
+		result.Stmts = append(result.Stmts, &DropSequenceStmt{
+			// MEMO: DROP TABLE also drops the sequences owned by the table.
+			IfExists: sequenceOwnedBy(before.Options.Find(SequenceOptionOwnedBy)) != "",
+			Name:     before.Name,
+		})
+		return result, nil
+	case before == nil && after == nil:
+		return nil, ddl.ErrNoDifference
+	}
+
+	options := make(SequenceOptions, 0)
+	for _, afterOption := range after.Options {
+		if sequenceOptionForDiff(before.Options.Find(afterOption.Key())) != sequenceOptionForDiff(afterOption) {
+			options = append(options, afterOption)
+		}
+	}
+
+	if len(options) == 0 {
+		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
+	}
+
+	// ALTER SEQUENCE sequence_name sequence_option ...;
+	result.Stmts = append(result.Stmts, &AlterSequenceStmt{
+		Comment: simplediff.Diff(before.StringForDiff(), after.StringForDiff()).String(),
+		Name:    after.Name,
+		Options: options,
+	})
+
+	return result, nil
+}
+
+func sequenceOptionForDiff(option *SequenceOption) string {
+	if option == nil {
+		return ""
+	}
+	if option.Key() == SequenceOptionOwnedBy {
+		if ownedBy := sequenceOwnedBy(option); ownedBy != "" {
+			return SequenceOptionOwnedBy + " " + ownedBy
+		}
+		return ""
+	}
+	return option.StringForDiff()
+}
+
+// sequenceOwnedBy returns table_name.column_name of OWNED BY without the schema name, or "" for OWNED BY NONE.
+func sequenceOwnedBy(option *SequenceOption) string {
+	if option == nil || strings.EqualFold(option.Value.StringForDiff(), "NONE") {
+		return ""
+	}
+	const tableAndColumn = 2
+	names := strings.Split(option.Value.StringForDiff(), ".")
+	if len(names) > tableAndColumn {
+		names = names[len(names)-tableAndColumn:]
+	}
+	return strings.Join(names, ".")
+}
@@ -1,7 +1,9 @@
 package postgres
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
 
@@ -12,6 +14,8 @@ import (
 
 type DiffCreateTableConfig struct {
 	UseAlterTableAddConstraintNotValid bool
+	UseAlterTableValidateConstraint    bool
+	UseCheckConstraintForSetNotNull    bool
 }
 
 type DiffCreateTableOption interface {
@@ -32,6 +36,39 @@ func (o *diffCreateTableConfigUseConstraintNotValid) apply(c *DiffCreateTableCon
 	c.UseAlterTableAddConstraintNotValid = o.useAlterTableAddConstraintNotValid
 }
 
+// DiffCreateTableUseAlterTableValidateConstraint makes DiffCreateTable follow each ADD CONSTRAINT ... NOT VALID
+// with ALTER TABLE ... VALIDATE CONSTRAINT as a separate statement, which does not block writes on the table.
+func DiffCreateTableUseAlterTableValidateConstraint(validate bool) DiffCreateTableOption { //nolint:ireturn
+	return &diffCreateTableConfigUseValidateConstraint{
+		useAlterTableValidateConstraint: validate,
+	}
+}
+
+type diffCreateTableConfigUseValidateConstraint struct {
+	useAlterTableValidateConstraint bool
+}
+
+func (o *diffCreateTableConfigUseValidateConstraint) apply(c *DiffCreateTableConfig) {
+	c.UseAlterTableValidateConstraint = o.useAlterTableValidateConstraint
+}
+
+// DiffCreateTableUseCheckConstraintForSetNotNull makes DiffCreateTable replace ALTER COLUMN ... SET NOT NULL
+// with a validated CHECK (column IS NOT NULL) constraint, SET NOT NULL and DROP CONSTRAINT.
+// Since PostgreSQL 12, SET NOT NULL skips the full table scan under ACCESS EXCLUSIVE lock if such a constraint exists.
+func DiffCreateTableUseCheckConstraintForSetNotNull(use bool) DiffCreateTableOption { //nolint:ireturn
+	return &diffCreateTableConfigUseCheckConstraintForSetNotNull{
+		useCheckConstraintForSetNotNull: use,
+	}
+}
+
+type diffCreateTableConfigUseCheckConstraintForSetNotNull struct {
+	useCheckConstraintForSetNotNull bool
+}
+
+func (o *diffCreateTableConfigUseCheckConstraintForSetNotNull) apply(c *DiffCreateTableConfig) {
+	c.UseCheckConstraintForSetNotNull = o.useCheckConstraintForSetNotNull
+}
+
 //nolint:funlen,cyclop
 func DiffCreateTable(before, after *CreateTableStmt, opts ...DiffCreateTableOption) (*DDL, error) {
 	config := &DiffCreateTableConfig{}
@@ -89,6 +126,7 @@ func DiffCreateTable(before, after *CreateTableStmt, opts ...DiffCreateTableOpti
 
 	config.diffCreateTableColumn(result, before, after)
 
+	validateStmts := make([]Stmt, 0)
 	for _, beforeConstraint := range before.Constraints {
 		afterConstraint := findConstraintByName(beforeConstraint.GetName().Name, after.Constraints)
 		if afterConstraint != nil {
@@ -109,10 +147,11 @@ func DiffCreateTable(before, after *CreateTableStmt, opts ...DiffCreateTableOpti
 						Name:    after.Name,
 						Action: &AddConstraint{
 							Constraint: afterConstraint,
-							NotValid:   config.UseAlterTableAddConstraintNotValid,
+							NotValid:   config.notValid(afterConstraint),
 						},
 					},
 				)
+				validateStmts = config.appendValidateConstraint(validateStmts, after.Name, afterConstraint)
 			}
 			continue
 		}
@@ -125,11 +164,15 @@ func DiffCreateTable(before, after *CreateTableStmt, opts ...DiffCreateTableOpti
 			Name:    after.Name,
 			Action: &AddConstraint{
 				Constraint: afterConstraint,
-				NotValid:   config.UseAlterTableAddConstraintNotValid,
+				NotValid:   config.notValid(afterConstraint),
 			},
 		})
+		validateStmts = config.appendValidateConstraint(validateStmts, after.Name, afterConstraint)
 	}
 
+	// ALTER TABLE table_name VALIDATE CONSTRAINT constraint_name;
+	result.Stmts = append(result.Stmts, validateStmts...)
+
 	if len(result.Stmts) == 0 {
 		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
 	}
@@ -153,6 +196,10 @@ func (config *DiffCreateTableConfig) diffCreateTableColumn(ddls *DDL, before, af
 			continue
 		}
 
+		// MEMO: use before.Name for both, because ALTER TABLE RENAME TO does not rename the sequence of SERIAL.
+		serialColumn := afterColumn
+		beforeColumn, afterColumn = normalizeColumn(before.Name, beforeColumn), normalizeColumn(before.Name, afterColumn)
+
 		if beforeColumn.DataType.StringForDiff() != afterColumn.DataType.StringForDiff() {
 			// ALTER TABLE table_name ALTER COLUMN column_name SET DATA TYPE data_type;
 			ddls.Stmts = append(ddls.Stmts, &AlterTableStmt{
@@ -163,6 +210,24 @@ func (config *DiffCreateTableConfig) diffCreateTableColumn(ddls *DDL, before, af
 					DataType: afterColumn.DataType,
 				},
 			})
+			if isSerial(serialColumn) && beforeColumn.Default.StringForDiff() == afterColumn.Default.StringForDiff() {
+				// ALTER SEQUENCE table_name_column_name_seq AS data_type;
+				ddls.Stmts = append(ddls.Stmts, &AlterSequenceStmt{
+					Name:    serialSequenceName(before.Name, afterColumn.Name),
+					Options: SequenceOptions{{Name: SequenceOptionAs, Value: NewRawIdent(afterColumn.DataType.StringForDiff())}},
+				})
+			}
+		}
+
+		if beforeColumn.Identity != nil && afterColumn.Identity == nil {
+			// ALTER TABLE table_name ALTER COLUMN column_name DROP IDENTITY;
+			ddls.Stmts = append(ddls.Stmts, &AlterTableStmt{
+				Comment: simplediff.Diff(beforeColumn.String(), afterColumn.String()).String(),
+				Name:    after.Name,
+				Action: &AlterColumnDropIdentity{
+					Name: afterColumn.Name,
+				},
+			})
 		}
 
 		switch {
@@ -197,6 +262,37 @@ func (config *DiffCreateTableConfig) diffCreateTableColumn(ddls *DDL, before, af
 					Name: afterColumn.Name,
 				},
 			})
+		case !beforeColumn.NotNull && afterColumn.NotNull && config.UseCheckConstraintForSetNotNull:
+			// ALTER TABLE table_name ADD CONSTRAINT table_name_column_name_not_null CHECK (column_name IS NOT NULL) NOT VALID;
+			// ALTER TABLE table_name VALIDATE CONSTRAINT table_name_column_name_not_null;
+			// ALTER TABLE table_name ALTER COLUMN column_name SET NOT NULL;
+			// ALTER TABLE table_name DROP CONSTRAINT table_name_column_name_not_null;
+			notNullConstraint := &CheckConstraint{
+				Name: NewRawIdent(fmt.Sprintf("%s_%s_not_null", after.Name.Name.StringForDiff(), afterColumn.Name.StringForDiff())),
+				Expr: &Expr{Idents: []*Ident{NewRawIdent("("), afterColumn.Name, NewRawIdent("IS"), NewRawIdent("NOT"), NewRawIdent("NULL"), NewRawIdent(")")}},
+			}
+			ddls.Stmts = append(ddls.Stmts,
+				&AlterTableStmt{
+					Comment: simplediff.Diff(beforeColumn.String(), afterColumn.String()).String(),
+					Name:    after.Name,
+					Action: &AddConstraint{
+						Constraint: notNullConstraint,
+						NotValid:   true,
+					},
+				},
+				&AlterTableStmt{
+					Name:   after.Name,
+					Action: &ValidateConstraint{Name: notNullConstraint.Name},
+				},
+				&AlterTableStmt{
+					Name:   after.Name,
+					Action: &AlterColumnSetNotNull{Name: afterColumn.Name},
+				},
+				&AlterTableStmt{
+					Name:   after.Name,
+					Action: &DropConstraint{Name: notNullConstraint.Name},
+				},
+			)
 		case !beforeColumn.NotNull && afterColumn.NotNull:
 			// ALTER TABLE table_name ALTER COLUMN column_name SET NOT NULL;
 			ddls.Stmts = append(ddls.Stmts, &AlterTableStmt{
@@ -207,6 +303,8 @@ func (config *DiffCreateTableConfig) diffCreateTableColumn(ddls *DDL, before, af
 				},
 			})
 		}
+
+		diffCreateTableColumnIdentity(ddls, before.Name, after.Name, beforeColumn, afterColumn)
 	}
 
 	for _, afterColumn := range onlyLeftColumn(after.Columns, before.Columns) {
@@ -221,6 +319,157 @@ func (config *DiffCreateTableConfig) diffCreateTableColumn(ddls *DDL, before, af
 	}
 }
 
+// diffCreateTableColumnIdentity appends ADD GENERATED ... AS IDENTITY or SET GENERATED ... to ddls.
+// When a column of nextval, such as SERIAL, becomes an identity column, the identity continues from the current value of the sequence,
+// and the sequence of SERIAL is dropped after that. The other sequences are dropped by Diff if they are removed.
+func diffCreateTableColumnIdentity(ddls *DDL, beforeTableName, afterTableName *ObjectName, beforeColumn, afterColumn *Column) {
+	switch {
+	case beforeColumn.Identity == nil && afterColumn.Identity != nil:
+		// ALTER TABLE table_name ALTER COLUMN column_name ADD GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY;
+		ddls.Stmts = append(ddls.Stmts, &AlterTableStmt{
+			Comment: simplediff.Diff(beforeColumn.String(), afterColumn.String()).String(),
+			Name:    afterTableName,
+			Action: &AlterColumnAddIdentity{
+				Name:     afterColumn.Name,
+				Identity: afterColumn.Identity,
+			},
+		})
+		sequenceName := nextvalSequenceName(beforeColumn.Default)
+		if sequenceName == "" {
+			return
+		}
+		// MEMO: the identity starts at 1, so it is set to the current value of the sequence, not to conflict with the existing rows.
+		// SELECT setval(pg_get_serial_sequence('table_name', 'column_name'), last_value, is_called) FROM sequence_name;
+		ddls.Stmts = append(ddls.Stmts, &RawStmt{
+			Comment: "continue the identity of " + afterColumn.Name.StringForDiff() + " from the current value of " + sequenceName,
+			Raw:     "SELECT setval(pg_get_serial_sequence('" + afterTableName.String() + "', '" + afterColumn.Name.StringForDiff() + "'), last_value, is_called) FROM " + sequenceName,
+		})
+		if beforeColumn.Default.StringForDiff() == serialDefault(beforeTableName, beforeColumn.Name).StringForDiff() {
+			// DROP SEQUENCE IF EXISTS table_name_column_name_seq;
+			ddls.Stmts = append(ddls.Stmts, &DropSequenceStmt{
+				IfExists: true,
+				Name:     serialSequenceName(beforeTableName, beforeColumn.Name),
+			})
+		}
+	case beforeColumn.Identity != nil && afterColumn.Identity != nil:
+		action := &AlterColumnSetIdentity{
+			Name:         afterColumn.Name,
+			SetGenerated: beforeColumn.Identity.Always != afterColumn.Identity.Always,
+			Always:       afterColumn.Identity.Always,
+		}
+		for _, afterOption := range afterColumn.Identity.Options {
+			switch afterOption.Key() {
+			case SequenceOptionAs, SequenceOptionOwnedBy:
+				// MEMO: SET accepts neither AS nor OWNED BY.
+				continue
+			}
+			if sequenceOptionForDiff(beforeColumn.Identity.Options.Find(afterOption.Key())) != sequenceOptionForDiff(afterOption) {
+				action.Options = append(action.Options, afterOption)
+			}
+		}
+		if action.SetGenerated || len(action.Options) > 0 {
+			// ALTER TABLE table_name ALTER COLUMN column_name SET GENERATED { ALWAYS | BY DEFAULT } SET sequence_option;
+			ddls.Stmts = append(ddls.Stmts, &AlterTableStmt{
+				Comment: simplediff.Diff(beforeColumn.String(), afterColumn.String()).String(),
+				Name:    afterTableName,
+				Action:  action,
+			})
+		}
+	}
+}
+
+// normalizeColumn returns column in the form PostgreSQL stores it, so that equivalent columns are regarded as the same.
+// SMALLSERIAL, SERIAL and BIGSERIAL are expanded to SMALLINT, INTEGER and BIGINT with
+// DEFAULT nextval('table_name_column_name_seq'::regclass) NOT NULL, and an identity column is NOT NULL.
+func normalizeColumn(tableName *ObjectName, column *Column) *Column {
+	c := *column
+	if isSerial(column) {
+		dataType, _ := serialDataType(column.DataType.Type)
+		c.DataType = &DataType{Name: string(dataType), Type: dataType}
+		c.Default = serialDefault(tableName, column.Name)
+		c.NotNull = true
+	}
+	if c.Identity != nil {
+		c.NotNull = true
+	}
+	return &c
+}
+
+func isSerial(column *Column) bool {
+	if column.DataType == nil {
+		return false
+	}
+	_, ok := serialDataType(column.DataType.Type)
+	return ok
+}
+
+// serialDataType returns the data type that SMALLSERIAL, SERIAL or BIGSERIAL is expanded to.
+func serialDataType(tokenType TokenType) (TokenType, bool) {
+	switch tokenType { //nolint:exhaustive
+	case TOKEN_SMALLSERIAL:
+		return TOKEN_SMALLINT, true
+	case TOKEN_SERIAL:
+		return TOKEN_INTEGER, true
+	case TOKEN_BIGSERIAL:
+		return TOKEN_BIGINT, true
+	default:
+		return "", false
+	}
+}
+
+// serialSequenceName returns the name of the sequence that PostgreSQL creates for a SERIAL column.
+func serialSequenceName(tableName *ObjectName, columnName *Ident) *ObjectName {
+	return &ObjectName{
+		Schema: tableName.Schema,
+		Name:   NewRawIdent(tableName.Name.StringForDiff() + "_" + columnName.StringForDiff() + "_seq"),
+	}
+}
+
+// serialDefault returns DEFAULT nextval('table_name_column_name_seq'::regclass) of a SERIAL column.
+func serialDefault(tableName *ObjectName, columnName *Ident) *Default {
+	sequenceName := serialSequenceName(tableName, columnName).Name.StringForDiff()
+	return &Default{Value: &Expr{Idents: []*Ident{
+		NewRawIdent("nextval"), NewRawIdent("("), NewRawIdent("'" + sequenceName + "'"), NewRawIdent("::"), NewRawIdent("regclass"), NewRawIdent(")"),
+	}}}
+}
+
+// nextvalSequenceName returns the sequence name of DEFAULT nextval('sequence_name'[::regclass]), or "" if def is not nextval.
+func nextvalSequenceName(def *Default) string {
+	if def == nil || def.Value == nil {
+		return ""
+	}
+	idents := def.Value.Idents
+	const nextvalAndParenAndName = 3
+	if len(idents) < nextvalAndParenAndName || !strings.EqualFold(idents[0].String(), "nextval") || idents[1].String() != "(" {
+		return ""
+	}
+	name := idents[2].String()
+	if len(name) < 2 || !strings.HasPrefix(name, "'") || !strings.HasSuffix(name, "'") {
+		return ""
+	}
+	return name[1 : len(name)-1]
+}
+
+// notValid reports whether constraint should be added as NOT VALID. PostgreSQL accepts NOT VALID only for FOREIGN KEY and CHECK constraints.
+func (config *DiffCreateTableConfig) notValid(constraint Constraint) bool {
+	switch constraint.(type) {
+	case *ForeignKeyConstraint, *CheckConstraint:
+		return config.UseAlterTableAddConstraintNotValid
+	default:
+		return false
+	}
+}
+
+func (config *DiffCreateTableConfig) appendValidateConstraint(stmts []Stmt, tableName *ObjectName, constraint Constraint) []Stmt {
+	if !config.UseAlterTableValidateConstraint || !config.notValid(constraint) {
+		return stmts
+	}
+	return append(stmts, &AlterTableStmt{
+		Name:   tableName,
+		Action: &ValidateConstraint{Name: constraint.GetName()},
+	})
+}
+
 func onlyLeftColumn(left, right []*Column) []*Column {
 	onlyLeftColumns := make([]*Column, 0)
 	for _, leftColumn := range left {
@@ -505,6 +505,76 @@ ALTER TABLE "users" ADD CONSTRAINT users_age_check CHECK ("age" >= 0) NOT VALID;
 		t.Logf("✅: %s:\n%s", t.Name(), actual)
 	})
 
+	t.Run("success,DiffCreateTableUseAlterTableValidateConstraint", func(t *testing.T) {
+		t.Parallel()
+
+		before := `CREATE TABLE "users" (id UUID NOT NULL, group_id UUID NOT NULL, "name" VARCHAR(255) NOT NULL, "age" INT DEFAULT 0, description TEXT, PRIMARY KEY ("id"));`
+		beforeDDL, err := NewParser(NewLexer(before)).Parse()
+		require.NoError(t, err)
+
+		after := `CREATE TABLE "users" (id UUID NOT NULL, group_id UUID NOT NULL REFERENCES "groups" ("id"), "name" VARCHAR(255) NOT NULL UNIQUE, "age" INT DEFAULT 0 CHECK ("age" >= 0), description TEXT, PRIMARY KEY ("id"));`
+		afterDDL, err := NewParser(NewLexer(after)).Parse()
+		require.NoError(t, err)
+
+		expectedStr := `-- -
+-- +CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES "groups" ("id")
+ALTER TABLE "users" ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES "groups" ("id") NOT VALID;
+-- -
+-- +CONSTRAINT users_unique_name UNIQUE ("name")
+ALTER TABLE "users" ADD CONSTRAINT users_unique_name UNIQUE ("name");
+-- -
+-- +CONSTRAINT users_age_check CHECK ("age" >= 0)
+ALTER TABLE "users" ADD CONSTRAINT users_age_check CHECK ("age" >= 0) NOT VALID;
+ALTER TABLE "users" VALIDATE CONSTRAINT users_group_id_fkey;
+ALTER TABLE "users" VALIDATE CONSTRAINT users_age_check;
+`
+
+		//nolint:forcetypeassert
+		actual, err := DiffCreateTable(
+			beforeDDL.Stmts[0].(*CreateTableStmt),
+			afterDDL.Stmts[0].(*CreateTableStmt),
+			DiffCreateTableUseAlterTableAddConstraintNotValid(true),
+			DiffCreateTableUseAlterTableValidateConstraint(true),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStr, actual.String())
+
+		t.Logf("✅: %s:\n%s", t.Name(), actual)
+	})
+
+	t.Run("success,DiffCreateTableUseCheckConstraintForSetNotNull", func(t *testing.T) {
+		t.Parallel()
+
+		before := `CREATE TABLE "users" (id UUID NOT NULL, "name" VARCHAR(255), PRIMARY KEY ("id"));`
+		beforeDDL, err := NewParser(NewLexer(before)).Parse()
+		require.NoError(t, err)
+
+		after := `CREATE TABLE "users" (id UUID NOT NULL, "name" VARCHAR(255) NOT NULL, PRIMARY KEY ("id"));`
+		afterDDL, err := NewParser(NewLexer(after)).Parse()
+		require.NoError(t, err)
+
+		expectedStr := `-- -"name" VARCHAR(255)
+-- +"name" VARCHAR(255) NOT NULL
+ALTER TABLE "users" ADD CONSTRAINT users_name_not_null CHECK ("name" IS NOT NULL) NOT VALID;
+ALTER TABLE "users" VALIDATE CONSTRAINT users_name_not_null;
+ALTER TABLE "users" ALTER COLUMN "name" SET NOT NULL;
+ALTER TABLE "users" DROP CONSTRAINT users_name_not_null;
+`
+
+		//nolint:forcetypeassert
+		actual, err := DiffCreateTable(
+			beforeDDL.Stmts[0].(*CreateTableStmt),
+			afterDDL.Stmts[0].(*CreateTableStmt),
+			DiffCreateTableUseCheckConstraintForSetNotNull(true),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStr, actual.String())
+
+		t.Logf("✅: %s:\n%s", t.Name(), actual)
+	})
+
 	t.Run("success,CREATE_TABLE", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// DiffCreateType returns the difference of ENUM types.
+//
+// ENUM values cannot be reordered, and dropping them is not supported.
+// The values that exist in both before and after divide the other values into gaps,
+// and in each gap, the values that only exist in before are regarded as renamed to the new values in order.
+//
+//nolint:funlen,cyclop,gocognit
+func DiffCreateType(before, after *CreateTypeStmt) (*DDL, error) {
+	result := &DDL{}
+
+	switch {
+	case before == nil && after != nil:
+		// CREATE TYPE type_name AS ENUM (...);
+		result.Stmts = append(result.Stmts, after)
+		return result, nil
+	case before != nil && after == nil:
+		// DROP TYPE type_name;
+		result.Stmts = append(result.Stmts, &DropTypeStmt{
+			Name: before.Name,
+		})
+		return result, nil
+	case (before == nil && after == nil) || before.StringForDiff() == after.StringForDiff():
+		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
+	}
+
+	beforeCommon, beforeGaps := splitEnumValues(before.Values, after.Values)
+	afterCommon, afterGaps := splitEnumValues(after.Values, before.Values)
+	for i := range beforeCommon {
+		if beforeCommon[i].Name != afterCommon[i].Name {
+			return nil, apperr.Errorf("type_name=%s: value=%s: ENUM value cannot be reordered: %w", after.GetNameForDiff(), afterCommon[i].StringForDiff(), ddl.ErrNotSupported)
+		}
+	}
+
+	// existing are the values of the type after RENAME VALUE.
+	existing := append(make([]*Ident, 0, len(after.Values)), afterCommon...)
+	for i := range beforeGaps {
+		for j, beforeValue := range beforeGaps[i] {
+			if j >= len(afterGaps[i]) {
+				return nil, apperr.Errorf("type_name=%s: value=%s: ENUM value cannot be dropped: %w", after.GetNameForDiff(), beforeValue.StringForDiff(), ddl.ErrNotSupported)
+			}
+			// ALTER TYPE type_name RENAME VALUE 'value' TO 'new_value';
+			result.Stmts = append(result.Stmts, &AlterTypeStmt{
+				Name: after.Name,
+				Action: &RenameValue{
+					Value:    beforeValue,
+					NewValue: afterGaps[i][j],
+				},
+			})
+			existing = append(existing, afterGaps[i][j])
+		}
+	}
+
+	for i, afterValue := range after.Values {
+		if indexIdentByName(afterValue.Name, existing) >= 0 {
+			continue
+		}
+		// ALTER TYPE type_name ADD VALUE 'new_value' [ { BEFORE | AFTER } 'value' ];
+		addValue := &AddValue{Value: afterValue}
+		if i > 0 {
+			addValue.After = after.Values[i-1]
+		} else {
+			for _, v := range after.Values[1:] {
+				if indexIdentByName(v.Name, existing) >= 0 {
+					addValue.Before = v
+					break
+				}
+			}
+		}
+		result.Stmts = append(result.Stmts, &AlterTypeStmt{
+			Name:   after.Name,
+			Action: addValue,
+		})
+		existing = append(existing, afterValue)
+	}
+
+	if len(result.Stmts) == 0 {
+		return nil, apperr.Errorf("before: %s, after: %s: %w", before.GetNameForDiff(), after.GetNameForDiff(), ddl.ErrNoDifference)
+	}
+	if stmt, ok := result.Stmts[0].(*AlterTypeStmt); ok {
+		stmt.Comment = simplediff.Diff(before.StringForDiff(), after.StringForDiff()).String()
+	}
+
+	return result, nil
+}
+
+// splitEnumValues splits values into the values that also exist in others and the gaps between them.
+// len(gaps) is always len(common)+1.
+func splitEnumValues(values, others []*Ident) (common []*Ident, gaps [][]*Ident) {
+	gaps = [][]*Ident{{}}
+	for _, v := range values {
+		if indexIdentByName(v.Name, others) >= 0 {
+			common = append(common, v)
+			gaps = append(gaps, []*Ident{})
+			continue
+		}
+		gaps[len(gaps)-1] = append(gaps[len(gaps)-1], v)
+	}
+	return common, gaps
+}
+
+func indexIdentByName(name string, idents []*Ident) int {
+	for i, ident := range idents {
+		if ident.Name == name {
+			return i
+		}
+	}
+	return -1
+}
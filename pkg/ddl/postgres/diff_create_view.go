@@ -0,0 +1,528 @@
+package postgres
+
+import (
+	"strings"
+
+	simplediff "github.com/hakadoriya/z.go/diffz/simplediffz"
+)
+
+// diffViews returns the statements that drop views and the statements that create, replace or refresh views.
+// The former must run before the tables are altered and the latter after that, because a view blocks
+// DROP COLUMN and SET DATA TYPE of the columns it depends on.
+//
+// A view is replaced with CREATE OR REPLACE VIEW if its columns in before are a prefix of its columns in after,
+// otherwise it is dropped and recreated together with the views that depend on it.
+// Materialized views that depend on a replaced view are refreshed.
+//
+//nolint:cyclop,funlen,gocognit
+func diffViews(before, after *DDL, tableStmts []Stmt) (dropStmts []Stmt, createStmts []Stmt) {
+	beforeViews, afterViews := sortViewsByDependency(viewStmts(before)), sortViewsByDependency(viewStmts(after))
+
+	findView := func(view *CreateViewStmt, views []*CreateViewStmt) *CreateViewStmt {
+		for _, v := range views {
+			if v.GetNameForDiff() == view.GetNameForDiff() {
+				return v
+			}
+		}
+		return nil
+	}
+
+	// MEMO: relations whose columns or names change incompatibly for the views that depend on them.
+	changed := make([]*ObjectName, 0)
+	for _, stmt := range tableStmts {
+		switch s := stmt.(type) {
+		case *DropTableStmt:
+			changed = append(changed, s.Name)
+		case *AlterTableStmt:
+			switch s.Action.(type) {
+			case *DropColumn, *AlterColumnSetDataType, *RenameColumn, *RenameTable:
+				changed = append(changed, s.Name)
+			}
+		}
+	}
+
+	replace := make(map[string]bool)
+	recreate := make(map[string]bool)
+	for _, beforeView := range beforeViews {
+		afterView := findView(beforeView, afterViews)
+		if afterView == nil {
+			changed = append(changed, beforeView.Name)
+			continue
+		}
+
+		switch {
+		case viewReferencesAny(beforeView, changed):
+			recreate[beforeView.GetNameForDiff()] = true
+		case beforeView.StringForDiff() == afterView.StringForDiff():
+			continue
+		case !beforeView.Materialized && !afterView.Materialized && viewColumnsCompatible(beforeView, afterView):
+			replace[beforeView.GetNameForDiff()] = true
+			continue
+		default:
+			recreate[beforeView.GetNameForDiff()] = true
+		}
+		// MEMO: beforeViews is sorted by dependency, so the views that depend on this view are checked later.
+		changed = append(changed, beforeView.Name)
+	}
+
+	for i := len(beforeViews) - 1; i >= 0; i-- {
+		beforeView := beforeViews[i]
+		afterView := findView(beforeView, afterViews)
+		if afterView != nil && !recreate[beforeView.GetNameForDiff()] {
+			continue
+		}
+		var comment string
+		if afterView != nil && beforeView.StringForDiff() != afterView.StringForDiff() {
+			comment = simplediff.Diff(beforeView.StringForDiff(), afterView.StringForDiff()).String()
+		}
+		dropStmts = append(dropStmts, &DropViewStmt{
+			Comment:      comment,
+			Materialized: beforeView.Materialized,
+			Name:         beforeView.Name,
+		})
+	}
+
+	stale := make([]*ObjectName, 0)
+	for _, afterView := range afterViews {
+		beforeView := findView(afterView, beforeViews)
+		switch {
+		case beforeView == nil || recreate[afterView.GetNameForDiff()]:
+			createStmts = append(createStmts, afterView)
+		case replace[afterView.GetNameForDiff()]:
+			s := *afterView
+			s.Comment = simplediff.Diff(beforeView.StringForDiff(), afterView.StringForDiff()).String()
+			s.OrReplace = true
+			createStmts = append(createStmts, &s)
+			stale = append(stale, afterView.Name)
+		case viewReferencesAny(afterView, stale):
+			// MEMO: a view returns the result of the replaced view as is, but a materialized view keeps the old result.
+			if afterView.Materialized {
+				createStmts = append(createStmts, &RefreshMaterializedViewStmt{Name: afterView.Name})
+			}
+			stale = append(stale, afterView.Name)
+		}
+	}
+
+	return dropStmts, createStmts
+}
+
+func viewStmts(d *DDL) []*CreateViewStmt {
+	views := make([]*CreateViewStmt, 0)
+	for _, stmt := range d.Stmts {
+		if s, ok := stmt.(*CreateViewStmt); ok {
+			views = append(views, s)
+		}
+	}
+	return views
+}
+
+// sortViewsByDependency returns views sorted so that each view comes after the views it depends on.
+// The order of views that do not depend on each other is kept.
+func sortViewsByDependency(views []*CreateViewStmt) []*CreateViewStmt {
+	sorted := make([]*CreateViewStmt, 0, len(views))
+	done := make(map[*CreateViewStmt]bool)
+	for len(sorted) < len(views) {
+		progress := false
+		for _, view := range views {
+			if done[view] {
+				continue
+			}
+			ready := true
+			for _, other := range views {
+				if other != view && !done[other] && viewReferences(view, other.Name) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, view)
+				done[view] = true
+				progress = true
+			}
+		}
+		if !progress {
+			// MEMO: circular references cannot be resolved, so the rest are kept in the original order.
+			for _, view := range views {
+				if !done[view] {
+					sorted = append(sorted, view)
+					done[view] = true
+				}
+			}
+		}
+	}
+	return sorted
+}
+
+// sortViewStmtsByDependency sorts the views in stmts by sortViewsByDependency, keeping the positions of the other statements.
+// If reverse is true, each view comes before the views it depends on.
+func sortViewStmtsByDependency(stmts []Stmt, reverse bool) {
+	positions := make([]int, 0)
+	views := make([]*CreateViewStmt, 0)
+	for i, stmt := range stmts {
+		if s, ok := stmt.(*CreateViewStmt); ok {
+			positions = append(positions, i)
+			views = append(views, s)
+		}
+	}
+	sorted := sortViewsByDependency(views)
+	for i, position := range positions {
+		if reverse {
+			stmts[position] = sorted[len(sorted)-1-i]
+			continue
+		}
+		stmts[position] = sorted[i]
+	}
+}
+
+func viewReferencesAny(view *CreateViewStmt, names []*ObjectName) bool {
+	for _, name := range names {
+		if viewReferences(view, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// viewReferences reports whether the query of view references name, with or without the schema.
+// It may report a column that has the same name as a relation, which only causes an unnecessary recreation.
+func viewReferences(view *CreateViewStmt, name *ObjectName) bool {
+	candidates := []string{strings.ToLower(name.Name.StringForDiff())}
+	if name.Schema != nil {
+		candidates = append(candidates, strings.ToLower(name.StringForDiff()))
+	}
+
+	for _, token := range queryTokens(view.Query) {
+		if token.Type == TOKEN_IDENT && strings.HasPrefix(token.Literal.Str, `'`) {
+			continue
+		}
+		literal := strings.ToLower(NewRawIdent(token.Literal.Str).StringForDiff())
+		for _, candidate := range candidates {
+			if literal == candidate || strings.HasPrefix(literal, candidate+".") || strings.HasSuffix(literal, "."+candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// viewColumnsCompatible reports whether CREATE OR REPLACE VIEW can replace before with after,
+// that is, the columns of before are a prefix of the columns of after.
+// The data types of the columns are not compared, because they cannot be determined from the query.
+func viewColumnsCompatible(before, after *CreateViewStmt) bool {
+	beforeColumns, ok := viewColumns(before)
+	if !ok {
+		return false
+	}
+	afterColumns, ok := viewColumns(after)
+	if !ok || len(beforeColumns) > len(afterColumns) {
+		return false
+	}
+	for i := range beforeColumns {
+		if beforeColumns[i] != afterColumns[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// viewColumns returns the names of the columns of view, or false if they cannot be determined, such as SELECT *.
+//
+//nolint:cyclop
+func viewColumns(view *CreateViewStmt) ([]string, bool) {
+	tokens := queryTokens(view.Query)
+
+	start, depth := -1, 0
+	items := make([][]Token, 0)
+	item := make([]Token, 0)
+LabelTokens:
+	for i, token := range tokens {
+		literal := strings.ToUpper(token.Literal.Str)
+		switch {
+		case start < 0:
+			if depth == 0 && literal == "SELECT" {
+				start = i
+			}
+		case depth == 0 && (literal == "FROM" || literal == "WHERE" || literal == "GROUP" || literal == "ORDER" || literal == "LIMIT" ||
+			literal == "UNION" || literal == "INTERSECT" || literal == "EXCEPT"):
+			break LabelTokens
+		case depth == 0 && token.Type == TOKEN_COMMA:
+			items = append(items, item)
+			item = make([]Token, 0)
+		case len(item) == 0 && depth == 0 && (literal == "DISTINCT" || literal == "ALL"):
+			// MEMO: DISTINCT ON ( expression ) is not supported.
+		default:
+			item = append(item, token)
+		}
+		switch token.Type { //nolint:exhaustive
+		case TOKEN_OPEN_PAREN:
+			depth++
+		case TOKEN_CLOSE_PAREN:
+			depth--
+		}
+	}
+	if start < 0 || len(item) == 0 {
+		return nil, false
+	}
+	items = append(items, item)
+
+	columns := make([]string, 0, len(items))
+	for _, item := range items {
+		column, ok := queryColumnName(item)
+		if !ok {
+			return nil, false
+		}
+		columns = append(columns, column)
+	}
+
+	// MEMO: the column names of CREATE VIEW view_name ( column_name [, ...] ) override the ones of the query.
+	for i := 0; i < len(view.Columns) && i < len(columns); i++ {
+		columns[i] = view.Columns[i].StringForDiff()
+	}
+
+	return columns, true
+}
+
+// queryColumnName returns the name of the column that PostgreSQL gives to an item of a select list.
+//
+//nolint:cyclop
+func queryColumnName(item []Token) (string, bool) {
+	n := len(item)
+	last := item[n-1].Literal.Str
+	columnName := func(literal string) string {
+		return NewRawIdent(literal[strings.LastIndex(literal, ".")+1:]).StringForDiff()
+	}
+
+	switch {
+	case last == "*" || strings.HasSuffix(last, ".*"):
+		return "", false
+	case n >= 2 && strings.EqualFold(item[n-2].Literal.Str, "AS"):
+		// expression AS column_name
+		return NewRawIdent(last).StringForDiff(), true
+	case n == 1 && item[0].Type != TOKEN_IDENT:
+		return "?column?", true
+	case n == 1, n == 2 && strings.HasSuffix(item[0].Literal.Str, "."):
+		// column_name, table_name.column_name, table_name."column_name"
+		return columnName(last), true
+	}
+
+	// expression::data_type
+	depth := 0
+	for i := n - 1; i > 0; i-- {
+		switch item[i].Type { //nolint:exhaustive
+		case TOKEN_CLOSE_PAREN:
+			depth++
+		case TOKEN_OPEN_PAREN:
+			depth--
+		case TOKEN_TYPECAST:
+			if depth == 0 {
+				return queryColumnName(item[:i])
+			}
+		}
+	}
+
+	switch {
+	case n >= 3 && item[1].Type == TOKEN_OPEN_PAREN && last == ")":
+		// function_name(...)
+		return strings.ToLower(columnName(item[0].Literal.Str)), true
+	case strings.EqualFold(item[0].Literal.Str, "CASE") && strings.EqualFold(last, "END"):
+		return "case", true
+	case item[n-1].Type == TOKEN_IDENT && !strings.HasPrefix(last, `'`) && (item[n-2].Type == TOKEN_IDENT || item[n-2].Type == TOKEN_CLOSE_PAREN):
+		// expression column_name
+		return NewRawIdent(last).StringForDiff(), true
+	default:
+		return "?column?", true
+	}
+}
+
+func queryTokens(query string) []Token {
+	l := NewLexer(query)
+	tokens := make([]Token, 0)
+	for token := l.NextToken(); token.Type != TOKEN_EOF; token = l.NextToken() {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// queryForDiff returns query in the form that the query written by hand and the one of pg_views converge on.
+// PostgreSQL qualifies the columns with the relation and parenthesizes the conditions, such as
+// SELECT users.id FROM users WHERE (users.name IS NOT NULL) for SELECT id FROM users WHERE name IS NOT NULL,
+// so the parentheses that do not change the meaning are removed, and so are the qualifiers if the query reads one relation.
+func queryForDiff(query string) string {
+	tokens := withoutRedundantParens(queryTokens(query))
+	qualifiers := singleRelationQualifiers(tokens)
+	if len(qualifiers) == 0 {
+		return normalizeQuery(tokens)
+	}
+
+	result := make([]Token, 0, len(tokens))
+	for i, token := range tokens {
+		literal := token.Literal.Str
+		switch {
+		case token.Type != TOKEN_IDENT || strings.HasPrefix(literal, `'`) || strings.HasPrefix(literal, "$"):
+		case strings.HasSuffix(literal, ".") && i+1 < len(tokens) && qualifiers[NewRawIdent(strings.TrimSuffix(literal, ".")).StringForDiff()]:
+			// table_name."column_name"
+			continue
+		case !strings.HasPrefix(literal, `"`) && strings.Contains(literal, "."):
+			// table_name.column_name
+			if index := strings.LastIndex(literal, "."); qualifiers[NewRawIdent(literal[:index]).StringForDiff()] {
+				token.Literal.Str = literal[index+1:]
+			}
+		}
+		result = append(result, token)
+	}
+	return normalizeQuery(result)
+}
+
+// singleRelationQualifiers returns the names that qualify the columns of the relation if the query is a SELECT that reads one relation,
+// such as users and u of SELECT ... FROM public.users u WHERE ..., otherwise nil.
+//
+//nolint:cyclop
+func singleRelationQualifiers(tokens []Token) map[string]bool {
+	from := -1
+	for i, token := range tokens {
+		literal := strings.ToUpper(token.Literal.Str)
+		switch {
+		case literal == "SELECT" && i > 0, literal == "WITH", literal == "UNION", literal == "INTERSECT", literal == "EXCEPT":
+			// MEMO: the columns of a subquery or another SELECT may be qualified with another relation.
+			return nil
+		case literal == "FROM" && from < 0:
+			from = i
+		}
+	}
+	if from < 0 || len(tokens) == 0 || !strings.EqualFold(tokens[0].Literal.Str, "SELECT") {
+		return nil
+	}
+
+	item := make([]string, 0)
+LabelFrom:
+	for _, token := range tokens[from+1:] {
+		literal := strings.ToUpper(token.Literal.Str)
+		switch {
+		case literal == "WHERE", literal == "GROUP", literal == "HAVING", literal == "WINDOW", literal == "ORDER", literal == "LIMIT", literal == "OFFSET":
+			break LabelFrom
+		case !isIdent(token.Type), queryKeywords[literal] && literal != "AS":
+			// MEMO: a JOIN, a comma or a function in FROM reads more than one relation, or not a relation.
+			return nil
+		case literal != "AS":
+			item = append(item, token.Literal.Str)
+		}
+	}
+	if len(item) == 0 || len(item) > 2 {
+		return nil
+	}
+
+	relation := NewRawIdent(item[0]).StringForDiff()
+	qualifiers := map[string]bool{relation: true, relation[strings.LastIndex(relation, ".")+1:]: true}
+	if len(item) == 2 {
+		qualifiers = map[string]bool{NewRawIdent(item[1]).StringForDiff(): true}
+	}
+	return qualifiers
+}
+
+// withoutRedundantParens returns tokens without the parentheses around a condition that do not change the meaning,
+// that is, the ones whose removal does not change which of AND, OR and NOT applies first.
+func withoutRedundantParens(tokens []Token) []Token {
+	for removed := true; removed; {
+		removed = false
+		for open := range tokens {
+			if tokens[open].Type != TOKEN_OPEN_PAREN {
+				continue
+			}
+			closing := matchingParen(tokens, open)
+			if closing < 0 || !redundantParens(tokens, open, closing) {
+				continue
+			}
+			tokens = append(append(append(make([]Token, 0, len(tokens)-2), tokens[:open]...), tokens[open+1:closing]...), tokens[closing+1:]...)
+			removed = true
+			break
+		}
+	}
+	return tokens
+}
+
+func matchingParen(tokens []Token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch tokens[i].Type { //nolint:exhaustive
+		case TOKEN_OPEN_PAREN:
+			depth++
+		case TOKEN_CLOSE_PAREN:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+//nolint:gochecknoglobals
+var (
+	conditionPrecedingKeywords = map[string]bool{"WHERE": true, "ON": true, "HAVING": true, "WHEN": true, "AND": true, "OR": true, "NOT": true}
+	conditionFollowingKeywords = map[string]bool{
+		"AND": true, "OR": true, "THEN": true,
+		"WHERE": true, "GROUP": true, "HAVING": true, "WINDOW": true, "ORDER": true, "LIMIT": true, "OFFSET": true,
+		"UNION": true, "INTERSECT": true, "EXCEPT": true,
+		"JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "CROSS": true, "NATURAL": true,
+	}
+)
+
+// redundantParens reports whether the parentheses of tokens[open] and tokens[closing] can be removed.
+//
+//nolint:cyclop
+func redundantParens(tokens []Token, open, closing int) bool {
+	inner := tokens[open+1 : closing]
+	if len(inner) == 0 {
+		return false
+	}
+	if first := strings.ToUpper(inner[0].Literal.Str); first == "SELECT" || first == "WITH" || first == "VALUES" {
+		return false
+	}
+
+	var hasAnd, hasOr bool
+	depth := 0
+	for _, token := range inner {
+		switch token.Type { //nolint:exhaustive
+		case TOKEN_OPEN_PAREN:
+			depth++
+		case TOKEN_CLOSE_PAREN:
+			depth--
+		case TOKEN_COMMA:
+			if depth == 0 {
+				// MEMO: ( expression, ... ) is a row or a list.
+				return false
+			}
+		default:
+			if depth == 0 {
+				hasAnd = hasAnd || strings.EqualFold(token.Literal.Str, "AND")
+				hasOr = hasOr || strings.EqualFold(token.Literal.Str, "OR")
+			}
+		}
+	}
+
+	if open == 0 {
+		return false
+	}
+	prev, next := tokens[open-1], ""
+	if closing+1 < len(tokens) {
+		next = strings.ToUpper(tokens[closing+1].Literal.Str)
+	}
+	preceding := strings.ToUpper(prev.Literal.Str)
+	switch {
+	case prev.Type == TOKEN_OPEN_PAREN && next == ")":
+		// ((expression))
+		return true
+	case !conditionPrecedingKeywords[preceding] && prev.Type != TOKEN_OPEN_PAREN:
+		return false
+	case next != "" && next != ")" && !conditionFollowingKeywords[next]:
+		return false
+	case hasOr:
+		// MEMO: AND and NOT apply before OR.
+		return preceding != "AND" && preceding != "NOT" && next != "AND"
+	case hasAnd:
+		return preceding != "NOT"
+	default:
+		// MEMO: the comparisons such as = and IS apply before NOT, AND and OR.
+		return true
+	}
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestViewColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		want   []string
+		wantOk bool
+	}{
+		{
+			name:   "success,columns",
+			input:  `CREATE VIEW v AS SELECT DISTINCT u.id, "Name", u."Age", count(*), u.age::TEXT, age + 1, 1 AS one, CASE WHEN u.age > 1 THEN 1 END, lower(name) lower_name FROM users u;`,
+			want:   []string{"id", "Name", "Age", "count", "age", "?column?", "one", "case", "lower_name"},
+			wantOk: true,
+		},
+		{
+			name:   "success,column_names",
+			input:  `CREATE VIEW v (user_id) AS WITH t AS (SELECT id FROM users) SELECT id, name FROM t UNION SELECT id, name FROM groups;`,
+			want:   []string{"user_id", "name"},
+			wantOk: true,
+		},
+		{
+			name:   "failure,asterisk",
+			input:  `CREATE VIEW v AS SELECT u.* FROM users u;`,
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "failure,VALUES",
+			input:  `CREATE VIEW v AS VALUES (1);`,
+			want:   nil,
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewParser(NewLexer(tt.input)).Parse()
+			require.NoError(t, err)
+
+			actual, ok := viewColumns(d.Stmts[0].(*CreateViewStmt)) //nolint:forcetypeassert
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func TestQueryForDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "success,pg_views",
+			input: `CREATE VIEW v AS SELECT users.id, users.name FROM users WHERE (users.name IS NOT NULL);`,
+			want:  `SELECT id, name FROM users WHERE name IS NOT NULL`,
+		},
+		{
+			name:  "success,alias",
+			input: `CREATE VIEW v AS SELECT u.id FROM public.users u WHERE ((u.a = 1) OR ((u.b = 2) AND (u.c = 3)));`,
+			want:  `SELECT id FROM public.users u WHERE a = 1 OR b = 2 AND c = 3`,
+		},
+		{
+			name:  "success,precedence",
+			input: `CREATE VIEW v AS SELECT id FROM users WHERE (((a = 1) OR (b = 2)) AND (c = 3));`,
+			want:  `SELECT id FROM users WHERE (a = 1 OR b = 2) AND c = 3`,
+		},
+		{
+			name:  "success,expression",
+			input: `CREATE VIEW v AS SELECT (a + b) * c, count((x)) FROM users WHERE NOT (a = 1 AND b = 2) AND x IN (1, 2);`,
+			want:  `SELECT (a + b) * c, count(x) FROM users WHERE NOT (a = 1 AND b = 2) AND x IN (1, 2)`,
+		},
+		{
+			name:  "success,JOIN",
+			input: `CREATE VIEW v AS SELECT a.id FROM a JOIN b ON (a.id = b.id);`,
+			want:  `SELECT a.id FROM a JOIN b ON a.id = b.id`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewParser(NewLexer(tt.input)).Parse()
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, queryForDiff(d.Stmts[0].(*CreateViewStmt).Query)) //nolint:forcetypeassert
+		})
+	}
+}
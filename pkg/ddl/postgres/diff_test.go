@@ -22,6 +22,18 @@ func TestDiff(t *testing.T) {
 		require.ErrorIs(t, err, ddl.ErrNoDifference)
 	})
 
+	t.Run("failure,ddl.ErrNoDifference,RawStmt", func(t *testing.T) {
+		t.Parallel()
+
+		before := &DDL{Stmts: []Stmt{&RawStmt{Raw: "GRANT SELECT ON users TO readonly"}}}
+		after := &DDL{Stmts: []Stmt{&RawStmt{Raw: "GRANT SELECT ON users TO readwrite"}}}
+		_, err := Diff(before, after)
+		require.ErrorIs(t, err, ddl.ErrNoDifference)
+		result, err := Diff(nil, after)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(result.Stmts))
+	})
+
 	t.Run("failure,ddl.ErrNotSupported,DropTableStmt", func(t *testing.T) {
 		t.Parallel()
 
@@ -355,6 +367,351 @@ CREATE UNIQUE INDEX IF NOT EXISTS public.users_idx_by_username ON users (usernam
 		}
 	})
 
+	t.Run("success,DiffUseIndexConcurrently", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE INDEX users_idx_by_username ON users (username); CREATE INDEX users_idx_by_age ON users (age);`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE INDEX users_idx_by_username ON users (username, age); CREATE INDEX users_idx_by_email ON users (email);`)).Parse()
+		require.NoError(t, err)
+
+		expected := `DROP INDEX CONCURRENTLY users_idx_by_age;
+CREATE INDEX CONCURRENTLY users_idx_by_email ON users (email);
+-- -CREATE INDEX users_idx_by_username ON users (username);
+-- +CREATE INDEX users_idx_by_username ON users (username, age);
+--  
+DROP INDEX CONCURRENTLY users_idx_by_username;
+CREATE INDEX CONCURRENTLY users_idx_by_username ON users (username, age);
+`
+		actual, err := Diff(before, after, DiffUseIndexConcurrently(true))
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+
+		// MEMO: DiffUseIndexConcurrently must not modify the input AST.
+		assert.Equal(t, "CREATE INDEX users_idx_by_username ON users (username, age);\n", after.Stmts[0].String())
+	})
+
+	t.Run("success,DiffUseSafeConstraints", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE public.users ( id UUID NOT NULL, group_id UUID, username TEXT, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE public.users ( id UUID NOT NULL, group_id UUID REFERENCES groups (id), username TEXT NOT NULL UNIQUE, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -username TEXT
+-- +username TEXT NOT NULL
+ALTER TABLE public.users ADD CONSTRAINT users_username_not_null CHECK (username IS NOT NULL) NOT VALID;
+ALTER TABLE public.users VALIDATE CONSTRAINT users_username_not_null;
+ALTER TABLE public.users ALTER COLUMN username SET NOT NULL;
+ALTER TABLE public.users DROP CONSTRAINT users_username_not_null;
+-- -
+-- +CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id)
+ALTER TABLE public.users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) NOT VALID;
+-- -
+-- +CONSTRAINT users_unique_username UNIQUE (username)
+ALTER TABLE public.users ADD CONSTRAINT users_unique_username UNIQUE (username);
+ALTER TABLE public.users VALIDATE CONSTRAINT users_group_id_fkey;
+`
+		actual, err := Diff(before, after, DiffUseSafeConstraints(true))
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,TYPE_DOMAIN_EXTENSION", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE EXTENSION pgcrypto WITH SCHEMA public VERSION '1.2';
+CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+CREATE DOMAIN positive_int AS INTEGER CHECK (VALUE > 0);
+CREATE DOMAIN email AS TEXT;
+CREATE TABLE public.users ( id UUID NOT NULL, mood mood NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE public.users ( id UUID NOT NULL, mood mood NOT NULL, status status NOT NULL, age positive_int, PRIMARY KEY (id) );
+CREATE DOMAIN positive_int AS INTEGER DEFAULT 1 NOT NULL CHECK (VALUE > 0);
+CREATE TYPE status AS ENUM ('active', 'inactive');
+CREATE TYPE mood AS ENUM ('very_sad', 'sad', 'fine', 'happy', 'joyful');
+CREATE EXTENSION pgcrypto WITH SCHEMA public VERSION '1.3';
+CREATE EXTENSION "uuid-ossp";`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -CREATE EXTENSION pgcrypto WITH SCHEMA public VERSION '1.2';
+-- +CREATE EXTENSION pgcrypto WITH SCHEMA public VERSION '1.3';
+--  
+ALTER EXTENSION pgcrypto UPDATE TO '1.3';
+CREATE EXTENSION "uuid-ossp";
+CREATE TYPE status AS ENUM ('active', 'inactive');
+-- -CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+-- +CREATE TYPE mood AS ENUM ('very_sad', 'sad', 'fine', 'happy', 'joyful');
+--  
+ALTER TYPE mood RENAME VALUE 'ok' TO 'fine';
+ALTER TYPE mood ADD VALUE 'very_sad' BEFORE 'sad';
+ALTER TYPE mood ADD VALUE 'joyful' AFTER 'happy';
+-- -
+-- +DEFAULT 1
+ALTER DOMAIN positive_int SET DEFAULT 1;
+-- -
+-- +NOT NULL
+ALTER DOMAIN positive_int SET NOT NULL;
+-- -
+-- +status status NOT NULL
+ALTER TABLE public.users ADD COLUMN status status NOT NULL;
+-- -
+-- +age positive_int
+ALTER TABLE public.users ADD COLUMN age positive_int;
+DROP DOMAIN email;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,DOMAIN_DATA_TYPE", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE DOMAIN email AS VARCHAR(100);
+CREATE TABLE public.users ( id UUID NOT NULL, email email NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE DOMAIN email AS VARCHAR(255);
+CREATE TABLE public.users ( id UUID NOT NULL, email email NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -CREATE DOMAIN email AS CHARACTER VARYING(100);
+-- +CREATE DOMAIN email AS CHARACTER VARYING(255);
+--  
+ALTER DOMAIN email RENAME TO email_ddlctl_old;
+CREATE DOMAIN email AS VARCHAR(255);
+ALTER TABLE public.users ALTER COLUMN email SET DATA TYPE email;
+DROP DOMAIN email_ddlctl_old;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("failure,ddl.ErrNotSupported,TYPE", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TYPE mood AS ENUM ('happy', 'ok', 'sad');`)).Parse()
+		require.NoError(t, err)
+
+		actual, err := Diff(before, after)
+		require.ErrorIs(t, err, ddl.ErrNotSupported)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("success,SERIAL_equals_nextval", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE public.users ( id SERIAL NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE SEQUENCE public.users_id_seq AS integer START WITH 1 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1 OWNED BY public.users.id;
+CREATE TABLE public.users ( id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		actual, err := Diff(before, after)
+		require.ErrorIs(t, err, ddl.ErrNoDifference)
+		assert.Equal(t, "", actual.String())
+	})
+
+	t.Run("success,nextval_to_IDENTITY", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE SEQUENCE public.users_id_seq AS integer START WITH 1 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1 OWNED BY public.users.id;
+CREATE TABLE public.users ( id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE public.users ( id INTEGER GENERATED BY DEFAULT AS IDENTITY NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL
+-- +id INTEGER NOT NULL GENERATED BY DEFAULT AS IDENTITY
+ALTER TABLE public.users ALTER COLUMN id DROP DEFAULT;
+-- -id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL
+-- +id INTEGER NOT NULL GENERATED BY DEFAULT AS IDENTITY
+ALTER TABLE public.users ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY;
+-- continue the identity of id from the current value of users_id_seq
+SELECT setval(pg_get_serial_sequence('public.users', 'id'), last_value, is_called) FROM users_id_seq;
+DROP SEQUENCE IF EXISTS public.users_id_seq;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+
+		phased := SplitPhases(actual)
+		assert.Equal(t, "", phased.Expand.String())
+		assert.Equal(t, expected, phased.Contract.String())
+	})
+
+	t.Run("success,SERIAL_IDENTITY", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE public.users ( id SERIAL NOT NULL, group_id BIGSERIAL, seq BIGINT GENERATED BY DEFAULT AS IDENTITY, num BIGINT GENERATED BY DEFAULT AS IDENTITY, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE public.users ( id INTEGER GENERATED ALWAYS AS IDENTITY, group_id SERIAL, seq BIGINT GENERATED ALWAYS AS IDENTITY (START WITH 10), num BIGINT NOT NULL, PRIMARY KEY (id) );`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL
+-- +id INTEGER NOT NULL GENERATED ALWAYS AS IDENTITY
+ALTER TABLE public.users ALTER COLUMN id DROP DEFAULT;
+-- -id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL
+-- +id INTEGER NOT NULL GENERATED ALWAYS AS IDENTITY
+ALTER TABLE public.users ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY;
+-- continue the identity of id from the current value of users_id_seq
+SELECT setval(pg_get_serial_sequence('public.users', 'id'), last_value, is_called) FROM users_id_seq;
+DROP SEQUENCE IF EXISTS public.users_id_seq;
+-- -group_id BIGINT DEFAULT nextval('users_group_id_seq'::regclass) NOT NULL
+-- +group_id INTEGER DEFAULT nextval('users_group_id_seq'::regclass) NOT NULL
+ALTER TABLE public.users ALTER COLUMN group_id SET DATA TYPE INTEGER;
+ALTER SEQUENCE public.users_group_id_seq AS INTEGER;
+-- -seq BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY
+-- +seq BIGINT NOT NULL GENERATED ALWAYS AS IDENTITY (START WITH 10)
+ALTER TABLE public.users ALTER COLUMN seq SET GENERATED ALWAYS SET START WITH 10;
+-- -num BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY
+-- +num BIGINT NOT NULL
+ALTER TABLE public.users ALTER COLUMN num DROP IDENTITY;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,SEQUENCE", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE SEQUENCE counter INCREMENT BY 1 CACHE 1;
+CREATE SEQUENCE old_counter;`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE SEQUENCE counter INCREMENT BY 2 CACHE 1 CYCLE;
+CREATE SEQUENCE orders_seq OWNED BY orders.id;
+CREATE TABLE orders ( id BIGINT NOT NULL DEFAULT nextval('orders_seq') );`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -CREATE SEQUENCE counter INCREMENT BY 1 CACHE 1;
+-- +CREATE SEQUENCE counter INCREMENT BY 2 CACHE 1 CYCLE;
+--  
+ALTER SEQUENCE counter INCREMENT BY 2 CYCLE;
+CREATE SEQUENCE orders_seq;
+CREATE TABLE orders (
+    id BIGINT DEFAULT nextval('orders_seq') NOT NULL
+);
+ALTER SEQUENCE orders_seq OWNED BY orders.id;
+DROP SEQUENCE old_counter;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,VIEW", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INT, name TEXT, age INT);
+CREATE VIEW v1 AS SELECT id, name FROM users;
+CREATE VIEW v2 AS SELECT id FROM v1;
+CREATE MATERIALIZED VIEW mv AS SELECT id FROM v1;
+CREATE VIEW old AS SELECT 1;`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INT, name TEXT, age INT);
+CREATE MATERIALIZED VIEW mv AS SELECT id FROM v1;
+CREATE VIEW v3 AS SELECT id FROM v2;
+CREATE VIEW v2 AS SELECT id FROM v1;
+CREATE VIEW v1 AS SELECT id, name, age FROM users WHERE age > 1;`)).Parse()
+		require.NoError(t, err)
+
+		expected := `DROP VIEW old;
+-- -CREATE VIEW v1 AS SELECT id, name FROM users;
+-- +CREATE VIEW v1 AS SELECT id, name, age FROM users WHERE age > 1;
+--  
+CREATE OR REPLACE VIEW v1 AS SELECT id, name, age FROM users WHERE age > 1;
+REFRESH MATERIALIZED VIEW mv;
+CREATE VIEW v3 AS SELECT id FROM v2;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,VIEW,pg_views", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users ( id UUID NOT NULL, name TEXT, PRIMARY KEY (id) );
+CREATE VIEW v AS SELECT users.id, users.name FROM users WHERE (users.name IS NOT NULL);`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE users ( id UUID NOT NULL, name TEXT, PRIMARY KEY (id) );
+CREATE VIEW v AS SELECT id, name FROM users WHERE name IS NOT NULL;`)).Parse()
+		require.NoError(t, err)
+
+		actual, err := Diff(before, after)
+		require.ErrorIs(t, err, ddl.ErrNoDifference)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("success,VIEW,recreate", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INT, name TEXT);
+CREATE VIEW v1 AS SELECT id, name FROM users;
+CREATE MATERIALIZED VIEW mv AS SELECT id FROM v1;
+CREATE VIEW v2 AS SELECT name, id FROM v1;
+CREATE VIEW other AS SELECT 1 AS one;`)).Parse()
+		require.NoError(t, err)
+
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INT, name VARCHAR(10));
+CREATE VIEW v1 AS SELECT id, name FROM users;
+CREATE MATERIALIZED VIEW mv AS SELECT id FROM v1;
+CREATE VIEW v2 AS SELECT id, name FROM v1;
+CREATE VIEW other AS SELECT 1 AS one;`)).Parse()
+		require.NoError(t, err)
+
+		expected := `-- -CREATE VIEW v2 AS SELECT name, id FROM v1;
+-- +CREATE VIEW v2 AS SELECT id, name FROM v1;
+--  
+DROP VIEW v2;
+DROP MATERIALIZED VIEW mv;
+DROP VIEW v1;
+-- -name TEXT
+-- +name VARCHAR(10)
+ALTER TABLE users ALTER COLUMN name SET DATA TYPE VARCHAR(10);
+CREATE VIEW v1 AS SELECT id, name FROM users;
+CREATE MATERIALIZED VIEW mv AS SELECT id FROM v1;
+CREATE VIEW v2 AS SELECT id, name FROM v1;
+`
+		actual, err := Diff(before, after)
+		require.NoError(t, err)
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
 	t.Run("success,VARCHAR(10)->VARCHAR(11)", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+
+		input := `-- users table
+create table public.users (
+  -- primary key
+  id uuid not null,
+  "name" varchar(255) not null, age int default 0,
+  -- the foreign key to groups
+  constraint users_group_fkey foreign key (group_id) references groups (id),
+  primary key (id)
+);
+--index
+create unique index users_idx_name on public.users (name);
+-- trailing comment
+`
+		expected := `-- users table
+CREATE TABLE public.users (
+    -- primary key
+    id     UUID NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    age    INT DEFAULT 0,
+    -- the foreign key to groups
+    CONSTRAINT users_group_fkey FOREIGN KEY (group_id) REFERENCES groups (id),
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+
+-- index
+CREATE UNIQUE INDEX users_idx_name ON public.users (name);
+
+-- trailing comment
+`
+
+		d, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+		actual := Format(d)
+		assert.Equal(t, expected, actual)
+
+		d, err = NewParser(NewLexer(actual)).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, expected, Format(d))
+	})
+
+	t.Run("success,trailing_comment", func(t *testing.T) {
+		t.Parallel()
+
+		input := `-- header comment
+
+-- users table
+create table public.users (
+  id int NOT NULL, -- trailing id comment
+  -- name
+  name text not null, -- trailing name comment
+  primary key (id) -- trailing pk comment
+);
+`
+		expected := `-- header comment
+
+-- users table
+CREATE TABLE public.users (
+    id   INT NOT NULL, -- trailing id comment
+    -- name
+    name TEXT NOT NULL, -- trailing name comment
+    CONSTRAINT users_pkey PRIMARY KEY (id) -- trailing pk comment
+);
+`
+
+		d, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+		actual := Format(d)
+		assert.Equal(t, expected, actual)
+
+		d, err = NewParser(NewLexer(actual)).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, expected, Format(d))
+	})
+
+	t.Run("success,comment_inside_and_before_close", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the comment inside the column definition precedes the column, and the one before ")" is kept before it.
+		input := `CREATE TABLE users (
+ id integer -- inside column
+ not null,
+ name text
+ -- before close
+);
+`
+		expected := `CREATE TABLE users (
+    -- inside column
+    id   INTEGER NOT NULL,
+    name TEXT
+    -- before close
+);
+`
+
+		d, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+		actual := Format(d)
+		assert.Equal(t, expected, actual)
+
+		d, err = NewParser(NewLexer(actual)).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, expected, Format(d))
+	})
+
+	t.Run("success,nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "", Format(nil))
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 )
 
 func Test_lookupIdent(t *testing.T) {
@@ -28,13 +30,34 @@ func Test_lookupIdent(t *testing.T) {
 		{name: "success,TRUNCATE", input: "TRUNCATE", want: TOKEN_TRUNCATE},
 		{name: "success,DELETE", input: "DELETE", want: TOKEN_DELETE},
 		{name: "success,UPDATE", input: "UPDATE", want: TOKEN_UPDATE},
+		{name: "success,REFRESH", input: "REFRESH", want: TOKEN_REFRESH},
 		{name: "success,TABLE", input: "TABLE", want: TOKEN_TABLE},
 		{name: "success,INDEX", input: "INDEX", want: TOKEN_INDEX},
 		{name: "success,VIEW", input: "VIEW", want: TOKEN_VIEW},
+		{name: "success,TYPE", input: "TYPE", want: TOKEN_TYPE},
+		{name: "success,DOMAIN", input: "DOMAIN", want: TOKEN_DOMAIN},
+		{name: "success,EXTENSION", input: "EXTENSION", want: TOKEN_EXTENSION},
+		{name: "success,SEQUENCE", input: "SEQUENCE", want: TOKEN_SEQUENCE},
 		{name: "success,IF", input: "IF", want: TOKEN_IF},
 		{name: "success,EXISTS", input: "EXISTS", want: TOKEN_EXISTS},
 		{name: "success,ON", input: "ON", want: TOKEN_ON},
 		{name: "success,TO", input: "TO", want: TOKEN_TO},
+		{name: "success,CONCURRENTLY", input: "CONCURRENTLY", want: TOKEN_CONCURRENTLY},
+		{name: "success,AS", input: "AS", want: TOKEN_AS},
+		{name: "success,ENUM", input: "ENUM", want: TOKEN_ENUM},
+		{name: "success,SCHEMA", input: "SCHEMA", want: TOKEN_SCHEMA},
+		{name: "success,VERSION", input: "VERSION", want: TOKEN_VERSION},
+		{name: "success,INCREMENT", input: "INCREMENT", want: TOKEN_INCREMENT},
+		{name: "success,BY", input: "BY", want: TOKEN_BY},
+		{name: "success,MINVALUE", input: "MINVALUE", want: TOKEN_MINVALUE},
+		{name: "success,MAXVALUE", input: "MAXVALUE", want: TOKEN_MAXVALUE},
+		{name: "success,START", input: "START", want: TOKEN_START},
+		{name: "success,CACHE", input: "CACHE", want: TOKEN_CACHE},
+		{name: "success,CYCLE", input: "CYCLE", want: TOKEN_CYCLE},
+		{name: "success,OWNED", input: "OWNED", want: TOKEN_OWNED},
+		{name: "success,OR", input: "OR", want: TOKEN_OR},
+		{name: "success,REPLACE", input: "REPLACE", want: TOKEN_REPLACE},
+		{name: "success,MATERIALIZED", input: "MATERIALIZED", want: TOKEN_MATERIALIZED},
 		{name: "success,BOOLEAN", input: "BOOLEAN", want: TOKEN_BOOLEAN},
 		{name: "success,SMALLINT", input: "SMALLINT", want: TOKEN_SMALLINT},
 		{name: "success,INTEGER", input: "INTEGER", want: TOKEN_INTEGER},
@@ -67,6 +90,9 @@ func Test_lookupIdent(t *testing.T) {
 		{name: "success,CASCADE", input: "CASCADE", want: TOKEN_CASCADE},
 		{name: "success,NO", input: "NO", want: TOKEN_NO},
 		{name: "success,ACTION", input: "ACTION", want: TOKEN_ACTION},
+		{name: "success,GENERATED", input: "GENERATED", want: TOKEN_GENERATED},
+		{name: "success,ALWAYS", input: "ALWAYS", want: TOKEN_ALWAYS},
+		{name: "success,IDENTITY", input: "IDENTITY", want: TOKEN_IDENTITY},
 		{name: "success,CONSTRAINT", input: "CONSTRAINT", want: TOKEN_CONSTRAINT},
 		{name: "success,PRIMARY", input: "PRIMARY", want: TOKEN_PRIMARY},
 		{name: "success,KEY", input: "KEY", want: TOKEN_KEY},
@@ -112,64 +138,64 @@ func TestLex(t *testing.T) {
     UNIQUE ("email")
 );`,
 			want: []Token{
-				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}},
-				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}},
-				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_UUID, Literal: Literal{Str: "UUID"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}},
-				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}},
-				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}},
-				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}},
-				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}},
+				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}, Pos: ddl.Position{Offset: 0, Line: 1, Column: 1}},
+				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}, Pos: ddl.Position{Offset: 7, Line: 1, Column: 8}},
+				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}, Pos: ddl.Position{Offset: 13, Line: 1, Column: 14}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 16, Line: 1, Column: 17}},
+				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}, Pos: ddl.Position{Offset: 20, Line: 1, Column: 21}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}, Pos: ddl.Position{Offset: 27, Line: 1, Column: 28}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 35, Line: 1, Column: 36}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 41, Line: 2, Column: 5}},
+				{Type: TOKEN_UUID, Literal: Literal{Str: "UUID"}, Pos: ddl.Position{Offset: 54, Line: 2, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 67, Line: 2, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 71, Line: 2, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 75, Line: 2, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}, Pos: ddl.Position{Offset: 81, Line: 3, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 94, Line: 3, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 101, Line: 3, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 102, Line: 3, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 105, Line: 3, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 107, Line: 3, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 111, Line: 3, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 115, Line: 3, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 121, Line: 4, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 134, Line: 4, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 141, Line: 4, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 142, Line: 4, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 145, Line: 4, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 147, Line: 4, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 151, Line: 4, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 155, Line: 4, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}, Pos: ddl.Position{Offset: 161, Line: 5, Column: 5}},
+				{Type: TOKEN_VARCHAR, Literal: Literal{Str: "VARCHAR"}, Pos: ddl.Position{Offset: 174, Line: 5, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 181, Line: 5, Column: 25}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 182, Line: 5, Column: 26}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 185, Line: 5, Column: 29}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 187, Line: 5, Column: 31}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 191, Line: 5, Column: 35}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 195, Line: 5, Column: 39}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}, Pos: ddl.Position{Offset: 201, Line: 6, Column: 5}},
+				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}, Pos: ddl.Position{Offset: 214, Line: 6, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 228, Line: 6, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 232, Line: 6, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 236, Line: 6, Column: 40}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}, Pos: ddl.Position{Offset: 242, Line: 7, Column: 5}},
+				{Type: TOKEN_TIMESTAMPTZ, Literal: Literal{Str: "TIMESTAMPTZ"}, Pos: ddl.Position{Offset: 255, Line: 7, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 269, Line: 7, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 273, Line: 7, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 277, Line: 7, Column: 40}},
+				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}, Pos: ddl.Position{Offset: 283, Line: 8, Column: 5}},
+				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}, Pos: ddl.Position{Offset: 291, Line: 8, Column: 13}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 295, Line: 8, Column: 17}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 296, Line: 8, Column: 18}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 305, Line: 8, Column: 27}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 306, Line: 8, Column: 28}},
+				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}, Pos: ddl.Position{Offset: 312, Line: 9, Column: 5}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 319, Line: 9, Column: 12}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 320, Line: 9, Column: 13}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 327, Line: 9, Column: 20}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 329, Line: 10, Column: 1}},
+				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}, Pos: ddl.Position{Offset: 330, Line: 10, Column: 2}},
 			},
 		},
 	}
@@ -219,6 +245,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "|"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -227,6 +254,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: ":"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -235,6 +263,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "!"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 	}
@@ -4,12 +4,15 @@ package postgres
 // MEMO: https://www.postgresql.jp/docs/11/ddl-constraints.html
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hakadoriya/z.go/pathz/filepathz"
-	"github.com/hakadoriya/z.go/stringz"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
 
@@ -46,45 +49,108 @@ func NewIdent(name, quotationMark, raw string) *Ident {
 	}
 }
 
+//nolint:gochecknoglobals
+var plainIdentRegex = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// newDerivedIdent returns the identifier of name derived from other identifiers, such as a constraint name derived from the table name.
+// It is quoted unless name is a plain identifier, so that the result of String can be parsed again.
+func newDerivedIdent(name string) *Ident {
+	if plainIdentRegex.MatchString(name) {
+		return NewRawIdent(name)
+	}
+	return NewIdent(name, `"`, `"`+name+`"`)
+}
+
 // Parser はSQL文を解析するパーサーです。
 type Parser struct {
 	l            *Lexer
 	currentToken Token
 	peekToken    Token
+	lenient      bool
+	warnings     []*ddl.ParseError
+	// collecting reports whether nextToken collects the comments of the tokens into collected.
+	collecting bool
+	collected  []string
+}
+
+type ParserOption interface {
+	apply(p *Parser)
+}
+
+// ParserUseLenient makes Parse keep the statements that it does not support, such as GRANT, as RawStmt
+// and report them as Warnings, instead of returning errors.
+func ParserUseLenient(lenient bool) ParserOption { //nolint:ireturn
+	return &parserUseLenient{
+		lenient: lenient,
+	}
+}
+
+type parserUseLenient struct {
+	lenient bool
+}
+
+func (o *parserUseLenient) apply(p *Parser) {
+	p.lenient = o.lenient
 }
 
 // NewParser は新しいParserを生成します。
-func NewParser(l *Lexer) *Parser {
+func NewParser(l *Lexer, opts ...ParserOption) *Parser {
 	p := &Parser{
 		l: l,
 	}
 
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
 	return p
 }
 
+// Warnings は lenient モードで読み飛ばした文の警告を返します。
+func (p *Parser) Warnings() []*ddl.ParseError {
+	return p.warnings
+}
+
 // nextToken は次のトークンを読み込みます。
 func (p *Parser) nextToken() {
 	p.currentToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+	if p.collecting {
+		p.collected = append(p.collected, p.currentToken.Comments...)
+	}
 
 	_, file, line, _ := runtime.Caller(1)
 	logs.Trace.Printf("🪲: nextToken: caller=%s:%d currentToken: %#v, peekToken: %#v", filepathz.ExtractShortPath(file), line, p.currentToken, p.peekToken)
 }
 
 // Parse はSQL文を解析します。
+// 解析できない文があっても次の文から解析を続け、すべてのエラーを ddl.ParseErrors として返します。
 func (p *Parser) Parse() (*DDL, error) { //nolint:ireturn
 	p.nextToken() // current = ""
 	p.nextToken() // current = CREATE or ALTER or ...
 
 	d := &DDL{}
+	var errs ddl.ParseErrors
+
+	// MEMO: the comments separated from the first statement by a blank line are not the comments of the statement.
+	for i := len(p.currentToken.Comments) - 1; i >= 0 && p.currentToken.Type != TOKEN_EOF; i-- {
+		if p.currentToken.Comments[i] == "" {
+			d.Header, p.currentToken.Comments = p.currentToken.Comments[:i], p.currentToken.Comments[i+1:]
+			break
+		}
+	}
 
 LabelDDL:
 	for {
 		switch p.currentToken.Type { //nolint:exhaustive
 		case TOKEN_CREATE:
+			start := p.currentToken
 			stmt, err := p.parseCreateStatement()
 			if err != nil {
-				return nil, apperr.Errorf("parseCreateStatement: %w", err)
+				if err := p.recoverStmt(d, start, apperr.Errorf("parseCreateStatement: %w", err)); err != nil {
+					errs = append(errs, err)
+				}
+				break
 			}
 			d.Stmts = append(d.Stmts, stmt)
 		case TOKEN_CLOSE_PAREN:
@@ -92,17 +158,85 @@ LabelDDL:
 		case TOKEN_SEMICOLON:
 			// do nothing
 		case TOKEN_EOF:
+			d.Comments = p.currentToken.Comments
 			break LabelDDL
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			if err := p.recoverStmt(d, p.currentToken, p.newParseError(p.currentToken, ddl.ErrUnsupportedStatement)); err != nil {
+				errs = append(errs, err)
+			}
 		}
 
 		p.nextToken()
 	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return d, nil
 }
 
+// recoverStmt は解析できなかった文を ; または EOF まで読み飛ばします。
+// lenient モードではサポートしていない文を RawStmt として d に追加して警告とし、それ以外の場合は err を返します。
+func (p *Parser) recoverStmt(d *DDL, start Token, err error) error {
+	end := start.Pos.Offset + len(start.Literal.Str)
+	for !p.isCurrentToken(TOKEN_SEMICOLON) && !p.isCurrentToken(TOKEN_EOF) {
+		end = max(end, p.currentToken.Pos.Offset+len(p.currentToken.Literal.Str))
+		p.nextToken()
+	}
+
+	var parseErr *ddl.ParseError
+	if !p.lenient || !errors.Is(err, ddl.ErrUnsupportedStatement) || !errors.As(err, &parseErr) {
+		return err
+	}
+	p.warnings = append(p.warnings, parseErr)
+	d.Stmts = append(d.Stmts, &RawStmt{
+		Comment: strings.Join(start.Comments, "\n"),
+		Raw:     p.l.input[start.Pos.Offset:end],
+	})
+	return nil
+}
+
+// trailingComment returns the comment on the same line after the current token, which is "," or ")" after an element of CREATE TABLE,
+// and removes it from the comments of the token, so that it is not the comment that precedes the next element.
+func (p *Parser) trailingComment() string {
+	for _, token := range []*Token{&p.currentToken, &p.peekToken} {
+		if token.HasTrailingComment && len(token.Comments) > 0 {
+			comment := token.Comments[0]
+			token.Comments, token.HasTrailingComment = token.Comments[1:], false
+			return comment
+		}
+		if token.Type != TOKEN_COMMA {
+			break
+		}
+	}
+	return ""
+}
+
+// collectComments starts to collect the comments of the tokens after the current one, which is the first token of an element of CREATE TABLE.
+func (p *Parser) collectComments() {
+	p.collecting, p.collected = true, nil
+}
+
+// innerComments stops collecting the comments, and returns the ones inside the element without the blank lines.
+// The comments of the current token, which is "," or ")" after the element, are not inside the element,
+// because they are the trailing comment of the element or the ones that precede the next element.
+func (p *Parser) innerComments() []string {
+	p.collecting = false
+	collected := p.collected[:max(0, len(p.collected)-len(p.currentToken.Comments))]
+	p.collected = nil
+
+	var comments []string
+	for _, comment := range collected {
+		if comment != "" {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
+
 func (p *Parser) parseCreateStatement() (Stmt, error) { //nolint:ireturn
+	comment := strings.Join(p.currentToken.Comments, "\n")
+	pos := p.currentToken.Pos
 	p.nextToken() // current = TABLE or INDEX or ...
 
 	switch p.currentToken.Type { //nolint:exhaustive
@@ -111,15 +245,54 @@ func (p *Parser) parseCreateStatement() (Stmt, error) { //nolint:ireturn
 		if err != nil {
 			return nil, apperr.Errorf("parseCreateTableStmt: %w", err)
 		}
+		stmt.Comment = comment
+		stmt.Pos = pos
 		return stmt, nil
 	case TOKEN_INDEX, TOKEN_UNIQUE:
 		stmt, err := p.parseCreateIndexStmt()
 		if err != nil {
 			return nil, apperr.Errorf("parseCreateIndexStmt: %w", err)
 		}
+		stmt.Comment = comment
+		stmt.Pos = pos
+		return stmt, nil
+	case TOKEN_TYPE:
+		stmt, err := p.parseCreateTypeStmt()
+		if err != nil {
+			return nil, apperr.Errorf("parseCreateTypeStmt: %w", err)
+		}
+		stmt.Comment = comment
+		return stmt, nil
+	case TOKEN_DOMAIN:
+		stmt, err := p.parseCreateDomainStmt()
+		if err != nil {
+			return nil, apperr.Errorf("parseCreateDomainStmt: %w", err)
+		}
+		stmt.Comment = comment
+		return stmt, nil
+	case TOKEN_EXTENSION:
+		stmt, err := p.parseCreateExtensionStmt()
+		if err != nil {
+			return nil, apperr.Errorf("parseCreateExtensionStmt: %w", err)
+		}
+		stmt.Comment = comment
+		return stmt, nil
+	case TOKEN_SEQUENCE:
+		stmt, err := p.parseCreateSequenceStmt()
+		if err != nil {
+			return nil, apperr.Errorf("parseCreateSequenceStmt: %w", err)
+		}
+		stmt.Comment = comment
+		return stmt, nil
+	case TOKEN_OR, TOKEN_MATERIALIZED, TOKEN_VIEW:
+		stmt, err := p.parseCreateViewStmt()
+		if err != nil {
+			return nil, apperr.Errorf("parseCreateViewStmt: %w", err)
+		}
+		stmt.Comment = comment
 		return stmt, nil
 	default:
-		return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, p.newParseError(p.currentToken, ddl.ErrUnsupportedStatement)
 	}
 }
 
@@ -161,11 +334,19 @@ func (p *Parser) parseCreateTableStmt() (*CreateTableStmt, error) {
 LabelColumns:
 	for {
 		switch { //nolint:exhaustive
-		case p.isCurrentToken(TOKEN_IDENT):
+		case isIdent(p.currentToken.Type):
+			comments := p.currentToken.Comments
+			pos := p.currentToken.Pos
+			p.collectComments()
 			column, constraints, err := p.parseColumn(createTableStmt.Name.Name)
+			inner := p.innerComments()
 			if err != nil {
 				return nil, apperr.Errorf(errFmtPrefix+"parseColumn: %w", err)
 			}
+			// MEMO: the comments inside the column definition, such as the one before NOT NULL on the next line, precede the column.
+			column.Comments = append(slices.Clip(comments), inner...)
+			column.TrailingComment = p.trailingComment()
+			column.Pos = pos
 			createTableStmt.Columns = append(createTableStmt.Columns, column)
 			if len(constraints) > 0 {
 				for _, c := range constraints {
@@ -177,19 +358,23 @@ LabelColumns:
 			if err != nil {
 				return nil, apperr.Errorf(errFmtPrefix+"parseTableConstraint: %w", err)
 			}
+			constraint.setTrailingComment(p.trailingComment())
 			createTableStmt.Constraints = createTableStmt.Constraints.Append(constraint)
 		case p.isCurrentToken(TOKEN_COMMA):
+			// MEMO: the comments before "," except the trailing comment of the previous element precede the next element.
+			p.peekToken.Comments = append(slices.Clip(p.currentToken.Comments), p.peekToken.Comments...)
 			p.nextToken()
 			continue
 		case p.isCurrentToken(TOKEN_CLOSE_PAREN):
+			createTableStmt.Footer = p.currentToken.Comments
 			switch p.peekToken.Type { //nolint:exhaustive
 			case TOKEN_SEMICOLON, TOKEN_EOF:
 				break LabelColumns
 			default:
-				return nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedPeekToken)
+				return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.peekToken, ddl.ErrUnexpectedPeekToken))
 			}
 		default:
-			return nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 		}
 	}
 
@@ -205,6 +390,11 @@ func (p *Parser) parseCreateIndexStmt() (*CreateIndexStmt, error) {
 		p.nextToken() // current = INDEX
 	}
 
+	if p.isPeekToken(TOKEN_CONCURRENTLY) {
+		p.nextToken() // current = CONCURRENTLY
+		createIndexStmt.Concurrently = true
+	}
+
 	if p.isPeekToken(TOKEN_IF) {
 		p.nextToken() // current = IF
 		if err := p.checkPeekToken(TOKEN_NOT); err != nil {
@@ -262,13 +452,494 @@ func (p *Parser) parseCreateIndexStmt() (*CreateIndexStmt, error) {
 	return createIndexStmt, nil
 }
 
+func (p *Parser) parseCreateTypeStmt() (*CreateTypeStmt, error) {
+	createTypeStmt := &CreateTypeStmt{}
+
+	p.nextToken() // current = type_name
+	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkCurrentToken: %w", err)
+	}
+
+	createTypeStmt.Name = NewObjectName(p.currentToken.Literal.Str)
+	errFmtPrefix := fmt.Sprintf("type_name=%s: ", createTypeStmt.Name.StringForDiff())
+
+	if err := p.checkPeekToken(TOKEN_AS); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = AS
+	if err := p.checkPeekToken(TOKEN_ENUM); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = ENUM
+	if err := p.checkPeekToken(TOKEN_OPEN_PAREN); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = (
+
+	p.nextToken() // current = 'value'
+
+LabelValues:
+	for {
+		switch p.currentToken.Type { //nolint:exhaustive
+		case TOKEN_IDENT:
+			createTypeStmt.Values = append(createTypeStmt.Values, NewRawIdent(p.currentToken.Literal.Str))
+		case TOKEN_COMMA:
+			// do nothing
+		case TOKEN_CLOSE_PAREN:
+			break LabelValues
+		default:
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+		}
+		p.nextToken()
+	}
+
+	return createTypeStmt, nil
+}
+
+//nolint:cyclop,funlen
+func (p *Parser) parseCreateDomainStmt() (*CreateDomainStmt, error) {
+	createDomainStmt := &CreateDomainStmt{}
+
+	p.nextToken() // current = domain_name
+	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkCurrentToken: %w", err)
+	}
+
+	createDomainStmt.Name = NewObjectName(p.currentToken.Literal.Str)
+	errFmtPrefix := fmt.Sprintf("domain_name=%s: ", createDomainStmt.Name.StringForDiff())
+
+	p.nextToken() // current = AS or data_type
+	if p.isCurrentToken(TOKEN_AS) {
+		p.nextToken() // current = data_type
+	}
+
+	if !isDataType(p.currentToken.Type) && !p.isCurrentToken(TOKEN_IDENT) {
+		return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+	}
+	dataType, err := p.parseDataType()
+	if err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"parseDataType: %w", err)
+	}
+	createDomainStmt.DataType = dataType
+
+	p.nextToken() // current = DEFAULT or CONSTRAINT or NOT or NULL or CHECK or ;
+
+	var unnamedChecks int
+LabelConstraints:
+	for {
+		var constraintName *Ident
+		if p.isCurrentToken(TOKEN_CONSTRAINT) {
+			p.nextToken() // current = constraint_name
+			if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"checkCurrentToken: %w", err)
+			}
+			constraintName = NewRawIdent(p.currentToken.Literal.Str)
+			p.nextToken() // current = NOT or NULL or CHECK
+		}
+
+		switch p.currentToken.Type { //nolint:exhaustive
+		case TOKEN_DEFAULT:
+			p.nextToken() // current = default_value
+			def, err := p.parseColumnDefault()
+			if err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"parseColumnDefault: %w", err)
+			}
+			createDomainStmt.Default = def
+			continue
+		case TOKEN_NOT:
+			if err := p.checkPeekToken(TOKEN_NULL); err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = NULL
+			createDomainStmt.NotNull = true
+		case TOKEN_NULL:
+			createDomainStmt.NotNull = false
+		case TOKEN_CHECK:
+			if err := p.checkPeekToken(TOKEN_OPEN_PAREN); err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = (
+			if constraintName == nil {
+				// MEMO: PostgreSQL names unnamed domain CHECK constraints domain_name_check, domain_name_check1, ...
+				name := createDomainStmt.Name.Name.StringForDiff() + "_check"
+				if unnamedChecks > 0 {
+					name += strconv.Itoa(unnamedChecks)
+				}
+				unnamedChecks++
+				constraintName = newDerivedIdent(name)
+			}
+			idents, err := p.parseExpr()
+			if err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"parseExpr: %w", err)
+			}
+			createDomainStmt.Constraints = createDomainStmt.Constraints.Append(&CheckConstraint{
+				Name: constraintName,
+				Expr: (*Expr)(nil).Append(idents...),
+			})
+			continue
+		case TOKEN_SEMICOLON, TOKEN_EOF:
+			break LabelConstraints
+		default:
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+		}
+
+		p.nextToken()
+	}
+
+	return createDomainStmt, nil
+}
+
+//nolint:cyclop
+func (p *Parser) parseCreateExtensionStmt() (*CreateExtensionStmt, error) {
+	createExtensionStmt := &CreateExtensionStmt{}
+
+	if p.isPeekToken(TOKEN_IF) {
+		p.nextToken() // current = IF
+		if err := p.checkPeekToken(TOKEN_NOT); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = NOT
+		if err := p.checkPeekToken(TOKEN_EXISTS); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = EXISTS
+		createExtensionStmt.IfNotExists = true
+	}
+
+	p.nextToken() // current = extension_name
+	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkCurrentToken: %w", err)
+	}
+
+	createExtensionStmt.Name = NewRawIdent(p.currentToken.Literal.Str)
+	errFmtPrefix := fmt.Sprintf("extension_name=%s: ", createExtensionStmt.Name.StringForDiff())
+
+	p.nextToken() // current = WITH or SCHEMA or VERSION or CASCADE or ;
+
+LabelOptions:
+	for {
+		switch p.currentToken.Type { //nolint:exhaustive
+		case TOKEN_WITH:
+			// do nothing
+		case TOKEN_SCHEMA:
+			if err := p.checkPeekToken(TOKEN_IDENT); err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = schema_name
+			createExtensionStmt.Schema = NewRawIdent(p.currentToken.Literal.Str)
+		case TOKEN_VERSION:
+			if err := p.checkPeekToken(TOKEN_IDENT); err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = version
+			createExtensionStmt.Version = NewRawIdent(p.currentToken.Literal.Str)
+		case TOKEN_CASCADE:
+			createExtensionStmt.Cascade = true
+		case TOKEN_SEMICOLON, TOKEN_EOF:
+			break LabelOptions
+		default:
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+		}
+
+		p.nextToken()
+	}
+
+	return createExtensionStmt, nil
+}
+
+func (p *Parser) parseCreateSequenceStmt() (*CreateSequenceStmt, error) {
+	createSequenceStmt := &CreateSequenceStmt{}
+
+	if p.isPeekToken(TOKEN_IF) {
+		p.nextToken() // current = IF
+		if err := p.checkPeekToken(TOKEN_NOT); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = NOT
+		if err := p.checkPeekToken(TOKEN_EXISTS); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = EXISTS
+		createSequenceStmt.IfNotExists = true
+	}
+
+	p.nextToken() // current = sequence_name
+	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkCurrentToken: %w", err)
+	}
+
+	createSequenceStmt.Name = NewObjectName(p.currentToken.Literal.Str)
+	errFmtPrefix := fmt.Sprintf("sequence_name=%s: ", createSequenceStmt.Name.StringForDiff())
+
+	p.nextToken() // current = AS or INCREMENT or ... or ;
+
+	options, err := p.parseSequenceOptions()
+	if err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"parseSequenceOptions: %w", err)
+	}
+	createSequenceStmt.Options = options
+
+	if err := p.checkCurrentToken(TOKEN_SEMICOLON, TOKEN_EOF); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkCurrentToken: %w", err)
+	}
+
+	return createSequenceStmt, nil
+}
+
+//nolint:cyclop,funlen
+func (p *Parser) parseCreateViewStmt() (*CreateViewStmt, error) {
+	createViewStmt := &CreateViewStmt{}
+
+	if p.isCurrentToken(TOKEN_OR) {
+		if err := p.checkPeekToken(TOKEN_REPLACE); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = REPLACE
+		createViewStmt.OrReplace = true
+		if !p.isPeekToken(TOKEN_VIEW) && !p.isPeekToken(TOKEN_MATERIALIZED) {
+			// MEMO: CREATE OR REPLACE FUNCTION, CREATE OR REPLACE TRIGGER and so on.
+			return nil, p.newParseError(p.peekToken, ddl.ErrUnsupportedStatement)
+		}
+		// MEMO: CREATE OR REPLACE MATERIALIZED VIEW is not supported by PostgreSQL.
+		if err := p.checkPeekToken(TOKEN_VIEW); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = VIEW
+	}
+
+	if p.isCurrentToken(TOKEN_MATERIALIZED) {
+		if err := p.checkPeekToken(TOKEN_VIEW); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = VIEW
+		createViewStmt.Materialized = true
+	}
+
+	if createViewStmt.Materialized && p.isPeekToken(TOKEN_IF) {
+		p.nextToken() // current = IF
+		if err := p.checkPeekToken(TOKEN_NOT); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = NOT
+		if err := p.checkPeekToken(TOKEN_EXISTS); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = EXISTS
+		createViewStmt.IfNotExists = true
+	}
+
+	p.nextToken() // current = view_name
+	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkCurrentToken: %w", err)
+	}
+
+	createViewStmt.Name = NewObjectName(p.currentToken.Literal.Str)
+	errFmtPrefix := fmt.Sprintf("view_name=%s: ", createViewStmt.Name.StringForDiff())
+
+	p.nextToken() // current = ( or AS
+
+	if p.isCurrentToken(TOKEN_OPEN_PAREN) {
+		for {
+			p.nextToken() // current = column_name
+			if !isIdent(p.currentToken.Type) {
+				return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+			}
+			createViewStmt.Columns = append(createViewStmt.Columns, NewRawIdent(p.currentToken.Literal.Str))
+			if err := p.checkPeekToken(TOKEN_COMMA, TOKEN_CLOSE_PAREN); err != nil {
+				return nil, apperr.Errorf(errFmtPrefix+"checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = , or )
+			if p.isCurrentToken(TOKEN_CLOSE_PAREN) {
+				break
+			}
+		}
+		p.nextToken() // current = AS
+	}
+
+	if err := p.checkCurrentToken(TOKEN_AS); err != nil {
+		return nil, apperr.Errorf(errFmtPrefix+"checkCurrentToken: %w", err)
+	}
+
+	p.nextToken() // current = SELECT or WITH or ...
+
+	tokens := make([]Token, 0)
+	depth := 0
+LabelQuery:
+	for {
+		switch p.currentToken.Type { //nolint:exhaustive
+		case TOKEN_OPEN_PAREN:
+			depth++
+		case TOKEN_CLOSE_PAREN:
+			depth--
+		case TOKEN_SEMICOLON, TOKEN_EOF:
+			if depth == 0 {
+				break LabelQuery
+			}
+			return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+		case TOKEN_ILLEGAL:
+			// MEMO: characters such as % are kept as they are, but an unclosed quotation cannot be.
+			if strings.HasPrefix(p.currentToken.Literal.Str, `"`) || strings.HasPrefix(p.currentToken.Literal.Str, `'`) {
+				return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+			}
+		}
+		tokens = append(tokens, p.currentToken)
+		p.nextToken()
+	}
+
+	// WITH [ NO ] DATA
+	if n := len(tokens); createViewStmt.Materialized && n >= 2 && strings.EqualFold(tokens[n-1].Literal.Str, "DATA") {
+		switch {
+		case n >= 3 && tokens[n-3].Type == TOKEN_WITH && tokens[n-2].Type == TOKEN_NO:
+			createViewStmt.WithNoData = true
+			tokens = tokens[:n-3]
+		case tokens[n-2].Type == TOKEN_WITH:
+			tokens = tokens[:n-2]
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
+	}
+
+	createViewStmt.Query = normalizeQuery(tokens)
+
+	return createViewStmt, nil
+}
+
+// parseSequenceOptions parses sequence options until a token that does not start an option.
+//
+//nolint:cyclop,funlen
+func (p *Parser) parseSequenceOptions() (SequenceOptions, error) {
+	options := make(SequenceOptions, 0)
+
+LabelOptions:
+	for {
+		switch p.currentToken.Type { //nolint:exhaustive
+		case TOKEN_AS:
+			p.nextToken() // current = data_type
+			if !isDataType(p.currentToken.Type) {
+				return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
+			}
+			dataType, err := p.parseDataType()
+			if err != nil {
+				return nil, apperr.Errorf("parseDataType: %w", err)
+			}
+			options = append(options, &SequenceOption{Name: SequenceOptionAs, Value: NewRawIdent(dataType.StringForDiff())})
+		case TOKEN_INCREMENT:
+			if p.isPeekToken(TOKEN_BY) {
+				p.nextToken() // current = BY
+			}
+			value, err := p.parseSequenceOptionValue()
+			if err != nil {
+				return nil, apperr.Errorf("parseSequenceOptionValue: %w", err)
+			}
+			options = append(options, &SequenceOption{Name: SequenceOptionIncrementBy, Value: value})
+		case TOKEN_MINVALUE, TOKEN_MAXVALUE, TOKEN_CACHE:
+			name := string(p.currentToken.Type)
+			value, err := p.parseSequenceOptionValue()
+			if err != nil {
+				return nil, apperr.Errorf("parseSequenceOptionValue: %w", err)
+			}
+			options = append(options, &SequenceOption{Name: name, Value: value})
+		case TOKEN_START:
+			if p.isPeekToken(TOKEN_WITH) {
+				p.nextToken() // current = WITH
+			}
+			value, err := p.parseSequenceOptionValue()
+			if err != nil {
+				return nil, apperr.Errorf("parseSequenceOptionValue: %w", err)
+			}
+			options = append(options, &SequenceOption{Name: SequenceOptionStartWith, Value: value})
+		case TOKEN_CYCLE:
+			options = append(options, &SequenceOption{Name: SequenceOptionCycle})
+		case TOKEN_NO:
+			if err := p.checkPeekToken(TOKEN_MINVALUE, TOKEN_MAXVALUE, TOKEN_CYCLE); err != nil {
+				return nil, apperr.Errorf("checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = MINVALUE or MAXVALUE or CYCLE
+			options = append(options, &SequenceOption{Name: sequenceOptionNoPrefix + string(p.currentToken.Type)})
+		case TOKEN_OWNED:
+			if err := p.checkPeekToken(TOKEN_BY); err != nil {
+				return nil, apperr.Errorf("checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = BY
+			if err := p.checkPeekToken(TOKEN_IDENT); err != nil {
+				return nil, apperr.Errorf("checkPeekToken: %w", err)
+			}
+			p.nextToken() // current = table_name.column_name or NONE
+			options = append(options, &SequenceOption{Name: SequenceOptionOwnedBy, Value: NewRawIdent(p.currentToken.Literal.Str)})
+		default:
+			break LabelOptions
+		}
+
+		p.nextToken()
+	}
+
+	return options, nil
+}
+
+// parseSequenceOptionValue parses the numeric value of a sequence option, including a negative one.
+func (p *Parser) parseSequenceOptionValue() (*Ident, error) {
+	var sign string
+	if p.isPeekToken(TOKEN_MINUS) {
+		p.nextToken() // current = -
+		sign = p.currentToken.Literal.Str
+	}
+	if err := p.checkPeekToken(TOKEN_IDENT); err != nil {
+		return nil, apperr.Errorf("checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = value
+	return NewRawIdent(sign + p.currentToken.Literal.Str), nil
+}
+
+// parseColumnIdentity parses GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [ ( sequence_options ) ].
+func (p *Parser) parseColumnIdentity() (*Identity, error) {
+	identity := &Identity{}
+
+	if err := p.checkPeekToken(TOKEN_ALWAYS, TOKEN_BY); err != nil {
+		return nil, apperr.Errorf("checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = ALWAYS or BY
+	if p.isCurrentToken(TOKEN_ALWAYS) {
+		identity.Always = true
+	} else {
+		if err := p.checkPeekToken(TOKEN_DEFAULT); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = DEFAULT
+	}
+	if err := p.checkPeekToken(TOKEN_AS); err != nil {
+		return nil, apperr.Errorf("checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = AS
+	if err := p.checkPeekToken(TOKEN_IDENTITY); err != nil {
+		return nil, apperr.Errorf("checkPeekToken: %w", err)
+	}
+	p.nextToken() // current = IDENTITY
+
+	if p.isPeekToken(TOKEN_OPEN_PAREN) {
+		p.nextToken() // current = (
+		p.nextToken() // current = AS or INCREMENT or ...
+		options, err := p.parseSequenceOptions()
+		if err != nil {
+			return nil, apperr.Errorf("parseSequenceOptions: %w", err)
+		}
+		if err := p.checkCurrentToken(TOKEN_CLOSE_PAREN); err != nil {
+			return nil, apperr.Errorf("checkCurrentToken: %w", err)
+		}
+		identity.Options = options
+	}
+
+	return identity, nil
+}
+
 //nolint:funlen,cyclop
 func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 	column := &Column{}
 	constraints := make(Constraints, 0)
 
-	if err := p.checkCurrentToken(TOKEN_IDENT); err != nil {
-		return nil, nil, apperr.Errorf("checkCurrentToken: %w", err)
+	if !isIdent(p.currentToken.Type) {
+		return nil, nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 	}
 
 	column.Name = NewRawIdent(p.currentToken.Literal.Str)
@@ -277,7 +948,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 	p.nextToken() // current = DATA_TYPE
 
 	switch { //nolint:exhaustive
-	case isDataType(p.currentToken.Type):
+	case isDataType(p.currentToken.Type), p.isCurrentToken(TOKEN_IDENT): // MEMO: TOKEN_IDENT is a user-defined type such as ENUM or DOMAIN
 		dataType, err := p.parseDataType()
 		if err != nil {
 			return nil, nil, apperr.Errorf(errFmtPrefix+"parseDataType: %w", err)
@@ -304,6 +975,12 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 				}
 				column.Default = def
 				continue
+			case TOKEN_GENERATED:
+				identity, err := p.parseColumnIdentity()
+				if err != nil {
+					return nil, nil, apperr.Errorf(errFmtPrefix+"parseColumnIdentity: %w", err)
+				}
+				column.Identity = identity
 			default:
 				break LabelDefaultNotNull
 			}
@@ -321,7 +998,7 @@ func (p *Parser) parseColumn(tableName *Ident) (*Column, []Constraint, error) {
 			}
 		}
 	default:
-		return nil, nil, apperr.Errorf(errFmtPrefix+"currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, nil, apperr.Errorf(errFmtPrefix+"%w", p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken))
 	}
 
 	return column, constraints, nil
@@ -334,7 +1011,7 @@ func (p *Parser) parseColumnDefault() (*Default, error) {
 LabelDefault:
 	for {
 		switch p.currentToken.Type { //nolint:exhaustive
-		case TOKEN_IDENT:
+		case TOKEN_IDENT, TOKEN_REPLACE:
 			def.Value = def.Value.Append(NewRawIdent(p.currentToken.Literal.String()))
 		case TOKEN_OPEN_PAREN:
 			ids, err := p.parseExpr()
@@ -343,7 +1020,7 @@ LabelDefault:
 			}
 			def.Value = def.Value.Append(ids...)
 			continue
-		case TOKEN_NOT, TOKEN_COMMA, TOKEN_CLOSE_PAREN:
+		case TOKEN_NOT, TOKEN_COMMA, TOKEN_CLOSE_PAREN, TOKEN_SEMICOLON, TOKEN_EOF:
 			break LabelDefault
 		default:
 			if isReservedValue(p.currentToken.Type) {
@@ -357,14 +1034,14 @@ LabelDefault:
 				continue
 			}
 			if isDataType(p.currentToken.Type) {
-				def.Value.Idents = append(def.Value.Idents, NewRawIdent(p.currentToken.Literal.Str))
+				def.Value = def.Value.Append(NewRawIdent(p.currentToken.Literal.Str))
 				p.nextToken()
 				continue
 			}
 			if isConstraint(p.currentToken.Type) {
 				break LabelDefault
 			}
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -406,7 +1083,7 @@ LabelExpr:
 			}
 			idents = append(idents, NewRawIdent(value))
 		case TOKEN_EOF:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		default:
 			if isReservedValue(p.currentToken.Type) {
 				idents = append(idents, NewRawIdent(p.currentToken.Type.String()))
@@ -434,7 +1111,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = KEY
 			constraints = constraints.Append(&PrimaryKeyConstraint{
-				Name:    NewRawIdent(tableName.StringForDiff() + "_pkey"),
+				Name:    newDerivedIdent(tableName.StringForDiff() + "_pkey"),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			})
 		case TOKEN_REFERENCES:
@@ -443,7 +1120,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = table_name
 			constraint := &ForeignKeyConstraint{
-				Name:    NewRawIdent(fmt.Sprintf("%s_%s_fkey", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name:    newDerivedIdent(fmt.Sprintf("%s_%s_fkey", tableName.StringForDiff(), column.Name.StringForDiff())),
 				Ref:     NewRawIdent(p.currentToken.Literal.Str),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			}
@@ -477,9 +1154,13 @@ LabelConstraints:
 			}
 			constraint.RefColumns = idents
 			constraints = constraints.Append(constraint)
+			// MEMO: without ON DELETE or ON UPDATE, the current token is already the one after the column constraint.
+			if constraint.OnAction == "" {
+				continue
+			}
 		case TOKEN_UNIQUE:
 			constraints = constraints.Append(&UniqueConstraint{ //diff:ignore-line-postgres-cockroach
-				Name:    NewRawIdent(fmt.Sprintf("%s_unique_%s", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name:    newDerivedIdent(fmt.Sprintf("%s_unique_%s", tableName.StringForDiff(), column.Name.StringForDiff())),
 				Columns: []*ColumnIdent{{Ident: column.Name}},
 			})
 		case TOKEN_CHECK:
@@ -488,7 +1169,7 @@ LabelConstraints:
 			}
 			p.nextToken() // current = (
 			constraint := &CheckConstraint{
-				Name: NewRawIdent(fmt.Sprintf("%s_%s_check", tableName.StringForDiff(), column.Name.StringForDiff())),
+				Name: newDerivedIdent(fmt.Sprintf("%s_%s_check", tableName.StringForDiff(), column.Name.StringForDiff())),
 			}
 			idents, err := p.parseExpr()
 			if err != nil {
@@ -499,7 +1180,7 @@ LabelConstraints:
 		case TOKEN_IDENT, TOKEN_COMMA, TOKEN_CLOSE_PAREN:
 			break LabelConstraints
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 
 		p.nextToken()
@@ -510,11 +1191,13 @@ LabelConstraints:
 
 //nolint:funlen,cyclop,gocognit
 func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //nolint:ireturn
+	comments := p.currentToken.Comments
+	pos := p.currentToken.Pos
 	var constraintName *Ident
 	if p.isCurrentToken(TOKEN_CONSTRAINT) {
 		p.nextToken() // current = constraint_name
 		if p.currentToken.Type != TOKEN_IDENT {
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		}
 		constraintName = NewRawIdent(p.currentToken.Literal.Str)
 		p.nextToken() // current = PRIMARY or CHECK or UNIQUE //diff:ignore-line-postgres-cockroach
@@ -535,11 +1218,13 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 			return nil, apperr.Errorf("parseColumnIdents: %w", err)
 		}
 		if constraintName == nil {
-			constraintName = NewRawIdent(tableName.StringForDiff() + "_pkey")
+			constraintName = newDerivedIdent(tableName.StringForDiff() + "_pkey")
 		}
 		return &PrimaryKeyConstraint{
-			Name:    constraintName,
-			Columns: idents,
+			Comments: comments,
+			Pos:      pos,
+			Name:     constraintName,
+			Columns:  idents,
 		}, nil
 	case TOKEN_FOREIGN:
 		if err := p.checkPeekToken(TOKEN_KEY); err != nil {
@@ -586,6 +1271,7 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 				p.nextToken()                                     // current = ACTION
 				onAction += " " + p.currentToken.Literal.String() // current = ACTION
 			}
+			p.nextToken() // current = , or )
 		}
 		if constraintName == nil {
 			name := tableName.StringForDiff()
@@ -593,9 +1279,11 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 				name += "_" + ident.StringForDiff()
 			}
 			name += "_fkey"
-			constraintName = NewRawIdent(name)
+			constraintName = newDerivedIdent(name)
 		}
 		return &ForeignKeyConstraint{
+			Comments:   comments,
+			Pos:        pos,
 			Name:       constraintName,
 			Columns:    idents,
 			Ref:        refName,
@@ -618,13 +1306,35 @@ func (p *Parser) parseTableConstraint(tableName *Ident) (Constraint, error) { //
 			for _, ident := range idents {                //diff:ignore-line-postgres-cockroach
 				name += "_" + ident.StringForDiff() //diff:ignore-line-postgres-cockroach
 			} //diff:ignore-line-postgres-cockroach
-			constraintName = NewRawIdent(name) //diff:ignore-line-postgres-cockroach
+			constraintName = newDerivedIdent(name) //diff:ignore-line-postgres-cockroach
 		} //diff:ignore-line-postgres-cockroach
+		c.Comments = comments
+		c.Pos = pos
 		c.Name = constraintName
 		c.Columns = idents
 		return c, nil
+	case TOKEN_CHECK:
+		if err := p.checkPeekToken(TOKEN_OPEN_PAREN); err != nil {
+			return nil, apperr.Errorf("checkPeekToken: %w", err)
+		}
+		p.nextToken() // current = (
+		idents, err := p.parseExpr()
+		if err != nil {
+			return nil, apperr.Errorf("parseExpr: %w", err)
+		}
+		if constraintName == nil {
+			// MEMO: the database names an unnamed table CHECK constraint after the columns in the expression,
+			// but the columns cannot be distinguished from the other identifiers here.
+			constraintName = newDerivedIdent(tableName.StringForDiff() + "_check")
+		}
+		return &CheckConstraint{
+			Comments: comments,
+			Pos:      pos,
+			Name:     constraintName,
+			Expr:     (*Expr)(nil).Append(idents...),
+		}, nil
 	default:
-		return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+		return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 	}
 }
 
@@ -708,7 +1418,10 @@ LabelIdents:
 			p.nextToken()
 			break LabelIdents
 		default:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			if !isIdent(p.currentToken.Type) {
+				return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
+			}
+			idents = append(idents, &ColumnIdent{Ident: NewRawIdent(p.currentToken.Literal.Str)})
 		}
 		p.nextToken()
 	}
@@ -729,7 +1442,7 @@ LabelIdents:
 		case TOKEN_CLOSE_PAREN:
 			break LabelIdents
 		case TOKEN_EOF, TOKEN_ILLEGAL:
-			return nil, apperr.Errorf("currentToken=%#v, peekToken=%#v: %w", p.currentToken, p.peekToken, ddl.ErrUnexpectedCurrentToken)
+			return nil, p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken)
 		default:
 			idents = append(idents, NewRawIdent(p.currentToken.Literal.Str))
 		}
@@ -777,6 +1490,77 @@ func isDataType(tokenType TokenType) bool {
 	}
 }
 
+// isIdent reports whether tokenType can be used as an identifier such as a column name.
+// Non-reserved keywords such as TYPE and VERSION are often used as column names.
+func isIdent(tokenType TokenType) bool {
+	switch tokenType { //nolint:exhaustive
+	case TOKEN_IDENT,
+		TOKEN_TYPE, TOKEN_DOMAIN, TOKEN_EXTENSION, TOKEN_SEQUENCE,
+		TOKEN_ENUM, TOKEN_SCHEMA, TOKEN_VERSION,
+		TOKEN_INCREMENT, TOKEN_BY, TOKEN_MINVALUE, TOKEN_MAXVALUE, TOKEN_START, TOKEN_CACHE, TOKEN_CYCLE, TOKEN_OWNED,
+		TOKEN_GENERATED, TOKEN_ALWAYS, TOKEN_IDENTITY,
+		TOKEN_REPLACE, TOKEN_MATERIALIZED, TOKEN_REFRESH:
+		return true
+	default:
+		return false
+	}
+}
+
+//nolint:gochecknoglobals
+var queryKeywords = map[string]bool{
+	"SELECT": true, "DISTINCT": true, "ALL": true, "FROM": true, "WHERE": true,
+	"AND": true, "OR": true, "NOT": true, "AS": true, "ON": true, "USING": true,
+	"JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "OUTER": true, "CROSS": true, "NATURAL": true, "LATERAL": true,
+	"GROUP": true, "BY": true, "HAVING": true, "ORDER": true, "ASC": true, "DESC": true, "NULLS": true, "LIMIT": true, "OFFSET": true,
+	"UNION": true, "INTERSECT": true, "EXCEPT": true, "WITH": true, "RECURSIVE": true, "VALUES": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true, "CAST": true,
+	"IN": true, "IS": true, "NULL": true, "TRUE": true, "FALSE": true, "LIKE": true, "ILIKE": true, "BETWEEN": true, "EXISTS": true, "ANY": true,
+	"OVER": true, "PARTITION": true, "FILTER": true,
+}
+
+// normalizeQuery returns the query that tokens represent, with whitespace collapsed,
+// keywords in upper case and unquoted identifiers in lower case, as PostgreSQL folds them.
+func normalizeQuery(tokens []Token) string {
+	var str, prev string
+	for i, token := range tokens {
+		literal := token.Literal.Str
+		if !strings.HasPrefix(literal, `"`) && !strings.HasPrefix(literal, `'`) && !strings.HasPrefix(literal, "$") {
+			if upper := strings.ToUpper(literal); queryKeywords[upper] {
+				literal = upper
+			} else {
+				literal = strings.ToLower(literal)
+			}
+		}
+		if i > 0 && needsSpaceInQuery(prev, literal) {
+			str += " "
+		}
+		str += literal
+		prev = literal
+	}
+	return str
+}
+
+func needsSpaceInQuery(prev, current string) bool {
+	switch {
+	case prev == "(", current == ")", current == ",":
+		return false
+	case prev == "::", current == "::":
+		return false
+	case strings.HasSuffix(prev, ".") && strings.HasPrefix(current, `"`), strings.HasSuffix(prev, `"`) && strings.HasPrefix(current, "."):
+		// table_name."column_name", "table_name".column_name
+		// MEMO: other tokens are not joined, because the joined token would be lexed as another identifier.
+		return false
+	case (prev == "<" || prev == ">" || prev == "!") && (current == "=" || current == ">"):
+		// <=, >=, !=, <>
+		return false
+	case current == "(" && prev != "" && (isLiteral(prev[0]) || prev[0] == '"') && !queryKeywords[prev]:
+		// function_name(
+		return false
+	default:
+		return true
+	}
+}
+
 func isConstraint(tokenType TokenType) bool {
 	switch tokenType { //nolint:exhaustive
 	case TOKEN_CONSTRAINT,
@@ -805,7 +1589,7 @@ func (p *Parser) checkCurrentToken(expectedTypes ...TokenType) error {
 			return nil
 		}
 	}
-	return apperr.Errorf("currentToken=%#v, peekToken=%#v: expected=%v, but got=%v: %w", p.currentToken, p.peekToken, stringz.JoinStringers(",", expectedTypes...), p.currentToken.Type, ddl.ErrUnexpectedCurrentToken)
+	return p.newParseError(p.currentToken, ddl.ErrUnexpectedCurrentToken, expectedTypes...)
 }
 
 func (p *Parser) isPeekToken(expectedTypes ...TokenType) bool {
@@ -823,5 +1607,20 @@ func (p *Parser) checkPeekToken(expectedTypes ...TokenType) error {
 			return nil
 		}
 	}
-	return apperr.Errorf("currentToken=%#v, peekToken=%#v: expected=%v, but got=%v: %w", p.currentToken, p.peekToken, stringz.JoinStringers(",", expectedTypes...), p.peekToken.Type, ddl.ErrUnexpectedPeekToken)
+	return p.newParseError(p.peekToken, ddl.ErrUnexpectedPeekToken, expectedTypes...)
+}
+
+// newParseError returns the error for the unexpected token, with its position and the expected token types.
+func (p *Parser) newParseError(token Token, err error, expectedTypes ...TokenType) *ddl.ParseError {
+	expected := make([]string, 0, len(expectedTypes))
+	for _, expectedType := range expectedTypes {
+		expected = append(expected, expectedType.String())
+	}
+	return &ddl.ParseError{
+		Position: token.Pos,
+		Token:    token.Literal.Str,
+		Expected: expected,
+		Source:   p.l.input,
+		Err:      err,
+	}
 }
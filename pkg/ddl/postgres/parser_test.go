@@ -2,6 +2,7 @@
 package postgres
 
 import (
+	"errors"
 	"testing"
 
 	assert "github.com/hakadoriya/z.go/testingz/assertz"
@@ -55,6 +56,48 @@ CREATE TABLE public.users (
 		t.Logf("ℹ️: %s: stmt: %%s: \n%s", t.Name(), actual)
 	})
 
+	t.Run("success,REFERENCES_before_table_constraint", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE users (id INT NOT NULL, group_id INT REFERENCES groups (id), PRIMARY KEY (id));`
+		expected := `CREATE TABLE users (
+    id INT NOT NULL,
+    group_id INT,
+    CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id),
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,Pos", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE users (
+    id INT NOT NULL,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX users_idx_id ON users (id);`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		table := actual.Stmts[0].(*CreateTableStmt) //nolint:forcetypeassert
+		assert.Equal(t, "1:1", table.Pos.String())
+		assert.Equal(t, "2:5", table.Columns[0].Pos.String())
+		constraint := table.Constraints[0].(*PrimaryKeyConstraint) //nolint:forcetypeassert
+		assert.Equal(t, "3:5", constraint.Pos.String())
+		index := actual.Stmts[1].(*CreateIndexStmt) //nolint:forcetypeassert
+		assert.Equal(t, "6:1", index.Pos.String())
+	})
+
 	t.Run("success,complex_defaults", func(t *testing.T) {
 		t.Parallel()
 
@@ -71,7 +114,9 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
     calculated_value INTEGER DEFAULT (SELECT COUNT(*) FROM another_table)
 );
 `
-		expected := `CREATE TABLE IF NOT EXISTS complex_defaults (
+		expected := `-- table: complex_defaults
+CREATE TABLE IF NOT EXISTS complex_defaults (
+    -- id is the primary key.
     id SERIAL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
@@ -97,6 +142,100 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 		t.Logf("ℹ️: %s: stmt: %%s: \n%s", t.Name(), actual)
 	})
 
+	t.Run("success,CREATE_INDEX_CONCURRENTLY", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS users_idx_username ON public.users USING btree (username);`
+		expected := `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS users_idx_username ON public.users USING btree (username);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,CREATE_TYPE_DOMAIN_EXTENSION", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public VERSION '1.1' CASCADE;
+CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy');
+CREATE DOMAIN positive_int AS INTEGER DEFAULT 1 NOT NULL CHECK (VALUE > 0) CHECK (VALUE < 100);
+CREATE DOMAIN email TEXT CONSTRAINT email_check CHECK (VALUE LIKE '%@%');
+CREATE TABLE public.users (id UUID NOT NULL, type TEXT, mood public.mood NOT NULL, version positive_int, PRIMARY KEY (id));
+CREATE INDEX users_idx_type ON public.users (type, version);`
+		expected := `CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public VERSION '1.1' CASCADE;
+CREATE TYPE public.mood AS ENUM ('sad', 'ok', 'happy');
+CREATE DOMAIN positive_int AS INTEGER DEFAULT 1 NOT NULL CONSTRAINT positive_int_check CHECK (VALUE > 0) CONSTRAINT positive_int_check1 CHECK (VALUE < 100);
+CREATE DOMAIN email AS TEXT CONSTRAINT email_check CHECK (VALUE LIKE '%@%');
+CREATE TABLE public.users (
+    id UUID NOT NULL,
+    type TEXT,
+    mood public.mood NOT NULL,
+    version positive_int,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+CREATE INDEX users_idx_type ON public.users (type, version);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,CREATE_SEQUENCE_IDENTITY", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE SEQUENCE IF NOT EXISTS public.users_seq AS bigint INCREMENT BY 2 MINVALUE -10 NO MAXVALUE START WITH 5 CACHE 1 NO CYCLE OWNED BY public.users.seq;
+CREATE SEQUENCE counter INCREMENT 1 START 1 CYCLE;
+CREATE TABLE public.users (id BIGINT GENERATED ALWAYS AS IDENTITY (START WITH 10 INCREMENT BY 1), seq BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY, start INTEGER, cache INTEGER, PRIMARY KEY (id));`
+		expected := `CREATE SEQUENCE IF NOT EXISTS public.users_seq AS BIGINT INCREMENT BY 2 MINVALUE -10 NO MAXVALUE START WITH 5 CACHE 1 NO CYCLE OWNED BY public.users.seq;
+CREATE SEQUENCE counter INCREMENT BY 1 START WITH 1 CYCLE;
+CREATE TABLE public.users (
+    id BIGINT GENERATED ALWAYS AS IDENTITY (START WITH 10 INCREMENT BY 1),
+    seq BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    start INTEGER,
+    cache INTEGER,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,CREATE_VIEW", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE OR REPLACE VIEW public.active_users (user_id, name) AS
+  select u.id, u.name, count(*) AS cnt
+  FROM public.users u LEFT JOIN groups g ON g.id = u.group_id
+  WHERE u.deleted_at IS NULL AND u.age >= 20 AND u.name <> 'Admin' AND u.type IN ('a', 'b') -- comment
+  GROUP BY u.id;
+CREATE MATERIALIZED VIEW IF NOT EXISTS user_counts AS SELECT count(*) FROM public.active_users WITH NO DATA;
+CREATE MATERIALIZED VIEW "Users" AS SELECT "Name"::TEXT FROM users WITH DATA;`
+		expected := `CREATE OR REPLACE VIEW public.active_users (user_id, name) AS SELECT u.id, u.name, count(*) AS cnt FROM public.users u LEFT JOIN groups g ON g.id = u.group_id WHERE u.deleted_at IS NULL AND u.age >= 20 AND u.name <> 'Admin' AND u.type IN ('a', 'b') GROUP BY u.id;
+CREATE MATERIALIZED VIEW IF NOT EXISTS user_counts AS SELECT count(*) FROM public.active_users WITH NO DATA;
+CREATE MATERIALIZED VIEW "Users" AS SELECT "Name"::text FROM users;
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
 	failureTests := []struct {
 		name    string
 		input   string
@@ -322,6 +461,11 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 			input:   `CREATE INDEX NOT`,
 			wantErr: ddl.ErrUnexpectedCurrentToken,
 		},
+		{
+			name:    "failure,CREATE_INDEX_CONCURRENTLY_INVALID",
+			input:   `CREATE INDEX CONCURRENTLY NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
 		{
 			name:    "failure,CREATE_INDEX_IF_INVALID",
 			input:   `CREATE INDEX IF;`,
@@ -367,6 +511,231 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 			input:   `CREATE INDEX users_idx_username ON users USING btree (NOT)`,
 			wantErr: ddl.ErrUnexpectedCurrentToken,
 		},
+		{
+			name:    "failure,CREATE_TYPE_INVALID",
+			input:   `CREATE TYPE NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_INVALID",
+			input:   `CREATE TYPE mood NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_AS_INVALID",
+			input:   `CREATE TYPE mood AS NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_AS_ENUM_INVALID",
+			input:   `CREATE TYPE mood AS ENUM NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TYPE_type_name_AS_ENUM_OPEN_PAREN_INVALID",
+			input:   `CREATE TYPE mood AS ENUM ('sad', NOT)`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_INVALID",
+			input:   `CREATE DOMAIN NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_INVALID",
+			input:   `CREATE DOMAIN positive_int AS NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_data_type_INVALID",
+			input:   `CREATE DOMAIN positive_int AS INTEGER (`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_data_type_DEFAULT_INVALID",
+			input:   `CREATE DOMAIN positive_int AS INTEGER DEFAULT ,`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_data_type_NOT_INVALID",
+			input:   `CREATE DOMAIN positive_int AS INTEGER NOT DEFAULT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_data_type_CONSTRAINT_INVALID",
+			input:   `CREATE DOMAIN positive_int AS INTEGER CONSTRAINT NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_data_type_CHECK_INVALID",
+			input:   `CREATE DOMAIN positive_int AS INTEGER CHECK NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_DOMAIN_domain_name_AS_data_type_CHECK_OPEN_PAREN_INVALID",
+			input:   `CREATE DOMAIN positive_int AS INTEGER CHECK (VALUE > 0`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_EXTENSION_IF_INVALID",
+			input:   `CREATE EXTENSION IF NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_EXTENSION_IF_NOT_INVALID",
+			input:   `CREATE EXTENSION IF NOT NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_EXTENSION_INVALID",
+			input:   `CREATE EXTENSION NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_EXTENSION_extension_name_SCHEMA_INVALID",
+			input:   `CREATE EXTENSION pgcrypto WITH SCHEMA NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_EXTENSION_extension_name_VERSION_INVALID",
+			input:   `CREATE EXTENSION pgcrypto VERSION NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_EXTENSION_extension_name_INVALID",
+			input:   `CREATE EXTENSION pgcrypto NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_INVALID",
+			input:   `CREATE SEQUENCE NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_IF_NOT_INVALID",
+			input:   `CREATE SEQUENCE IF NOT NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_AS_INVALID",
+			input:   `CREATE SEQUENCE s AS NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_INCREMENT_BY_INVALID",
+			input:   `CREATE SEQUENCE s INCREMENT BY NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_START_WITH_INVALID",
+			input:   `CREATE SEQUENCE s START WITH NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_CACHE_INVALID",
+			input:   `CREATE SEQUENCE s CACHE NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_NO_INVALID",
+			input:   `CREATE SEQUENCE s NO NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_OWNED_INVALID",
+			input:   `CREATE SEQUENCE s OWNED NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_OWNED_BY_INVALID",
+			input:   `CREATE SEQUENCE s OWNED BY NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_SEQUENCE_sequence_name_INVALID",
+			input:   `CREATE SEQUENCE s NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_TABLE_table_name_column_name_GENERATED_INVALID",
+			input:   `CREATE TABLE "users" ("id" BIGINT GENERATED NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TABLE_table_name_column_name_GENERATED_BY_INVALID",
+			input:   `CREATE TABLE "users" ("id" BIGINT GENERATED BY NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TABLE_table_name_column_name_GENERATED_ALWAYS_INVALID",
+			input:   `CREATE TABLE "users" ("id" BIGINT GENERATED ALWAYS NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TABLE_table_name_column_name_GENERATED_ALWAYS_AS_INVALID",
+			input:   `CREATE TABLE "users" ("id" BIGINT GENERATED ALWAYS AS NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_TABLE_table_name_column_name_GENERATED_ALWAYS_AS_IDENTITY_OPEN_PAREN_INVALID",
+			input:   `CREATE TABLE "users" ("id" BIGINT GENERATED ALWAYS AS IDENTITY (START WITH 1 NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_TABLE_table_name_column_name_GENERATED_ALWAYS_AS_IDENTITY_OPTION_INVALID",
+			input:   `CREATE TABLE "users" ("id" BIGINT GENERATED ALWAYS AS IDENTITY (CACHE NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_OR_INVALID",
+			input:   `CREATE OR NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_OR_REPLACE_INVALID",
+			input:   `CREATE OR REPLACE MATERIALIZED VIEW v AS SELECT 1;`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_MATERIALIZED_INVALID",
+			input:   `CREATE MATERIALIZED NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_MATERIALIZED_VIEW_IF_NOT_INVALID",
+			input:   `CREATE MATERIALIZED VIEW IF NOT NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_VIEW_INVALID",
+			input:   `CREATE VIEW NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_VIEW_view_name_OPEN_PAREN_INVALID",
+			input:   `CREATE VIEW v (NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_VIEW_view_name_OPEN_PAREN_column_name_INVALID",
+			input:   `CREATE VIEW v (id NOT`,
+			wantErr: ddl.ErrUnexpectedPeekToken,
+		},
+		{
+			name:    "failure,CREATE_VIEW_view_name_INVALID",
+			input:   `CREATE VIEW v NOT`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_VIEW_view_name_AS_INVALID",
+			input:   `CREATE VIEW v AS;`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
+		{
+			name:    "failure,CREATE_VIEW_view_name_AS_OPEN_PAREN_INVALID",
+			input:   `CREATE VIEW v AS SELECT (1`,
+			wantErr: ddl.ErrUnexpectedCurrentToken,
+		},
 	}
 
 	for _, tt := range failureTests {
@@ -377,6 +746,87 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 			require.ErrorIs(t, err, tt.wantErr)
 		})
 	}
+
+	t.Run("failure,ParseErrors", func(t *testing.T) {
+		t.Parallel()
+
+		input := "CREATE TABLE a (id INT NOT NOT);\nCREATE TABLE b (id UUID);\nCREATE TABLE c (id INT NOT NOT);\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnexpectedPeekToken)
+		parseErrs := ddl.AsParseErrors(err)
+		if !assert.Equal(t, 2, len(parseErrs)) {
+			t.FailNow()
+		}
+		assert.Equal(t, 1, parseErrs[0].Position.Line)
+		assert.Equal(t, 3, parseErrs[1].Position.Line)
+	})
+
+	t.Run("success,lenient", func(t *testing.T) {
+		t.Parallel()
+
+		input := "-- read only\nGRANT SELECT ON users TO readonly;\nCREATE TABLE users (id UUID NOT NULL);\nCREATE OR REPLACE FUNCTION f() RETURNS INT AS 'SELECT 1' LANGUAGE SQL;\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnsupportedStatement)
+
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Comment: "read only", Raw: "GRANT SELECT ON users TO readonly"}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: "CREATE OR REPLACE FUNCTION f() RETURNS INT AS 'SELECT 1' LANGUAGE SQL"}, d.Stmts[2])
+		if !assert.Equal(t, 2, len(p.Warnings())) {
+			t.FailNow()
+		}
+		assert.Equal(t, "GRANT", p.Warnings()[0].Token)
+		assert.Equal(t, "FUNCTION", p.Warnings()[1].Token)
+		require.ErrorIs(t, p.Warnings()[1], ddl.ErrUnsupportedStatement)
+	})
+
+	t.Run("success,lenient,function_body", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at := now(); -- not a statement;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TABLE users (id UUID NOT NULL);
+CREATE FUNCTION f() RETURNS TEXT AS $body$ SELECT E'it\'s; ok' $body$ LANGUAGE SQL;
+`
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at := now(); -- not a statement;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql`}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION f() RETURNS TEXT AS $body$ SELECT E'it\'s; ok' $body$ LANGUAGE SQL`}, d.Stmts[2])
+		assert.Equal(t, 2, len(p.Warnings()))
+	})
+
+	t.Run("failure,ParseError", func(t *testing.T) {
+		t.Parallel()
+
+		input := "CREATE TABLE users (\n\tid UUID NOT NULL,\n\tname TEXT NOT NOT\n);"
+		_, err := NewParser(NewLexer(input)).Parse()
+		var parseErr *ddl.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("❌: err=%v: expected *ddl.ParseError", err)
+		}
+		parseErr.Filename = "schema.sql"
+		require.ErrorIs(t, err, ddl.ErrUnexpectedPeekToken)
+		assert.Equal(t, ddl.Position{Offset: 55, Line: 3, Column: 16}, parseErr.Position)
+		assert.Equal(t, "schema.sql:3:16: unexpected peek token \"NOT\", expected NULL", parseErr.Error())
+		assert.Equal(t, "3 | \tname TEXT NOT NOT\n  | \t              ^\n", parseErr.Excerpt())
+	})
 }
 
 func TestParser_parseColumn(t *testing.T) {
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+func TestSplitPhases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,expand_migrate_contract", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, age INTEGER, PRIMARY KEY (id));
+CREATE INDEX users_idx_age ON users (age);
+CREATE TABLE old_logs (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name VARCHAR(100) NOT NULL, email TEXT NOT NULL, nickname TEXT, PRIMARY KEY (id));
+CREATE UNIQUE INDEX users_idx_email ON users (email);
+CREATE INDEX users_idx_nickname ON users (nickname);
+CREATE TABLE logs (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		phased := SplitPhases(result)
+		assert.Equal(t, `CREATE TABLE logs (
+    id INTEGER
+);
+-- -
+-- +email TEXT NOT NULL
+ALTER TABLE users ADD COLUMN email TEXT;
+-- -
+-- +nickname TEXT
+ALTER TABLE users ADD COLUMN nickname TEXT;
+CREATE INDEX users_idx_nickname ON users (nickname);
+`, phased.Phase(ddl.PhaseExpand).String())
+		assert.Equal(t, `-- TODO: backfill email before the contract phase, which sets NOT NULL
+UPDATE users SET email = <value> WHERE email IS NULL;
+-- TODO: backfill nickname from the dropped column of users if it replaces one
+UPDATE users SET nickname = <value> WHERE nickname IS NULL;
+`, phased.Phase(ddl.PhaseMigrate).String())
+		assert.Equal(t, `DROP INDEX users_idx_age;
+DROP TABLE old_logs;
+CREATE UNIQUE INDEX users_idx_email ON users (email);
+-- -name TEXT NOT NULL
+-- +name VARCHAR(100) NOT NULL
+ALTER TABLE users ALTER COLUMN name SET DATA TYPE VARCHAR(100);
+-- -age INTEGER
+-- +
+ALTER TABLE users DROP COLUMN age;
+ALTER TABLE users ALTER COLUMN email SET NOT NULL;
+`, phased.Phase(ddl.PhaseContract).String())
+	})
+
+	t.Run("success,recreate", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, age INTEGER, PRIMARY KEY (id));
+CREATE INDEX users_idx_name ON users (name);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, age INTEGER, PRIMARY KEY (id));
+CREATE INDEX users_idx_name ON users (id, name);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		phased := SplitPhases(result)
+		assert.Equal(t, 0, len(phased.Expand.Stmts))
+		assert.Equal(t, 0, len(phased.Migrate.Stmts))
+		assert.Equal(t, result.String(), phased.Contract.String())
+	})
+
+	t.Run("success,nil", func(t *testing.T) {
+		t.Parallel()
+
+		phased := SplitPhases(nil)
+		assert.Equal(t, "", phased.Expand.String()+phased.Migrate.String()+phased.Contract.String())
+		assert.Equal(t, (*DDL)(nil), phased.Phase(ddl.Phase("unknown")))
+	})
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+)
+
+// targetSequences returns the names of the sequences that the columns of the matched statements use as DEFAULT nextval('sequence_name'),
+// and that the matched tables in before used, to drop them together with the tables or the defaults.
+func targetSequences(before, result *DDL, matched []bool, patterns []string) (map[string]bool, error) {
+	sequences := make(map[string]bool)
+	// MEMO: SERIAL is normalized to DEFAULT nextval('table_name_column_name_seq'::regclass).
+	addColumns := func(tableName *ObjectName, columns ...*Column) {
+		for _, c := range columns {
+			if name := nextvalSequenceName(normalizeColumn(tableName, c).Default); name != "" {
+				sequences[targetKey(name)] = true
+			}
+		}
+	}
+
+	for i, stmt := range result.Stmts {
+		if !matched[i] {
+			continue
+		}
+		switch s := stmt.(type) {
+		case *CreateTableStmt:
+			addColumns(s.Name, s.Columns...)
+		case *AlterTableStmt:
+			switch a := s.Action.(type) {
+			case *AddColumn:
+				addColumns(s.Name, a.Column)
+			case *AlterColumnSetDefault:
+				addColumns(s.Name, &Column{Name: a.Name, Default: a.Default})
+			}
+		}
+	}
+
+	if before != nil {
+		for _, stmt := range before.Stmts {
+			s, ok := stmt.(*CreateTableStmt)
+			if !ok {
+				continue
+			}
+			m, err := matchTargets(patterns, []string{s.GetNameForDiff()})
+			if err != nil {
+				return nil, apperr.Errorf("matchTargets: %w", err)
+			}
+			if m {
+				addColumns(s.Name, s.Columns...)
+			}
+		}
+	}
+
+	return sequences, nil
+}
+
+// sequenceOwnerTable returns the table of OWNED BY table_name.column_name in options, or "" if absent or OWNED BY NONE.
+func sequenceOwnerTable(options SequenceOptions) string {
+	opt := options.Find(SequenceOptionOwnedBy)
+	if opt == nil || opt.Value == nil {
+		return ""
+	}
+	ownedBy := opt.Value.StringForDiff()
+	i := strings.LastIndex(ownedBy, ".")
+	if i < 0 {
+		return ""
+	}
+	return ownedBy[:i]
+}
+
+// viewDependencies returns the names that the query of view refers to.
+// A column that has the same name as a skipped relation only causes an unnecessary warning, as well as viewReferences.
+func viewDependencies(view *CreateViewStmt) []string {
+	deps := make([]string, 0)
+	for _, token := range queryTokens(view.Query) {
+		if token.Type == TOKEN_IDENT && !strings.HasPrefix(token.Literal.Str, `'`) {
+			deps = append(deps, NewRawIdent(token.Literal.Str).StringForDiff())
+		}
+	}
+	return deps
+}
+
+// skippedViewWarnings returns the warnings for the skipped views on the table that stmt alters,
+// because a view is recreated when the table changes incompatibly for it, and skipping it may fail the change.
+func skippedViewWarnings(stmt Stmt, skippedViews []*CreateViewStmt) []string {
+	s, ok := stmt.(*AlterTableStmt)
+	if !ok {
+		return nil
+	}
+	warnings := make([]string, 0)
+	for _, view := range skippedViews {
+		if viewReferences(view, s.Name) {
+			warnings = append(warnings, strconv.Quote(firstLine(view.String()))+" is skipped, but it depends on "+strconv.Quote(firstLine(stmt.String())))
+		}
+	}
+	return warnings
+}
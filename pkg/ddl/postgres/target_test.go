@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestFilterTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,dependency", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, PRIMARY KEY (id));
+CREATE INDEX users_idx_name ON users (name);
+CREATE TABLE logs (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE teams (id INTEGER NOT NULL, PRIMARY KEY (id));
+CREATE TABLE users (id INTEGER NOT NULL, name TEXT NOT NULL, team_id INTEGER, PRIMARY KEY (id), CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id));
+CREATE TABLE logs (id INTEGER, message TEXT);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"USERS"})
+		require.NoError(t, err)
+		assert.Equal(t, `DROP INDEX users_idx_name;
+-- -
+-- +team_id INTEGER
+ALTER TABLE users ADD COLUMN team_id INTEGER;
+-- -
+-- +CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id)
+ALTER TABLE users ADD CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id);
+`, filtered.String())
+		assert.Equal(t, []string{`"CREATE TABLE teams (" is skipped, but "ALTER TABLE users ADD CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id);" depends on it`}, warnings)
+	})
+
+	t.Run("success,glob", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER);
+CREATE TABLE order_items (id INTEGER);
+CREATE INDEX order_items_idx_id ON order_items (id);
+CREATE TABLE orders (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"order_*"})
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE order_items (
+    id INTEGER
+);
+CREATE INDEX order_items_idx_id ON order_items (id);
+`, filtered.String())
+		assert.Equal(t, 0, len(warnings))
+	})
+
+	t.Run("success,sequence", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, PRIMARY KEY (id));
+CREATE TABLE logs (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE SEQUENCE users_code_seq START WITH 100;
+CREATE SEQUENCE logs_id_seq OWNED BY logs.id;
+CREATE SEQUENCE users_id_seq OWNED BY users.id;
+CREATE TABLE users (id INTEGER NOT NULL, code INTEGER DEFAULT nextval('users_code_seq'), PRIMARY KEY (id));
+CREATE TABLE logs (id INTEGER DEFAULT nextval('logs_id_seq'));
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"users"})
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE SEQUENCE users_code_seq START WITH 100;
+-- -
+-- +code INTEGER DEFAULT nextval('users_code_seq')
+ALTER TABLE users ADD COLUMN code INTEGER DEFAULT nextval('users_code_seq');
+ALTER SEQUENCE users_id_seq OWNED BY users.id;
+`, filtered.String())
+		assert.Equal(t, 0, len(warnings))
+	})
+
+	t.Run("success,SERIAL_IDENTITY", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id SERIAL NOT NULL, PRIMARY KEY (id));
+CREATE TABLE logs (id SERIAL);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER GENERATED BY DEFAULT AS IDENTITY NOT NULL, PRIMARY KEY (id));
+CREATE TABLE logs (id INTEGER GENERATED BY DEFAULT AS IDENTITY);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		// MEMO: setval and DROP SEQUENCE of the SERIAL sequence of users are included, but not those of logs.
+		filtered, _, err := FilterTargets(before, result, []string{"users"})
+		require.NoError(t, err)
+		assert.Equal(t, `-- -id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL
+-- +id INTEGER NOT NULL GENERATED BY DEFAULT AS IDENTITY
+ALTER TABLE users ALTER COLUMN id DROP DEFAULT;
+-- -id INTEGER DEFAULT nextval('users_id_seq'::regclass) NOT NULL
+-- +id INTEGER NOT NULL GENERATED BY DEFAULT AS IDENTITY
+ALTER TABLE users ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY;
+-- continue the identity of id from the current value of users_id_seq
+SELECT setval(pg_get_serial_sequence('users', 'id'), last_value, is_called) FROM users_id_seq;
+DROP SEQUENCE IF EXISTS users_id_seq;
+`, filtered.String())
+	})
+
+	t.Run("success,view", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name TEXT, PRIMARY KEY (id));
+CREATE VIEW user_names AS SELECT id, name FROM users;
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER NOT NULL, name VARCHAR(255), PRIMARY KEY (id));
+CREATE TABLE teams (id INTEGER NOT NULL, PRIMARY KEY (id));
+CREATE VIEW user_names AS SELECT id, name FROM users;
+CREATE VIEW team_ids AS SELECT id FROM teams;
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"users"})
+		require.NoError(t, err)
+		assert.Equal(t, `-- -name TEXT
+-- +name VARCHAR(255)
+ALTER TABLE users ALTER COLUMN name SET DATA TYPE VARCHAR(255);
+`, filtered.String())
+		assert.Equal(t, []string{`"CREATE VIEW user_names AS SELECT id, name FROM users;" is skipped, but it depends on "ALTER TABLE users ALTER COLUMN name SET DATA TYPE VARCHAR(255);"`}, warnings)
+
+		filtered, warnings, err = FilterTargets(before, result, []string{"team_ids"})
+		require.NoError(t, err)
+		assert.Equal(t, "CREATE VIEW team_ids AS SELECT id FROM teams;\n", filtered.String())
+		assert.Equal(t, []string{`"CREATE TABLE teams (" is skipped, but "CREATE VIEW team_ids AS SELECT id FROM teams;" depends on it`}, warnings)
+	})
+
+	t.Run("failure,pattern", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := NewParser(NewLexer(`CREATE TABLE users (id INTEGER);
+`)).Parse()
+		require.NoError(t, err)
+
+		_, _, err = FilterTargets(nil, result, []string{"users["})
+		require.Error(t, err)
+	})
+}
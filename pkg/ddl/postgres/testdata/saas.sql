@@ -0,0 +1,44 @@
+-- A dump of the schema of a multi-tenant SaaS application.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TYPE public.plan AS ENUM ('free', 'team', 'enterprise');
+
+CREATE SEQUENCE public.invoice_number_seq START WITH 1000 INCREMENT BY 1;
+
+CREATE TABLE public.tenants (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    plan public.plan NOT NULL DEFAULT 'free',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT tenants_pkey PRIMARY KEY (id),
+    CONSTRAINT tenants_name_unique UNIQUE (name)
+);
+
+CREATE TABLE public.users (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    display_name TEXT,
+    age INTEGER CHECK (age >= 0),
+    is_admin BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES public.tenants (id) ON DELETE CASCADE,
+    CONSTRAINT users_email_unique UNIQUE (tenant_id, email)
+);
+
+CREATE INDEX users_idx_on_tenant_id_created_at ON public.users (tenant_id, created_at);
+
+CREATE TABLE public.invoices (
+    id BIGINT NOT NULL DEFAULT nextval('public.invoice_number_seq'),
+    tenant_id UUID NOT NULL REFERENCES public.tenants (id),
+    amount NUMERIC(12, 2) NOT NULL,
+    issued_on DATE NOT NULL,
+    note TEXT DEFAULT '',
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_unique_tenant_id_issued_on ON public.invoices (tenant_id, issued_on);
+
+CREATE VIEW public.active_users AS SELECT id, tenant_id, email FROM public.users WHERE is_admin = false;
@@ -47,14 +47,39 @@ type Stmt interface {
 }
 
 type DDL struct {
-	Stmts []Stmt
+	// Header is the comments at the beginning, which are separated from the first statement by a blank line.
+	Header []string
+	Stmts  []Stmt
+	// Comments is the comments after the last statement.
+	Comments []string
 }
 
 func (d *DDL) String() string {
 	if d == nil {
 		return ""
 	}
-	return stringz.JoinStringers("", d.Stmts...)
+	str := headerString(d.Header) + stringz.JoinStringers("", d.Stmts...)
+	for _, comment := range d.Comments {
+		if comment != "" {
+			str += CommentPrefix + comment + "\n"
+		}
+	}
+	return str
+}
+
+// headerString returns header followed by a blank line, or "" if header is empty.
+func headerString(header []string) string {
+	var str string
+	for _, comment := range header {
+		if comment != "" {
+			str += CommentPrefix + comment
+		}
+		str += "\n"
+	}
+	if str != "" {
+		str += "\n"
+	}
+	return str
 }
 
 type Ident struct {
@@ -6,12 +6,18 @@ import (
 
 	"github.com/hakadoriya/z.go/stringz"
 
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 	"github.com/hakadoriya/ddlctl/pkg/ddl/internal"
 )
 
 type Constraint interface {
 	isConstraint()
 	GetName() *Ident
+	// GetComments returns the comments that precede the constraint in CREATE TABLE.
+	GetComments() []string
+	// GetTrailingComment returns the comment on the same line after the constraint in CREATE TABLE.
+	GetTrailingComment() string
+	setTrailingComment(comment string)
 	GoString() string
 	String() string
 	StringForDiff() string
@@ -33,17 +39,24 @@ func (constraints Constraints) Append(constraint Constraint) Constraints {
 
 // ForeignKeyConstraint represents a FOREIGN KEY constraint.
 type ForeignKeyConstraint struct {
-	Name       *Ident
-	Columns    []*ColumnIdent
-	Ref        *Ident
-	RefColumns []*ColumnIdent
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Columns         []*ColumnIdent
+	Ref             *Ident
+	RefColumns      []*ColumnIdent
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*ForeignKeyConstraint)(nil)
 
-func (*ForeignKeyConstraint) isConstraint()      {}
-func (c *ForeignKeyConstraint) GetName() *Ident  { return c.Name }
-func (c *ForeignKeyConstraint) GoString() string { return internal.GoString(*c) }
+func (*ForeignKeyConstraint) isConstraint()                       {}
+func (c *ForeignKeyConstraint) GetName() *Ident                   { return c.Name }
+func (c *ForeignKeyConstraint) GetComments() []string             { return c.Comments }
+func (c *ForeignKeyConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *ForeignKeyConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *ForeignKeyConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *ForeignKeyConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -84,22 +97,30 @@ func (c *ForeignKeyConstraint) StringForDiff() string {
 
 // IndexConstraint represents a UNIQUE constraint. //diff:ignore-line-postgres-cockroach.
 type IndexConstraint struct { //diff:ignore-line-postgres-cockroach
-	Name    *Ident
-	Unique  bool //diff:ignore-line-postgres-cockroach
-	Columns []*ColumnIdent
+	Comments []string
+	Name     *Ident
+	Unique   bool //diff:ignore-line-postgres-cockroach
+	Columns  []*ColumnIdent
 }
 
 // CheckConstraint represents a CHECK constraint.
 type CheckConstraint struct {
-	Name *Ident
-	Expr *Expr
+	Comments        []string
+	TrailingComment string
+	Name            *Ident
+	Expr            *Expr
+	// Pos is the position of the table constraint. It is zero for a column constraint.
+	Pos ddl.Position
 }
 
 var _ Constraint = (*CheckConstraint)(nil)
 
-func (*CheckConstraint) isConstraint()      {}
-func (c *CheckConstraint) GetName() *Ident  { return c.Name }
-func (c *CheckConstraint) GoString() string { return internal.GoString(*c) }
+func (*CheckConstraint) isConstraint()                       {}
+func (c *CheckConstraint) GetName() *Ident                   { return c.Name }
+func (c *CheckConstraint) GetComments() []string             { return c.Comments }
+func (c *CheckConstraint) GetTrailingComment() string        { return c.TrailingComment }
+func (c *CheckConstraint) setTrailingComment(comment string) { c.TrailingComment = comment }
+func (c *CheckConstraint) GoString() string                  { return internal.GoString(*c) }
 func (c *CheckConstraint) String() string {
 	var str string
 	if c.Name != nil {
@@ -169,11 +190,17 @@ func (t *ObjectName) StringForDiff() string {
 }
 
 type Column struct {
-	Name     *Ident
-	DataType *DataType
-	Default  *Default
-	NotNull  bool
-	Options  *Expr
+	// Comments is the comments that precede the column definition.
+	Comments []string
+	// TrailingComment is the comment on the same line after the column definition.
+	TrailingComment string
+	Name            *Ident
+	DataType        *DataType
+	Default         *Default
+	NotNull         bool
+	Options         *Expr
+	// Pos is the position of the column name.
+	Pos ddl.Position
 }
 
 type Default struct {
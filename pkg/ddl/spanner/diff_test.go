@@ -22,6 +22,18 @@ func TestDiff(t *testing.T) {
 		require.ErrorIs(t, err, ddl.ErrNoDifference)
 	})
 
+	t.Run("failure,ddl.ErrNoDifference,RawStmt", func(t *testing.T) {
+		t.Parallel()
+
+		before := &DDL{Stmts: []Stmt{&RawStmt{Raw: "GRANT SELECT ON users TO readonly"}}}
+		after := &DDL{Stmts: []Stmt{&RawStmt{Raw: "GRANT SELECT ON users TO readwrite"}}}
+		_, err := Diff(before, after)
+		require.ErrorIs(t, err, ddl.ErrNoDifference)
+		result, err := Diff(nil, after)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(result.Stmts))
+	})
+
 	t.Run("failure,ddl.ErrNotSupported,DropTableStmt", func(t *testing.T) {
 		t.Parallel()
 
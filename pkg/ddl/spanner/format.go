@@ -0,0 +1,53 @@
+package spanner
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Format returns d in the canonical layout of `ddlctl fmt`.
+// Statements are separated by a blank line, and in CREATE TABLE the column names are padded so that
+// the column definitions are aligned and the built-in data types are written in upper case.
+func Format(d *DDL) string {
+	if d == nil {
+		return ""
+	}
+
+	strs := make([]string, 0, len(d.Stmts)+1)
+	for _, stmt := range d.Stmts {
+		if s, ok := stmt.(*CreateTableStmt); ok {
+			strs = append(strs, formatCreateTableStmt(s))
+			continue
+		}
+		strs = append(strs, stmt.String())
+	}
+
+	var comments string
+	for _, comment := range d.Comments {
+		if comment != "" {
+			comments += CommentPrefix + comment + "\n"
+		}
+	}
+	if comments != "" {
+		strs = append(strs, comments)
+	}
+
+	return headerString(d.Header) + strings.Join(strs, "\n")
+}
+
+func formatCreateTableStmt(s *CreateTableStmt) string {
+	stmt := *s
+	stmt.Columns = make([]*Column, 0, len(s.Columns))
+	columnNameWidth := 0
+	for _, column := range s.Columns {
+		c := *column
+		if c.DataType != nil && c.DataType.Type != TOKEN_IDENT {
+			dataType := *c.DataType
+			dataType.Name = strings.ToUpper(dataType.Name)
+			c.DataType = &dataType
+		}
+		stmt.Columns = append(stmt.Columns, &c)
+		columnNameWidth = max(columnNameWidth, utf8.RuneCountInString(c.Name.String()))
+	}
+	return stmt.string(columnNameWidth)
+}
@@ -0,0 +1,122 @@
+package spanner
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+
+		input := `-- users table
+create table users (
+  -- primary key
+  id string(36) not null,
+  name string(255) not null, age int64,
+  -- the foreign key to groups
+  constraint users_group_fkey foreign key (group_id) references groups (id),
+) primary key (id);
+--index
+create unique index users_idx_name on users (name);
+-- trailing comment
+`
+		expected := `-- users table
+CREATE TABLE users (
+    -- primary key
+    id   STRING(36) NOT NULL,
+    name STRING(255) NOT NULL,
+    age  INT64,
+    -- the foreign key to groups
+    CONSTRAINT users_group_fkey FOREIGN KEY (group_id) REFERENCES groups (id)
+) PRIMARY KEY (id);
+
+-- index
+CREATE UNIQUE INDEX users_idx_name ON users (name);
+
+-- trailing comment
+`
+
+		d, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+		actual := Format(d)
+		assert.Equal(t, expected, actual)
+
+		d, err = NewParser(NewLexer(actual)).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, expected, Format(d))
+	})
+
+	t.Run("success,trailing_comment", func(t *testing.T) {
+		t.Parallel()
+
+		input := `-- header comment
+
+-- users table
+create table users (
+  id int64 NOT NULL, -- trailing id comment
+  -- name
+  name string(255) not null, -- trailing name comment
+  constraint users_group_fkey foreign key (group_id) references groups (id), -- trailing fk comment
+) primary key (id);
+`
+		expected := `-- header comment
+
+-- users table
+CREATE TABLE users (
+    id   INT64 NOT NULL, -- trailing id comment
+    -- name
+    name STRING(255) NOT NULL, -- trailing name comment
+    CONSTRAINT users_group_fkey FOREIGN KEY (group_id) REFERENCES groups (id) -- trailing fk comment
+) PRIMARY KEY (id);
+`
+
+		d, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+		actual := Format(d)
+		assert.Equal(t, expected, actual)
+
+		d, err = NewParser(NewLexer(actual)).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, expected, Format(d))
+	})
+
+	t.Run("success,comment_inside_and_before_close", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the comment inside the column definition precedes the column, and the one before ")" is kept before it.
+		input := `CREATE TABLE users (
+ id int64 -- inside column
+ not null,
+ name string(36)
+ -- before close
+) primary key (id);
+`
+		expected := `CREATE TABLE users (
+    -- inside column
+    id   INT64 NOT NULL,
+    name STRING(36)
+    -- before close
+) PRIMARY KEY (id);
+`
+
+		d, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+		actual := Format(d)
+		assert.Equal(t, expected, actual)
+
+		d, err = NewParser(NewLexer(actual)).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, expected, Format(d))
+	})
+
+	t.Run("success,nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "", Format(nil))
+	})
+}
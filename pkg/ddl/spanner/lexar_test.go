@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
 )
 
 func Test_lookupIdent(t *testing.T) {
@@ -98,67 +100,67 @@ func TestLex(t *testing.T) {
     UNIQUE ("email")
 );`,
 			want: []Token{
-				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}},
-				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}},
-				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "36"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}},
-				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}},
-				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}},
-				{Type: TOKEN_TIMESTAMP, Literal: Literal{Str: "TIMESTAMP"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}},
-				{Type: TOKEN_TIMESTAMP, Literal: Literal{Str: "TIMESTAMP"}},
-				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}},
-				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}},
-				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}},
-				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}},
-				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}},
-				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}},
-				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}},
+				{Type: TOKEN_CREATE, Literal: Literal{Str: "CREATE"}, Pos: ddl.Position{Offset: 0, Line: 1, Column: 1}},
+				{Type: TOKEN_TABLE, Literal: Literal{Str: "TABLE"}, Pos: ddl.Position{Offset: 7, Line: 1, Column: 8}},
+				{Type: TOKEN_IF, Literal: Literal{Str: "IF"}, Pos: ddl.Position{Offset: 13, Line: 1, Column: 14}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 16, Line: 1, Column: 17}},
+				{Type: TOKEN_EXISTS, Literal: Literal{Str: "EXISTS"}, Pos: ddl.Position{Offset: 20, Line: 1, Column: 21}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"users"`}, Pos: ddl.Position{Offset: 27, Line: 1, Column: 28}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 35, Line: 1, Column: 36}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 41, Line: 2, Column: 5}},
+				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}, Pos: ddl.Position{Offset: 54, Line: 2, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 60, Line: 2, Column: 24}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "36"}, Pos: ddl.Position{Offset: 61, Line: 2, Column: 25}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 63, Line: 2, Column: 27}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 68, Line: 2, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 72, Line: 2, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 76, Line: 2, Column: 40}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"name"`}, Pos: ddl.Position{Offset: 82, Line: 3, Column: 5}},
+				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}, Pos: ddl.Position{Offset: 95, Line: 3, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 101, Line: 3, Column: 24}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 102, Line: 3, Column: 25}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 105, Line: 3, Column: 28}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 109, Line: 3, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 113, Line: 3, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 117, Line: 3, Column: 40}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 123, Line: 4, Column: 5}},
+				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}, Pos: ddl.Position{Offset: 136, Line: 4, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 142, Line: 4, Column: 24}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 143, Line: 4, Column: 25}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 146, Line: 4, Column: 28}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 150, Line: 4, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 154, Line: 4, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 158, Line: 4, Column: 40}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"password"`}, Pos: ddl.Position{Offset: 164, Line: 5, Column: 5}},
+				{Type: TOKEN_STRING, Literal: Literal{Str: "STRING"}, Pos: ddl.Position{Offset: 177, Line: 5, Column: 18}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 183, Line: 5, Column: 24}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: "255"}, Pos: ddl.Position{Offset: 184, Line: 5, Column: 25}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 187, Line: 5, Column: 28}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 191, Line: 5, Column: 32}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 195, Line: 5, Column: 36}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 199, Line: 5, Column: 40}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"created_at"`}, Pos: ddl.Position{Offset: 205, Line: 6, Column: 5}},
+				{Type: TOKEN_TIMESTAMP, Literal: Literal{Str: "TIMESTAMP"}, Pos: ddl.Position{Offset: 218, Line: 6, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 230, Line: 6, Column: 30}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 234, Line: 6, Column: 34}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 238, Line: 6, Column: 38}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"updated_at"`}, Pos: ddl.Position{Offset: 244, Line: 7, Column: 5}},
+				{Type: TOKEN_TIMESTAMP, Literal: Literal{Str: "TIMESTAMP"}, Pos: ddl.Position{Offset: 257, Line: 7, Column: 18}},
+				{Type: TOKEN_NOT, Literal: Literal{Str: "NOT"}, Pos: ddl.Position{Offset: 269, Line: 7, Column: 30}},
+				{Type: TOKEN_NULL, Literal: Literal{Str: "NULL"}, Pos: ddl.Position{Offset: 273, Line: 7, Column: 34}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 277, Line: 7, Column: 38}},
+				{Type: TOKEN_PRIMARY, Literal: Literal{Str: "PRIMARY"}, Pos: ddl.Position{Offset: 283, Line: 8, Column: 5}},
+				{Type: TOKEN_KEY, Literal: Literal{Str: "KEY"}, Pos: ddl.Position{Offset: 291, Line: 8, Column: 13}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 295, Line: 8, Column: 17}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"user_id"`}, Pos: ddl.Position{Offset: 296, Line: 8, Column: 18}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 305, Line: 8, Column: 27}},
+				{Type: TOKEN_COMMA, Literal: Literal{Str: ","}, Pos: ddl.Position{Offset: 306, Line: 8, Column: 28}},
+				{Type: TOKEN_UNIQUE, Literal: Literal{Str: "UNIQUE"}, Pos: ddl.Position{Offset: 312, Line: 9, Column: 5}},
+				{Type: TOKEN_OPEN_PAREN, Literal: Literal{Str: "("}, Pos: ddl.Position{Offset: 319, Line: 9, Column: 12}},
+				{Type: TOKEN_IDENT, Literal: Literal{Str: `"email"`}, Pos: ddl.Position{Offset: 320, Line: 9, Column: 13}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 327, Line: 9, Column: 20}},
+				{Type: TOKEN_CLOSE_PAREN, Literal: Literal{Str: ")"}, Pos: ddl.Position{Offset: 329, Line: 10, Column: 1}},
+				{Type: TOKEN_SEMICOLON, Literal: Literal{Str: ";"}, Pos: ddl.Position{Offset: 330, Line: 10, Column: 2}},
 			},
 		},
 	}
@@ -208,6 +210,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "|"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -216,6 +219,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: ":"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 		{
@@ -224,6 +228,7 @@ func TestLexer_NextToken(t *testing.T) {
 			want: Token{
 				Type:    TOKEN_ILLEGAL,
 				Literal: Literal{Str: "!"},
+				Pos:     ddl.Position{Offset: 0, Line: 1, Column: 1},
 			},
 		},
 	}
@@ -53,6 +53,48 @@ ROW DELETION POLICY (OLDER_THAN(ExpiredDate, INTERVAL 0 DAY));
 		t.Logf("✅: %s: actual: %%s: \n%s", t.Name(), actual)
 	})
 
+	t.Run("success,REFERENCES_before_table_constraint", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE Users (Id INT64 NOT NULL, GroupId INT64 REFERENCES Groups (Id), Name STRING(MAX)) PRIMARY KEY (Id);`
+		expected := `CREATE TABLE Users (
+    Id INT64 NOT NULL,
+    GroupId INT64,
+    Name STRING(MAX),
+    CONSTRAINT Users_GroupId_fkey FOREIGN KEY (GroupId) REFERENCES Groups (Id)
+) PRIMARY KEY (Id);
+`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		if !assert.Equal(t, expected, actual.String()) {
+			t.Errorf("❌: %s: stmt: %%#v: \n%#v", t.Name(), actual)
+		}
+	})
+
+	t.Run("success,Pos", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE TABLE Users (
+    Id INT64 NOT NULL,
+    CONSTRAINT Users_check CHECK (Id > 0)
+) PRIMARY KEY (Id);
+
+CREATE INDEX UsersById ON Users (Id);`
+
+		actual, err := NewParser(NewLexer(input)).Parse()
+		require.NoError(t, err)
+
+		table := actual.Stmts[0].(*CreateTableStmt) //nolint:forcetypeassert
+		assert.Equal(t, "1:1", table.Pos.String())
+		assert.Equal(t, "2:5", table.Columns[0].Pos.String())
+		constraint := table.Constraints[0].(*CheckConstraint) //nolint:forcetypeassert
+		assert.Equal(t, "3:5", constraint.Pos.String())
+		index := actual.Stmts[1].(*CreateIndexStmt) //nolint:forcetypeassert
+		assert.Equal(t, "6:1", index.Pos.String())
+	})
+
 	t.Run("success,complex_defaults", func(t *testing.T) {
 		// t.Parallel()
 
@@ -73,7 +115,9 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 		actual, err := p.Parse()
 		require.NoError(t, err)
 
-		const expected = `CREATE TABLE IF NOT EXISTS complex_defaults (
+		const expected = `-- table: complex_defaults
+CREATE TABLE IF NOT EXISTS complex_defaults (
+    -- id is the primary key.
     id INT64,
     created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP()),
     updated_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP()),
@@ -470,6 +514,61 @@ CREATE TABLE IF NOT EXISTS complex_defaults (
 		})
 	}
 
+	t.Run("failure,ParseErrors", func(t *testing.T) {
+		t.Parallel()
+
+		input := "CREATE TABLE a (id INT64 NOT NOT) PRIMARY KEY (id);\nCREATE TABLE b (id INT64) PRIMARY KEY (id);\nCREATE TABLE c (id INT64 NOT NOT) PRIMARY KEY (id);\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnexpectedPeekToken)
+		parseErrs := ddl.AsParseErrors(err)
+		if !assert.Equal(t, 2, len(parseErrs)) {
+			t.FailNow()
+		}
+		assert.Equal(t, 1, parseErrs[0].Position.Line)
+		assert.Equal(t, 3, parseErrs[1].Position.Line)
+	})
+
+	t.Run("success,lenient", func(t *testing.T) {
+		t.Parallel()
+
+		input := "-- read only\nGRANT SELECT ON TABLE Users TO ROLE Reader;\nCREATE TABLE Users (Id INT64 NOT NULL) PRIMARY KEY (Id);\nCREATE ROLE Reader;\n"
+		_, err := NewParser(NewLexer(input)).Parse()
+		require.ErrorIs(t, err, ddl.ErrUnsupportedStatement)
+
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Comment: "read only", Raw: "GRANT SELECT ON TABLE Users TO ROLE Reader"}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: "CREATE ROLE Reader"}, d.Stmts[2])
+		if !assert.Equal(t, 2, len(p.Warnings())) {
+			t.FailNow()
+		}
+		assert.Equal(t, "GRANT", p.Warnings()[0].Token)
+		assert.Equal(t, "ROLE", p.Warnings()[1].Token)
+		require.ErrorIs(t, p.Warnings()[1], ddl.ErrUnsupportedStatement)
+	})
+
+	t.Run("success,lenient,function_body", func(t *testing.T) {
+		t.Parallel()
+
+		input := `CREATE FUNCTION greet() RETURNS STRING SQL SECURITY INVOKER AS ('''it's; ok''');
+CREATE TABLE Users (Id INT64 NOT NULL) PRIMARY KEY (Id);
+CREATE FUNCTION quoted() RETURNS STRING SQL SECURITY INVOKER AS ('it\'s; ok');
+`
+		p := NewParser(NewLexer(input), ParserUseLenient(true))
+		d, err := p.Parse()
+		require.NoError(t, err)
+		if !assert.Equal(t, 3, len(d.Stmts)) {
+			t.FailNow()
+		}
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION greet() RETURNS STRING SQL SECURITY INVOKER AS ('''it's; ok''')`}, d.Stmts[0])
+		assert.Equal(t, &RawStmt{Raw: `CREATE FUNCTION quoted() RETURNS STRING SQL SECURITY INVOKER AS ('it\'s; ok')`}, d.Stmts[2])
+		assert.Equal(t, 2, len(p.Warnings()))
+	})
+
 	t.Run("success,TOKEN_SEMICOLON", func(t *testing.T) {
 		_, err := NewParser(NewLexer(`;`)).Parse()
 		require.NoError(t, err)
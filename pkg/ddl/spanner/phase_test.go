@@ -0,0 +1,89 @@
+package spanner
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+func TestSplitPhases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,expand_migrate_contract", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INT64 NOT NULL, name STRING(10) NOT NULL, age INT64) PRIMARY KEY (id);
+CREATE INDEX users_idx_age ON users (age);
+CREATE TABLE old_logs (id INT64) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INT64 NOT NULL, name STRING(100) NOT NULL, email STRING(MAX) NOT NULL, nickname STRING(MAX)) PRIMARY KEY (id);
+CREATE UNIQUE INDEX users_idx_email ON users (email);
+CREATE INDEX users_idx_nickname ON users (nickname);
+CREATE TABLE logs (id INT64) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		phased := SplitPhases(result)
+		assert.Equal(t, `CREATE TABLE logs (
+    id INT64
+) PRIMARY KEY (id);
+-- -
+-- +email STRING(MAX) NOT NULL
+ALTER TABLE users ADD COLUMN email STRING(MAX);
+-- -
+-- +nickname STRING(MAX)
+ALTER TABLE users ADD COLUMN nickname STRING(MAX);
+CREATE INDEX users_idx_nickname ON users (nickname);
+`, phased.Phase(ddl.PhaseExpand).String())
+		assert.Equal(t, `-- TODO: backfill email before the contract phase, which sets NOT NULL
+UPDATE users SET email = <value> WHERE email IS NULL;
+-- TODO: backfill nickname from the dropped column of users if it replaces one
+UPDATE users SET nickname = <value> WHERE nickname IS NULL;
+`, phased.Phase(ddl.PhaseMigrate).String())
+		assert.Equal(t, `DROP INDEX users_idx_age;
+DROP TABLE old_logs;
+CREATE UNIQUE INDEX users_idx_email ON users (email);
+-- -name STRING(10) NOT NULL
+-- +name STRING(100) NOT NULL
+ALTER TABLE users ALTER COLUMN name STRING(100) NOT NULL;
+-- -age INT64
+-- +
+ALTER TABLE users DROP COLUMN age;
+ALTER TABLE users ALTER COLUMN email STRING(MAX) NOT NULL;
+`, phased.Phase(ddl.PhaseContract).String())
+	})
+
+	t.Run("success,recreate", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INT64 NOT NULL, name STRING(10) NOT NULL, age INT64) PRIMARY KEY (id);
+CREATE INDEX users_idx_name ON users (name);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INT64 NOT NULL, name STRING(10) NOT NULL, age INT64) PRIMARY KEY (id);
+CREATE INDEX users_idx_name ON users (id, name);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		phased := SplitPhases(result)
+		assert.Equal(t, 0, len(phased.Expand.Stmts))
+		assert.Equal(t, 0, len(phased.Migrate.Stmts))
+		assert.Equal(t, result.String(), phased.Contract.String())
+	})
+
+	t.Run("success,nil", func(t *testing.T) {
+		t.Parallel()
+
+		phased := SplitPhases(nil)
+		assert.Equal(t, "", phased.Expand.String()+phased.Migrate.String()+phased.Contract.String())
+		assert.Equal(t, (*DDL)(nil), phased.Phase(ddl.Phase("unknown")))
+	})
+}
@@ -0,0 +1,132 @@
+package spanner
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// FilterTargets returns the statements of result on the tables matched by patterns, such as users or order_*, see ddl.MatchTarget,
+// which include the indexes and the constraints on the tables.
+// It also returns the warnings for the skipped statements that the returned statements depend on, which are the tables referenced by FOREIGN KEY.
+// before is the DDL that result is diffed from, to find the table of DROP INDEX.
+//
+//nolint:cyclop,funlen
+func FilterTargets(before, result *DDL, patterns []string) (filtered *DDL, warnings []string, err error) {
+	filtered = &DDL{}
+	if result == nil {
+		return filtered, nil, nil
+	}
+
+	indexTables := make(map[string]string)
+	if before != nil {
+		for _, stmt := range before.Stmts {
+			if s, ok := stmt.(*CreateIndexStmt); ok {
+				indexTables[targetKey(s.Name.StringForDiff())] = s.TableName.StringForDiff()
+			}
+		}
+	}
+
+	skipped := make(map[string]Stmt)
+	for _, stmt := range result.Stmts {
+		names := []string{TargetTable(stmt)}
+		switch s := stmt.(type) {
+		case *RawStmt:
+			continue
+		case *DropIndexStmt:
+			if table, ok := indexTables[targetKey(s.GetNameForDiff())]; ok {
+				names = []string{table}
+			}
+		case *AlterTableStmt:
+			if a, ok := s.Action.(*RenameTable); ok {
+				names = append(names, a.NewName.StringForDiff())
+			}
+		}
+
+		matched, err := matchTargets(patterns, names)
+		if err != nil {
+			return nil, nil, apperr.Errorf("matchTargets: %w", err)
+		}
+		if matched {
+			filtered.Stmts = append(filtered.Stmts, stmt)
+			continue
+		}
+		if _, ok := stmt.(*CreateTableStmt); ok {
+			skipped[targetKey(stmt.GetNameForDiff())] = stmt
+		}
+	}
+
+	for _, stmt := range filtered.Stmts {
+		for _, dep := range targetDependencies(stmt) {
+			if s, ok := skipped[targetKey(dep)]; ok {
+				warnings = append(warnings, strconv.Quote(firstLine(s.String()))+" is skipped, but "+strconv.Quote(firstLine(stmt.String()))+" depends on it")
+			}
+		}
+	}
+
+	return filtered, warnings, nil
+}
+
+// TargetTable returns the name of the table that stmt is on, such as the table of CREATE INDEX,
+// or the name of stmt itself if it is not on a table. DROP INDEX is on the index name, because the statement has no table.
+func TargetTable(stmt Stmt) string {
+	if s, ok := stmt.(*CreateIndexStmt); ok {
+		return s.TableName.StringForDiff()
+	}
+	return stmt.GetNameForDiff()
+}
+
+func matchTargets(patterns, names []string) (bool, error) {
+	for _, name := range names {
+		matched, err := ddl.MatchTarget(patterns, name)
+		if err != nil {
+			return false, apperr.Errorf("ddl.MatchTarget: %w", err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// targetDependencies returns the names of the tables that stmt refers to.
+func targetDependencies(stmt Stmt) []string {
+	deps := make([]string, 0)
+	addConstraint := func(c Constraint) {
+		if fk, ok := c.(*ForeignKeyConstraint); ok {
+			deps = append(deps, fk.Ref.StringForDiff())
+		}
+	}
+
+	switch s := stmt.(type) {
+	case *CreateTableStmt:
+		for _, c := range s.Constraints {
+			addConstraint(c)
+		}
+	case *AlterTableStmt:
+		if a, ok := s.Action.(*AddConstraint); ok {
+			addConstraint(a.Constraint)
+		}
+	}
+	return deps
+}
+
+// targetKey returns the name without the schema in lower case, to compare the names of the dependencies.
+func targetKey(name string) string {
+	name = strings.ToLower(name)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, CommentPrefix) {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package spanner
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestFilterTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,dependency", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INT64 NOT NULL, name STRING(MAX) NOT NULL) PRIMARY KEY (id);
+CREATE INDEX users_idx_name ON users (name);
+CREATE TABLE logs (id INT64) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE teams (id INT64 NOT NULL) PRIMARY KEY (id);
+CREATE TABLE users (id INT64 NOT NULL, name STRING(MAX) NOT NULL, team_id INT64, CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id)) PRIMARY KEY (id);
+CREATE TABLE logs (id INT64, message STRING(MAX)) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"USERS"})
+		require.NoError(t, err)
+		assert.Equal(t, `DROP INDEX users_idx_name;
+-- -
+-- +team_id INT64
+ALTER TABLE users ADD COLUMN team_id INT64;
+-- -
+-- +CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id)
+ALTER TABLE users ADD CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id);
+`, filtered.String())
+		assert.Equal(t, []string{`"CREATE TABLE teams (" is skipped, but "ALTER TABLE users ADD CONSTRAINT users_fk_team_id FOREIGN KEY (team_id) REFERENCES teams (id);" depends on it`}, warnings)
+	})
+
+	t.Run("success,glob", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := NewParser(NewLexer(`CREATE TABLE users (id INT64) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := NewParser(NewLexer(`CREATE TABLE users (id INT64) PRIMARY KEY (id);
+CREATE TABLE order_items (id INT64) PRIMARY KEY (id);
+CREATE INDEX order_items_idx_id ON order_items (id);
+CREATE TABLE orders (id INT64) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := Diff(before, after)
+		require.NoError(t, err)
+
+		filtered, warnings, err := FilterTargets(before, result, []string{"order_*"})
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE order_items (
+    id INT64
+) PRIMARY KEY (id);
+CREATE INDEX order_items_idx_id ON order_items (id);
+`, filtered.String())
+		assert.Equal(t, 0, len(warnings))
+	})
+
+	t.Run("failure,pattern", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := NewParser(NewLexer(`CREATE TABLE users (id INT64) PRIMARY KEY (id);
+`)).Parse()
+		require.NoError(t, err)
+
+		_, _, err = FilterTargets(nil, result, []string{"users["})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+-- A dump of the schema of a multi-tenant SaaS application.
+CREATE TABLE Tenants (
+    TenantId STRING(36) NOT NULL,
+    Name STRING(255) NOT NULL,
+    Plan STRING(16) NOT NULL DEFAULT ("free"),
+    CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+) PRIMARY KEY (TenantId);
+
+CREATE UNIQUE INDEX TenantsByName ON Tenants (Name);
+
+CREATE TABLE Users (
+    TenantId STRING(36) NOT NULL,
+    UserId STRING(36) NOT NULL,
+    Email STRING(MAX) NOT NULL,
+    DisplayName STRING(MAX),
+    Age INT64,
+    IsAdmin BOOL NOT NULL DEFAULT (false),
+    CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+    CONSTRAINT CK_Users_Age CHECK (Age >= 0),
+) PRIMARY KEY (TenantId, UserId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX UsersByEmail ON Users (TenantId, Email);
+
+CREATE TABLE Invoices (
+    TenantId STRING(36) NOT NULL,
+    InvoiceId INT64 NOT NULL,
+    Amount NUMERIC NOT NULL,
+    IssuedOn DATE NOT NULL,
+    Note STRING(MAX),
+    Receipt BYTES(MAX),
+    CONSTRAINT FK_Invoices_Tenants FOREIGN KEY (TenantId) REFERENCES Tenants (TenantId),
+) PRIMARY KEY (TenantId, InvoiceId);
@@ -0,0 +1,30 @@
+package ddl
+
+import (
+	"path"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+)
+
+// MatchTarget reports whether name matches any of patterns, which are the glob patterns of path.Match such as users or order_*.
+// The matching is case-insensitive, and a schema-qualified name such as public.users also matches by the name without the schema.
+func MatchTarget(patterns []string, name string) (bool, error) {
+	name = strings.ToLower(name)
+	names := []string{name}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		names = append(names, name[i+1:])
+	}
+	for _, pattern := range patterns {
+		for _, n := range names {
+			matched, err := path.Match(strings.ToLower(pattern), n)
+			if err != nil {
+				return false, apperr.Errorf("path.Match: pattern=%s: %w", pattern, err)
+			}
+			if matched {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
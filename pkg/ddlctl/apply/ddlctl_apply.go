@@ -6,7 +6,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,8 +26,9 @@ import (
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
 	"github.com/hakadoriya/ddlctl/pkg/internal/config"
 	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
-	"github.com/hakadoriya/ddlctl/pkg/internal/util"
+	ddllint "github.com/hakadoriya/ddlctl/pkg/lint"
 	"github.com/hakadoriya/ddlctl/pkg/logs"
+	spanshow "github.com/hakadoriya/ddlctl/pkg/show/spanner"
 )
 
 //nolint:cyclop,funlen,gocognit,gocyclo
@@ -35,25 +38,89 @@ func Command(c *cliz.Command, args []string) (err error) {
 		return apperr.Errorf("config.Load: %w", err)
 	}
 
+	args = config.Args(args)
 	const beforeAndAfterForDiff = 2
 	if len(args) != beforeAndAfterForDiff {
 		return apperr.Errorf("args=%v: %w", args, apperr.ErrTwoArgumentsRequired)
 	}
+	args, err = config.ResolveDSNs(ctx, args)
+	if err != nil {
+		return apperr.Errorf("config.ResolveDSNs: %w", err)
+	}
+	shadowDSN, err := config.ResolveDSN(ctx, config.ShadowDSN())
+	if err != nil {
+		return apperr.Errorf("config.ResolveDSN: %w", err)
+	}
 
 	dialect := config.Dialect()
 	language := config.Language()
 	leftArg, rightArg := args[0], args[1]
 
-	buf := new(strings.Builder)
-	if err := diff.Diff(ctx, buf, dialect, language, leftArg, rightArg); err != nil {
-		if errors.Is(err, ddl.ErrNoDifference) {
-			_, _ = fmt.Fprintln(os.Stdout, ddl.ErrNoDifference.Error())
-			return nil
+	// MEMO: the migrate phase is the templates to be edited and run by hand, so it cannot be applied.
+	if err := diff.ValidatePhase(config.Phase(), ddl.PhaseExpand, ddl.PhaseContract); err != nil {
+		return apperr.Errorf("diff.ValidatePhase: %w", err)
+	}
+
+	applyOpts := []ApplyOption{ApplyWithLockTimeout(config.LockTimeout()), ApplyWithStatementTimeout(config.StatementTimeout())}
+	if filename := config.Hooks(); filename != "" {
+		hooks, err := loadHooksFile(filename)
+		if err != nil {
+			return apperr.Errorf("loadHooksFile: %w", err)
+		}
+		applyOpts = append(applyOpts, ApplyWithHooks(hooks))
+	}
+
+	// MEMO: the lock is taken before the diff, because the diff is stale once another apply changes the database.
+	unlock, err := Lock(ctx, dialect, leftArg, config.ApplyLockWait())
+	if err != nil {
+		return apperr.Errorf("Lock: %w", err)
+	}
+	defer func() {
+		if err2 := unlock(context.WithoutCancel(ctx)); err2 != nil && err == nil {
+			err = apperr.Errorf("unlock: %w", err2)
+		}
+	}()
+
+	// MEMO: the interactive mode needs a terminal to answer, so it falls back to the prompt for the whole DDL otherwise, such as in CI.
+	interactive := config.Interactive() && !config.AutoApprove()
+	if interactive && !isTerminal(os.Stdin) {
+		logs.Warn.Printf("--%s is ignored, because stdin is not a terminal", consts.OptionInteractive)
+		interactive = false
+	}
+
+	stateFile := config.StateFile()
+	var progress *Progress
+	var ddlStr string
+	var findings []*ddllint.SafetyFinding
+	if config.Resume() {
+		progress, err = loadProgressToResume(stateFile, dialect, leftArg)
+		if err != nil {
+			return apperr.Errorf("loadProgressToResume: %w", err)
+		}
+		ddlStr = progress.Remaining()
+	} else {
+		if err := checkUnfinished(stateFile); err != nil {
+			return apperr.Errorf("checkUnfinished: %w", err)
 		}
-		return apperr.Errorf("diff: %w", err)
+
+		diffOpts := diff.ConfigOptions()
+		if interactive {
+			diffOpts = append(diffOpts, diff.DiffWithSafetyFindings(func(f []*ddllint.SafetyFinding) { findings = f }))
+		}
+		buf := new(strings.Builder)
+		if err := diff.Diff(ctx, buf, dialect, language, leftArg, rightArg, diffOpts...); err != nil {
+			if errors.Is(err, ddl.ErrNoDifference) {
+				_, _ = fmt.Fprintln(os.Stdout, ddl.ErrNoDifference.Error())
+				return nil
+			}
+			diff.PrintParseError(os.Stderr, err)
+			return apperr.Errorf("diff: %w", err)
+		}
+		ddlStr = buf.String()
+		progress = NewProgress(dialect, leftArg, ddlStr)
 	}
-	ddlStr := buf.String()
 
+	scanner := bufio.NewScanner(os.Stdin)
 	msg := `
 ddlctl will exec the following DDL queries:
 
@@ -68,6 +135,32 @@ Do you want to apply these DDL queries?
   Only 'yes' will be accepted to approve.
 
 Enter a value: `
+	if interactive {
+		if err := selectStatements(ctx, scanner, os.Stdout, progress, findings); err != nil {
+			return apperr.Errorf("selectStatements: %w", err)
+		}
+		printSelection(os.Stdout, progress)
+		if progress.Done() {
+			os.Stdout.WriteString("\nno DDL query is selected\n")
+			return nil
+		}
+		msg = `
+Do you want to apply the selected DDL queries?
+  ddlctl will exec the DDL queries selected above.
+  Only 'yes' will be accepted to approve.
+
+Enter a value: `
+	}
+
+	// MEMO: the shadow database verifies the DDL queries to be applied, which are the selected and edited ones in the interactive mode.
+	if shadowDSN != "" && !config.Resume() {
+		os.Stdout.WriteString("\nverifying on the shadow database...\n")
+		if err := verifyShadow(ctx, newShadowDB(), dialect, language, leftArg, shadowDSN, rightArg, progress.Remaining(), progress.Excluded(),
+			[]ApplyOption{ApplyWithStatementTimeout(config.StatementTimeout())}, verifyOptions(diff.ConfigOptions())...); err != nil {
+			return apperr.Errorf("verifyShadow: %w", err)
+		}
+		os.Stdout.WriteString("verified\n")
+	}
 
 	if _, err := os.Stdout.WriteString(msg); err != nil {
 		return apperr.Errorf("os.Stdout.WriteString: %w", err)
@@ -78,15 +171,46 @@ Enter a value: `
 			return apperr.Errorf("os.Stdout.WriteString: %w", err)
 		}
 	} else {
-		if err := prompt(); err != nil {
+		if err := prompt(scanner); err != nil {
 			return apperr.Errorf("prompt: %w", err)
 		}
 	}
 
 	os.Stdout.WriteString("\nexecuting...\n")
 
-	if err := Apply(ctx, dialect, leftArg, ddlStr); err != nil {
-		return apperr.Errorf("Apply: %w", err)
+	if err := progress.SaveTo(stateFile); err != nil {
+		return apperr.Errorf("progress.SaveTo: %w", err)
+	}
+	applyErr := ApplyProgress(ctx, dialect, leftArg, progress, applyOpts...)
+	os.Stdout.WriteString("\n")
+	progress.Report(os.Stdout)
+	if interactive {
+		// MEMO: the record is appended even if the apply fails, to audit who excluded or edited which DDL queries.
+		if err := appendRecord(config.RecordFile(), progress); err != nil {
+			applyErr = errors.Join(applyErr, apperr.Errorf("appendRecord: %w", err))
+		}
+	}
+	if applyErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "\nthe progress is saved to %s. fix the cause, and run ddlctl apply with --%s to continue from the failed statement.\n", stateFile, consts.OptionResume)
+		return apperr.Errorf("ApplyProgress: %w", applyErr)
+	}
+	if err := os.Remove(stateFile); err != nil {
+		logs.Warn.Printf("os.Remove: %v", err)
+	}
+
+	os.Stdout.WriteString("\nverifying...\n")
+
+	remaining, err := Remaining(ctx, dialect, language, leftArg, rightArg, verifyOptions(diff.ConfigOptions())...)
+	if err != nil {
+		return apperr.Errorf("Remaining: %w", err)
+	}
+	if remaining != "" {
+		if progress.Excluded() {
+			_, _ = fmt.Fprintf(os.Stdout, "the following DDL remains, because some DDL queries are excluded:\n\n%s\n", remaining)
+			return nil
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "the following DDL remains after applying:\n\n%s\n", remaining)
+		return apperr.Errorf("dsn: %w", apperr.ErrNotConverged)
 	}
 
 	os.Stdout.WriteString("done\n")
@@ -94,8 +218,72 @@ Enter a value: `
 	return nil
 }
 
-//nolint:cyclop,funlen,gocognit,gocyclo
-func Apply(ctx context.Context, dialect, dsn, ddlStr string) error {
+func loadHooksFile(filename string) (*Hooks, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, apperr.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	hooks, err := LoadHooks(f)
+	if err != nil {
+		return nil, apperr.Errorf("LoadHooks: filename=%s: %w", filename, err)
+	}
+	return hooks, nil
+}
+
+// loadProgressToResume loads the progress of the apply on dsn that failed halfway from stateFile.
+func loadProgressToResume(stateFile, dialect, dsn string) (*Progress, error) {
+	progress, err := LoadProgress(stateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, apperr.Errorf("state-file=%s: %w", stateFile, apperr.ErrNoApplyToResume)
+		}
+		return nil, apperr.Errorf("LoadProgress: %w", err)
+	}
+	if !progress.Matches(dialect, dsn) {
+		return nil, apperr.Errorf("state-file=%s: %w", stateFile, apperr.ErrApplyStateMismatch)
+	}
+	if progress.Done() {
+		return nil, apperr.Errorf("state-file=%s: all statements are done: %w", stateFile, apperr.ErrNoApplyToResume)
+	}
+	return progress, nil
+}
+
+// checkUnfinished returns apperr.ErrUnfinishedApply if stateFile has the progress of an apply that failed halfway,
+// because diffing again would hide which statements failed.
+func checkUnfinished(stateFile string) error {
+	progress, err := LoadProgress(stateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return apperr.Errorf("LoadProgress: %w", err)
+	}
+	if progress.Done() {
+		return nil
+	}
+	return apperr.Errorf("state-file=%s: run with --%s, or remove it to diff again: %w", stateFile, consts.OptionResume, apperr.ErrUnfinishedApply)
+}
+
+// Apply applies the DDL queries in ddlStr to dsn.
+func Apply(ctx context.Context, dialect, dsn, ddlStr string, opts ...ApplyOption) error {
+	if err := ApplyProgress(ctx, dialect, dsn, NewProgress(dialect, dsn, ddlStr), opts...); err != nil {
+		return apperr.Errorf("ApplyProgress: %w", err)
+	}
+	return nil
+}
+
+// ApplyDB applies the DDL queries in ddlStr to db, which is opened by the caller.
+// It does not take the lock of Lock, and the shell command hooks get no DSN.
+func ApplyDB(ctx context.Context, dialect string, db *sql.DB, ddlStr string, opts ...ApplyOption) error {
+	if err := applyProgress(ctx, db, dialect, "", NewProgress(dialect, "", ddlStr), newApplyConfig(opts)); err != nil {
+		return apperr.Errorf("applyProgress: %w", err)
+	}
+	return nil
+}
+
+// ApplyProgress applies the statements of progress that have not succeeded, been skipped or been excluded to dsn, and updates the status of each statement.
+func ApplyProgress(ctx context.Context, dialect, dsn string, progress *Progress, opts ...ApplyOption) (err error) {
 	driverName := func() string {
 		switch dialect {
 		case ddlcrdb.Dialect:
@@ -117,12 +305,92 @@ func Apply(ctx context.Context, dialect, dsn, ddlStr string) error {
 		}
 	}()
 
+	if err := applyProgress(ctx, db, dialect, dsn, progress, newApplyConfig(opts)); err != nil {
+		return apperr.Errorf("applyProgress: %w", err)
+	}
+	return nil
+}
+
+//nolint:cyclop,funlen,gocognit,gocyclo
+func applyProgress(ctx context.Context, db *sql.DB, dialect, dsn string, progress *Progress, cfg *ApplyConfig) (err error) {
+	// MEMO: the timeouts are set to the session, so all the DDL queries must run on the same connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return apperr.Errorf("db.Conn: %w", err)
+	}
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = apperr.Errorf("conn.Close: %w", err2)
+		}
+	}()
+
+	if err := setupSession(ctx, conn, dialect, cfg); err != nil {
+		return apperr.Errorf("setupSession: %w", err)
+	}
+
+	hooks := cfg.Hooks
+	if hooks == nil {
+		hooks = new(Hooks)
+	}
+	run := &hookRunner{conn: conn, dialect: dialect, dsn: dsn, progress: progress, hooks: hooks}
+
+	if err := run.apply(ctx, HookPreApply); err != nil {
+		return apperr.Errorf("run.apply: %w", err)
+	}
+
+	// MEMO: the DDL queries are executed in the ranges split by the table hooks,
+	// so that the pre hooks of a table run before its first DDL query, and the post hooks after its last one.
+	tables := run.tables()
+	from := 0
+	for i := range progress.Statements {
+		if t := tables[i]; t != nil && t.first == i {
+			if err := execRange(ctx, conn, dialect, cfg, progress, from, i); err != nil {
+				return apperr.Errorf("execRange: %w", err)
+			}
+			from = i
+			if err := run.table(ctx, HookPreTable, t); err != nil {
+				return apperr.Errorf("run.table: %w", err)
+			}
+		}
+		if t := tables[i]; t != nil && t.last == i {
+			if err := execRange(ctx, conn, dialect, cfg, progress, from, i+1); err != nil {
+				return apperr.Errorf("execRange: %w", err)
+			}
+			from = i + 1
+			if err := run.table(ctx, HookPostTable, t); err != nil {
+				return apperr.Errorf("run.table: %w", err)
+			}
+		}
+	}
+	if err := execRange(ctx, conn, dialect, cfg, progress, from, len(progress.Statements)); err != nil {
+		return apperr.Errorf("execRange: %w", err)
+	}
+
+	if err := run.apply(ctx, HookPostApply); err != nil {
+		return apperr.Errorf("run.apply: %w", err)
+	}
+
+	return nil
+}
+
+// execRange executes the statements of progress from index from to index to, exclusive.
+//
+//nolint:funlen
+func execRange(ctx context.Context, conn *sql.Conn, dialect string, cfg *ApplyConfig, progress *Progress, from, to int) error {
+	if from >= to {
+		return nil
+	}
+
 	switch dialect {
 	case ddlcrdb.Dialect:
 		if err := splitExec(
 			ctx,
-			db,
-			ddlStr,
+			conn,
+			progress,
+			from,
+			to,
+			0, // MEMO: statement_timeout is set to the session.
+			cfg.Logger,
 			func(err error) bool { return errorz.Contains(err, "already exists") },
 			func(_ error) bool { return false }, // TODO: handle error
 		); err != nil {
@@ -131,8 +399,12 @@ func Apply(ctx context.Context, dialect, dsn, ddlStr string) error {
 	case ddlmysql.Dialect:
 		if err := splitExec(
 			ctx,
-			db,
-			ddlStr,
+			conn,
+			progress,
+			from,
+			to,
+			cfg.StatementTimeout,
+			cfg.Logger,
 			func(err error) bool {
 				return errorz.Contains(err, "already exists") || errorz.Contains(err, "Duplicate column name")
 			},
@@ -143,8 +415,12 @@ func Apply(ctx context.Context, dialect, dsn, ddlStr string) error {
 	case ddlpostgres.Dialect:
 		if err := splitExec(
 			ctx,
-			db,
-			ddlStr,
+			&postgresExecer{db: conn},
+			progress,
+			from,
+			to,
+			0, // MEMO: statement_timeout is set to the session.
+			cfg.Logger,
 			func(err error) bool {
 				return errorz.Contains(err, "already exists") || errorz.Contains(err, "does not exist")
 			},
@@ -152,77 +428,35 @@ func Apply(ctx context.Context, dialect, dsn, ddlStr string) error {
 		); err != nil {
 			return apperr.Errorf("splitExec: %w", err)
 		}
-	case ddlspanner.Dialect:
-		conn, err := db.Conn(ctx)
-		if err != nil {
-			return apperr.Errorf("db.Conn: %w", err)
-		}
-		defer func() {
-			if err2 := conn.Close(); err == nil && err2 != nil {
-				err = apperr.Errorf("conn.Close: %w", err2)
-			}
-		}()
-		{
-			q := "START BATCH DDL"
-			if _, err := conn.ExecContext(ctx, q); err != nil {
-				return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
-			}
-		}
-		commentTrimmedDDL := readLine(ddlStr, "\n", readLineFuncRemoveCommentLine("--"))
-		for _, q := range strings.Split(commentTrimmedDDL, ";\n") {
-			if len(q) == 0 {
-				// skip empty query
-				continue
-			}
-			if _, err := conn.ExecContext(ctx, q); err != nil {
-				return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
-			}
-		}
-
-		{
-			q := "RUN BATCH"
-			if _, err := conn.ExecContext(ctx, q); err != nil {
-				return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
-			}
-		}
 	default:
-		if _, err := db.ExecContext(ctx, ddlStr); err != nil {
-			return apperr.Errorf("db.ExecContext: q=%s: %w", ddlStr, err)
+		if err := execBatch(ctx, conn, progress, from, to, cfg.StatementTimeout); err != nil {
+			return apperr.Errorf("execBatch: %w", err)
 		}
 	}
 
 	return nil
 }
 
-func readLine(content string, lineSeparator string, f func(line string, lineSeparator string, lastLine bool) (treated string)) string {
-	var result string
-	lines := strings.Split(content, lineSeparator)
-	lastLine := len(lines) - 1
-	for i, line := range lines {
-		treated := f(line, lineSeparator, i == lastLine)
-		result += treated
-	}
-	return result
+// verifyOptions returns opts, which are the options of the diff to apply, for the diff to verify the result of the apply.
+// MEMO: the safety check is off, because the remaining DDL is to be shown as it is instead of ErrUnsafeMigration.
+func verifyOptions(opts []diff.DiffOption) []diff.DiffOption {
+	return append(slices.Clip(opts), diff.DiffWithCheckSafety(false))
 }
 
-func readLineFuncRemoveCommentLine(commentPrefix string) func(line string, lineSeparator string, lastLine bool) (treated string) {
-	return func(line string, lineSeparator string, lastLine bool) (treated string) {
-		trimmed := strings.TrimSpace(line)
-
-		if lastLine && trimmed == "" {
-			return ""
-		}
-
-		if strings.HasPrefix(trimmed, commentPrefix) {
-			return ""
+// Remaining returns the DDL that remains from dsn to src, or empty if dsn has converged to src.
+// It is used after applying, because splitExec does not fail on the errors such as "already exists".
+func Remaining(ctx context.Context, dialect, language, dsn, src string, opts ...diff.DiffOption) (string, error) {
+	remaining := new(strings.Builder)
+	if err := diff.Diff(ctx, remaining, dialect, language, dsn, src, opts...); err != nil {
+		if errors.Is(err, ddl.ErrNoDifference) {
+			return "", nil
 		}
-
-		return line + lineSeparator
+		return "", apperr.Errorf("diff.Diff: %w", err)
 	}
+	return remaining.String(), nil
 }
 
-func prompt() error {
-	scanner := bufio.NewScanner(os.Stdin)
+func prompt(scanner *bufio.Scanner) error {
 	scanner.Scan()
 
 	switch input := scanner.Text(); input {
@@ -233,41 +467,55 @@ func prompt() error {
 	}
 }
 
+const (
+	// splitExecInitialInterval and splitExecMaxInterval are the backoff to execute the failed DDL queries again,
+	// which may succeed after the others, such as FOREIGN KEY to a table created later.
+	splitExecInitialInterval = 500 * time.Millisecond
+	splitExecMaxInterval     = 5 * time.Second
+	// lockRetryInitialInterval and lockRetryMaxInterval are the backoff to retry a DDL query that timed out waiting for the lock of the table.
+	lockRetryInitialInterval = 1 * time.Second
+	lockRetryMaxInterval     = 30 * time.Second
+	lockRetryMaxRetries      = 10
+)
+
 func splitExec(
 	ctx context.Context,
-	db interface {
-		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
-	},
-	ddlStr string,
+	db execer,
+	progress *Progress,
+	from, to int,
+	statementTimeout time.Duration,
+	logger logs.Logger,
 	notErrorNotLogFunc func(err error) bool,
 	errorNotLogFunc func(err error) bool,
 ) error {
-	ddls := strings.Split(util.RemoveCommentsAndEmptyLines("--", ddlStr), ";\n")
-	const interval = 500 * time.Millisecond
-	retryer := retry.New(ctx, retry.NewConfig(interval, interval, retry.WithMaxRetries(len(ddls))))
+	retryer := retry.New(ctx, retry.NewConfig(splitExecInitialInterval, splitExecMaxInterval, retry.WithMaxRetries(to-from)))
 	if err := retryer.Do(func(ctx context.Context) error {
 		var outerErr error
-		for _, q := range ddls {
-			if len(q) == 0 {
-				// skip empty query
+		for i := from; i < to; i++ {
+			stmt := progress.Statements[i]
+			if stmt.Status.done() {
 				continue
 			}
-			if _, err := db.ExecContext(ctx, q); err != nil {
+			if err := execRetryLockTimeout(ctx, db, stmt.Query, statementTimeout, logger); err != nil {
 				// If the error is one of the following, do not error and not log. go to the next DDL;
 				if notErrorNotLogFunc(err) {
+					progress.set(i, StatementSkipped, err)
 					continue
 				}
 
-				err = apperr.Errorf("db.ExecContext: q=%s: %w", q, err)
+				err = apperr.Errorf("execRetryLockTimeout: %w", err)
 				outerErr = err
+				progress.set(i, StatementFailed, err)
 				// If the error is one of the following, error but not log. go to the next DDL;
 				if errorNotLogFunc(err) {
 					continue
 				}
 
 				// If the error is not one of the above, error and log. go to the next DDL;
-				logs.Warn.Printf(err.Error())
+				logger.Printf(err.Error())
+				continue
 			}
+			progress.set(i, StatementSucceeded, nil)
 		}
 		if outerErr != nil {
 			return outerErr
@@ -279,3 +527,142 @@ func splitExec(
 
 	return nil
 }
+
+// execBatch executes the statements of progress from index from to index to in a DDL batch of spanner, which is much faster than executing them one by one.
+// statementTimeout is the deadline of the whole batch if positive.
+//
+// A batch that fails halfway leaves the statements before the failed one committed, but the error does not tell which one failed.
+// So the schema before the batch is compared with the schema after it, and the statements as many as the difference are marked as succeeded.
+func execBatch(ctx context.Context, conn *sql.Conn, progress *Progress, from, to int, statementTimeout time.Duration) error {
+	indexes := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		if !progress.Statements[i].Status.done() {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	before, err := spanshow.ShowCreateAllTables(ctx, conn)
+	if err != nil {
+		return apperr.Errorf("spanshow.ShowCreateAllTables: %w", err)
+	}
+
+	batchErr := runBatch(ctx, conn, progress, indexes, statementTimeout)
+	if batchErr == nil {
+		for _, i := range indexes {
+			progress.set(i, StatementSucceeded, nil)
+		}
+		return nil
+	}
+
+	committed, err := committedStatements(ctx, conn, before, len(indexes))
+	if err != nil {
+		// MEMO: if the schema cannot be compared, all the statements are left to --resume, which fails on the committed ones as "Duplicate name".
+		logs.Warn.Printf("committedStatements: %v", err)
+		committed = 0
+	}
+	for n, i := range indexes[:committed] {
+		logs.Debug.Printf("committed before the failure of the batch: %d. %s", n+1, progress.Statements[i].Query)
+		progress.set(i, StatementSucceeded, nil)
+	}
+	progress.set(indexes[committed], StatementFailed, batchErr)
+	return batchErr
+}
+
+// runBatch executes the statements of progress at indexes in a DDL batch.
+func runBatch(ctx context.Context, conn *sql.Conn, progress *Progress, indexes []int, statementTimeout time.Duration) error {
+	if statementTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, statementTimeout)
+		defer cancel()
+	}
+
+	{
+		q := "START BATCH DDL"
+		if _, err := conn.ExecContext(ctx, q); err != nil {
+			return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
+		}
+	}
+	for _, i := range indexes {
+		q := progress.Statements[i].Query
+		if _, err := conn.ExecContext(ctx, q); err != nil {
+			// MEMO: the batch is aborted, so no statement is committed, and the connection can be used for the hooks.
+			if _, err2 := conn.ExecContext(context.WithoutCancel(ctx), "ABORT BATCH"); err2 != nil {
+				logs.Warn.Printf("conn.ExecContext: q=ABORT BATCH: %v", err2)
+			}
+			return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
+		}
+	}
+	{
+		q := "RUN BATCH"
+		if _, err := conn.ExecContext(ctx, q); err != nil {
+			return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
+		}
+	}
+	return nil
+}
+
+// committedStatements returns how many statements of the failed batch of n statements were committed,
+// which is the number of the DDL queries from before, the schema before the batch, to the schema of conn now.
+// It is less than n, because the batch failed.
+func committedStatements(ctx context.Context, conn *sql.Conn, before string, n int) (int, error) {
+	after, err := spanshow.ShowCreateAllTables(ctx, conn)
+	if err != nil {
+		return 0, apperr.Errorf("spanshow.ShowCreateAllTables: %w", err)
+	}
+	return countDiffStatements(before, after, n)
+}
+
+// countDiffStatements returns the number of the DDL queries from before to after of spanner, up to n-1.
+func countDiffStatements(before, after string, n int) (int, error) {
+	buf := new(strings.Builder)
+	if err := diff.DiffDDL(buf, ddlspanner.Dialect, before, after, diff.DiffWithWarnings(io.Discard)); err != nil {
+		if errors.Is(err, ddl.ErrNoDifference) {
+			return 0, nil
+		}
+		return 0, apperr.Errorf("diff.DiffDDL: %w", err)
+	}
+	return min(len(NewProgress(ddlspanner.Dialect, "", buf.String()).Statements), n-1), nil
+}
+
+func execWithTimeout(ctx context.Context, db execer, q string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if _, err := db.ExecContext(ctx, q); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
+
+type execer = interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// execRetryLockTimeout executes q, and retries it with backoff only if it times out waiting for the lock of the table.
+// statementTimeout is the deadline of each execution if positive.
+func execRetryLockTimeout(ctx context.Context, db execer, q string, statementTimeout time.Duration, logger logs.Logger) error {
+	var execErr error
+	retryer := retry.New(ctx, retry.NewConfig(lockRetryInitialInterval, lockRetryMaxInterval, retry.WithMaxRetries(lockRetryMaxRetries)))
+	if err := retryer.Do(func(ctx context.Context) error {
+		execErr = nil
+		if err := execWithTimeout(ctx, db, q, statementTimeout); err != nil {
+			if isLockTimeout(err) {
+				logger.Printf("lock timeout, retrying: q=%s: %v", q, err)
+				return err //nolint:wrapcheck
+			}
+			execErr = err
+		}
+		return nil
+	}); err != nil {
+		return apperr.Errorf("db.ExecContext: q=%s: retry.Do: %w", q, err)
+	}
+	if execErr != nil {
+		return apperr.Errorf("db.ExecContext: q=%s: %w", q, execErr)
+	}
+	return nil
+}
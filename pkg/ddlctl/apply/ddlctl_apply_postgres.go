@@ -0,0 +1,99 @@
+package apply
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+// postgresExecer executes each DDL query on its own, outside any transaction block,
+// because CREATE INDEX CONCURRENTLY and DROP INDEX CONCURRENTLY cannot run inside one.
+//
+// If CREATE INDEX CONCURRENTLY fails, PostgreSQL leaves the index behind marked as INVALID.
+// Since splitExec ignores "already exists", the retry would silently keep the INVALID index,
+// so postgresExecer drops it before and after the build.
+type postgresExecer struct {
+	db postgresQueryer
+}
+
+type postgresQueryer = interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (e *postgresExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	createIndexStmt := parseCreateIndexConcurrently(query)
+	if createIndexStmt != nil {
+		if err := dropInvalidIndex(ctx, e.db, indexName(createIndexStmt)); err != nil {
+			return nil, apperr.Errorf("dropInvalidIndex: %w", err)
+		}
+	}
+
+	result, err := e.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if createIndexStmt != nil {
+			if err := dropInvalidIndex(ctx, e.db, indexName(createIndexStmt)); err != nil {
+				logs.Warn.Printf("dropInvalidIndex: %v", err)
+			}
+		}
+		return nil, err //nolint:wrapcheck
+	}
+
+	return result, nil
+}
+
+func parseCreateIndexConcurrently(query string) *ddlpostgres.CreateIndexStmt {
+	d, err := ddlpostgres.NewParser(ddlpostgres.NewLexer(query)).Parse()
+	if err != nil || len(d.Stmts) != 1 {
+		return nil
+	}
+
+	stmt, ok := d.Stmts[0].(*ddlpostgres.CreateIndexStmt)
+	if !ok || !stmt.Concurrently {
+		return nil
+	}
+
+	return stmt
+}
+
+// indexName returns the name of the index of stmt qualified with the schema of the table,
+// because the index is created in the schema of the table, which may be outside search_path.
+func indexName(stmt *ddlpostgres.CreateIndexStmt) *ddlpostgres.Ident {
+	if stmt.TableName == nil || stmt.TableName.Schema == nil {
+		return stmt.Name
+	}
+	raw := stmt.TableName.Schema.String() + "." + stmt.Name.String()
+	return ddlpostgres.NewIdent(stmt.TableName.Schema.StringForDiff()+"."+stmt.Name.StringForDiff(), "", raw)
+}
+
+func dropInvalidIndex(ctx context.Context, db postgresQueryer, name *ddlpostgres.Ident) error {
+	const q = `SELECT NOT i.indisvalid FROM pg_index i WHERE i.indexrelid = to_regclass($1)`
+
+	var invalid bool
+	if err := db.QueryRowContext(ctx, q, name.String()).Scan(&invalid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return apperr.Errorf("db.QueryRowContext: q=%s: %w", q, err)
+	}
+
+	if !invalid {
+		return nil
+	}
+
+	dropIndexStmt := &ddlpostgres.DropIndexStmt{
+		Concurrently: true,
+		IfExists:     true,
+		Name:         name,
+	}
+	logs.Warn.Printf("drop INVALID index left by a failed concurrent build: %s", name)
+	if _, err := db.ExecContext(ctx, dropIndexStmt.String()); err != nil {
+		return apperr.Errorf("db.ExecContext: q=%s: %w", dropIndexStmt, err)
+	}
+
+	return nil
+}
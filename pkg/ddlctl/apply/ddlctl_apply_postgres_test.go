@@ -0,0 +1,33 @@
+//nolint:testpackage
+package apply
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+)
+
+func TestIndexName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "no_schema", query: "CREATE INDEX CONCURRENTLY users_idx_name ON users (name)", expected: "users_idx_name"},
+		{name: "schema", query: "CREATE INDEX CONCURRENTLY users_idx_name ON app.users (name)", expected: "app.users_idx_name"},
+		{name: "quoted", query: `CREATE UNIQUE INDEX CONCURRENTLY "Users_idx_name" ON app.users (name)`, expected: `app."Users_idx_name"`},
+	}
+
+	for _, tt := range tests {
+		t.Run("success,"+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stmt := parseCreateIndexConcurrently(tt.query)
+			require.Equal(t, true, stmt != nil)
+			assert.Equal(t, tt.expected, indexName(stmt).String())
+		})
+	}
+}
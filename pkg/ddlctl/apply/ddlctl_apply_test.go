@@ -0,0 +1,163 @@
+//nolint:testpackage
+package apply
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
+)
+
+func TestRemaining(t *testing.T) {
+	t.Parallel()
+
+	writeFile := func(t *testing.T, name, content string) string {
+		t.Helper()
+		filename := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+		return filename
+	}
+
+	// MEMO: the DDL of src, and the schema after each phase of the apply from the old one, which a file stands for instead of a database.
+	src := writeFile(t, "src.sql", `CREATE TABLE users (id INTEGER NOT NULL, email TEXT, PRIMARY KEY (id));
+CREATE TABLE groups (id INTEGER NOT NULL, name TEXT NOT NULL, PRIMARY KEY (id));
+`)
+	expanded := writeFile(t, "expanded.sql", `CREATE TABLE users (id INTEGER NOT NULL, name TEXT, email TEXT, PRIMARY KEY (id));
+CREATE TABLE groups (id INTEGER NOT NULL, PRIMARY KEY (id));
+`)
+
+	t.Run("success,ddl.ErrNoDifference", func(t *testing.T) {
+		t.Parallel()
+
+		remaining, err := Remaining(context.Background(), ddlpostgres.Dialect, "", src, src)
+		require.NoError(t, err)
+		assert.Equal(t, "", remaining)
+	})
+
+	t.Run("success,remaining", func(t *testing.T) {
+		t.Parallel()
+
+		remaining, err := Remaining(context.Background(), ddlpostgres.Dialect, "", expanded, src)
+		require.NoError(t, err)
+		assert.Equal(t, `-- -name TEXT
+-- +
+ALTER TABLE users DROP COLUMN name;
+-- -
+-- +name TEXT NOT NULL
+ALTER TABLE groups ADD COLUMN name TEXT NOT NULL;
+`, remaining)
+	})
+
+	t.Run("success,phase", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the expand phase has converged, even though the contract phase remains.
+		remaining, err := Remaining(context.Background(), ddlpostgres.Dialect, "", writeFile(t, "expand.sql", `CREATE TABLE users (id INTEGER NOT NULL, name TEXT, email TEXT, PRIMARY KEY (id));
+CREATE TABLE groups (id INTEGER NOT NULL, name TEXT, PRIMARY KEY (id));
+`), src, diff.DiffWithPhase("expand"))
+		require.NoError(t, err)
+		assert.Equal(t, "", remaining)
+
+		remaining, err = Remaining(context.Background(), ddlpostgres.Dialect, "", expanded, src, diff.DiffWithPhase("expand"))
+		require.NoError(t, err)
+		assert.Equal(t, `-- -
+-- +name TEXT NOT NULL
+ALTER TABLE groups ADD COLUMN name TEXT;
+`, remaining)
+	})
+
+	t.Run("success,target", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the tables other than the targets are not verified.
+		remaining, err := Remaining(context.Background(), ddlpostgres.Dialect, "", expanded, src, diff.DiffWithTargets([]string{"groups"}))
+		require.NoError(t, err)
+		assert.Equal(t, `-- -
+-- +name TEXT NOT NULL
+ALTER TABLE groups ADD COLUMN name TEXT NOT NULL;
+`, remaining)
+
+		remaining, err = Remaining(context.Background(), ddlpostgres.Dialect, "", writeFile(t, "groups.sql", `CREATE TABLE users (id INTEGER NOT NULL, name TEXT, email TEXT, PRIMARY KEY (id));
+CREATE TABLE groups (id INTEGER NOT NULL, name TEXT NOT NULL, PRIMARY KEY (id));
+`), src, diff.DiffWithTargets([]string{"groups"}))
+		require.NoError(t, err)
+		assert.Equal(t, "", remaining)
+	})
+
+	t.Run("success,verifyOptions", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the remaining SET DATA TYPE is an error of the safety check, which would hide the DDL with ErrUnsafeMigration.
+		retyped := writeFile(t, "retyped.sql", `CREATE TABLE users (id INTEGER NOT NULL, email TEXT, PRIMARY KEY (id));
+CREATE TABLE groups (id INTEGER NOT NULL, name INTEGER NOT NULL, PRIMARY KEY (id));
+`)
+		opts := []diff.DiffOption{diff.DiffWithCheckSafety(true), diff.DiffWithWarnings(io.Discard)}
+		_, err := Remaining(context.Background(), ddlpostgres.Dialect, "", retyped, src, opts...)
+		require.ErrorIs(t, err, apperr.ErrUnsafeMigration)
+
+		remaining, err := Remaining(context.Background(), ddlpostgres.Dialect, "", retyped, src, verifyOptions(opts)...)
+		require.NoError(t, err)
+		assert.Equal(t, `-- -name INTEGER NOT NULL
+-- +name TEXT NOT NULL
+ALTER TABLE groups ALTER COLUMN name SET DATA TYPE TEXT;
+`, remaining)
+	})
+
+	t.Run("failure,Resolve", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Remaining(context.Background(), ddlpostgres.Dialect, "", filepath.Join(t.TempDir(), "not_found.sql"), src)
+		require.Error(t, err)
+	})
+}
+
+func TestCountDiffStatements(t *testing.T) {
+	t.Parallel()
+
+	before := "CREATE TABLE users (id STRING(36) NOT NULL) PRIMARY KEY (id);\n"
+	// MEMO: the first 2 statements of the batch below were committed, and the last one failed.
+	//   ALTER TABLE users ADD COLUMN name STRING(255);
+	//   CREATE TABLE groups (id STRING(36) NOT NULL) PRIMARY KEY (id);
+	//   CREATE INDEX users_idx_name ON users (name);
+	after := "CREATE TABLE users (id STRING(36) NOT NULL, name STRING(255)) PRIMARY KEY (id);\nCREATE TABLE groups (id STRING(36) NOT NULL) PRIMARY KEY (id);\n"
+
+	t.Run("success,committed", func(t *testing.T) {
+		t.Parallel()
+
+		n, err := countDiffStatements(before, after, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("success,ddl.ErrNoDifference", func(t *testing.T) {
+		t.Parallel()
+
+		n, err := countDiffStatements(before, before, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("success,less_than_n", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the failed statement is not committed, even if the schema has more difference, such as by another client.
+		n, err := countDiffStatements(before, after, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("failure,diff.DiffDDL", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := countDiffStatements(before, "CREATE TABLE", 3)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,374 @@
+package apply
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+	"github.com/hakadoriya/ddlctl/pkg/internal/redact"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+// HookEvent is the point of apply where a hook runs.
+type HookEvent string
+
+const (
+	HookPreApply  HookEvent = "pre_apply"
+	HookPostApply HookEvent = "post_apply"
+	HookPreTable  HookEvent = "pre_table"
+	HookPostTable HookEvent = "post_table"
+)
+
+// Hooks is the hooks run around apply, such as a data backfill between the DDL queries or ANALYZE after them.
+// A failing hook aborts the apply, and the progress is kept to resume it.
+//
+//nolint:tagliatelle
+type Hooks struct {
+	// PreApply runs before the first DDL query, and PostApply runs after all the DDL queries have succeeded.
+	PreApply  []*Hook `json:"pre_apply,omitempty"`
+	PostApply []*Hook `json:"post_apply,omitempty"`
+	// Tables is the hooks of the tables matched by the keys, which are the patterns of --target such as users or order_*.
+	Tables map[string]*TableHooks `json:"tables,omitempty"`
+	// RawDSN passes the DSN with the credentials to the shell commands as DDLCTL_HOOK_RAW_DSN.
+	// It is off by default, because the commands and their logs can leak it.
+	RawDSN bool `json:"raw_dsn,omitempty"`
+}
+
+// TableHooks is the hooks of a table. Pre runs before the first DDL query on the table, and Post runs after the last one.
+type TableHooks struct {
+	Pre  []*Hook `json:"pre,omitempty"`
+	Post []*Hook `json:"post,omitempty"`
+}
+
+// Hook is a SQL file executed on the DSN to apply, or a shell command.
+type Hook struct {
+	SQL     string `json:"sql,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+func (h *Hook) String() string {
+	if h.SQL != "" {
+		return "sql: " + h.SQL
+	}
+	return "command: " + h.Command
+}
+
+// ApplyWithHooks runs hooks around the DDL queries.
+func ApplyWithHooks(hooks *Hooks) ApplyOption { //nolint:ireturn
+	return &applyConfigHooks{
+		hooks: hooks,
+	}
+}
+
+type applyConfigHooks struct {
+	hooks *Hooks
+}
+
+func (o *applyConfigHooks) apply(c *ApplyConfig) {
+	c.Hooks = o.hooks
+}
+
+// LoadHooks reads Hooks in JSON from r.
+func LoadHooks(r io.Reader) (*Hooks, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	hooks := new(Hooks)
+	if err := dec.Decode(hooks); err != nil {
+		return nil, apperr.Errorf("json.Decode: %w", err)
+	}
+	if err := hooks.validate(); err != nil {
+		return nil, apperr.Errorf("validate: %w", err)
+	}
+	return hooks, nil
+}
+
+func (h *Hooks) validate() error {
+	validate := func(hooks []*Hook) error {
+		for _, hook := range hooks {
+			if hook == nil || (hook.SQL == "") == (hook.Command == "") {
+				return apperr.Errorf("hook=%v: either sql or command is required: %w", hook, apperr.ErrInvalidHooks)
+			}
+		}
+		return nil
+	}
+
+	if err := validate(slices.Concat(h.PreApply, h.PostApply)); err != nil {
+		return err
+	}
+	for pattern, t := range h.Tables {
+		if _, err := ddl.MatchTarget([]string{pattern}, ""); err != nil {
+			return apperr.Errorf("table=%s: %v: %w", pattern, err, apperr.ErrInvalidHooks)
+		}
+		if t == nil {
+			continue
+		}
+		if err := validate(slices.Concat(t.Pre, t.Post)); err != nil {
+			return apperr.Errorf("table=%s: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// hookRunner runs the hooks of an apply on conn, which is the connection of the DDL queries.
+type hookRunner struct {
+	conn     *sql.Conn
+	dialect  string
+	dsn      string
+	progress *Progress
+	hooks    *Hooks
+}
+
+// tableRange is the pending statements on a table that has the hooks.
+type tableRange struct {
+	table       string
+	hooks       *TableHooks
+	indexes     []int
+	first, last int
+}
+
+// tables returns the tables that have the hooks, keyed by the index of each pending statement on them.
+// A statement is on the table of the first matching pattern in the sorted order.
+func (r *hookRunner) tables() map[int]*tableRange {
+	patterns := make([]string, 0, len(r.hooks.Tables))
+	for pattern, t := range r.hooks.Tables {
+		if t != nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	slices.Sort(patterns)
+
+	ranges := make(map[string]*tableRange)
+	tables := make(map[int]*tableRange)
+	for i, stmt := range r.progress.Statements {
+		if stmt.Status.done() {
+			continue
+		}
+		table := statementTable(r.dialect, stmt.Query)
+		if table == "" {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := ddl.MatchTarget([]string{pattern}, table); !matched {
+				continue
+			}
+			t, ok := ranges[table]
+			if !ok {
+				t = &tableRange{table: table, hooks: r.hooks.Tables[pattern], first: i}
+				ranges[table] = t
+			}
+			t.indexes = append(t.indexes, i)
+			t.last = i
+			tables[i] = t
+			break
+		}
+	}
+	return tables
+}
+
+// apply runs the hooks of the whole apply.
+func (r *hookRunner) apply(ctx context.Context, event HookEvent) error {
+	hooks := r.hooks.PreApply
+	if event == HookPostApply {
+		hooks = r.hooks.PostApply
+	}
+	indexes := make([]int, 0, len(r.progress.Statements))
+	for i, stmt := range r.progress.Statements {
+		if stmt.Status != StatementExcluded {
+			indexes = append(indexes, i)
+		}
+	}
+	return r.run(ctx, event, hooks, "", indexes)
+}
+
+// table runs the hooks of the table of t.
+func (r *hookRunner) table(ctx context.Context, event HookEvent, t *tableRange) error {
+	hooks := t.hooks.Pre
+	if event == HookPostTable {
+		hooks = t.hooks.Post
+	}
+	return r.run(ctx, event, hooks, t.table, t.indexes)
+}
+
+// run runs hooks in order. The shell commands get the planned statements of indexes and the metadata of the DSN as the environment variables.
+func (r *hookRunner) run(ctx context.Context, event HookEvent, hooks []*Hook, table string, indexes []int) (err error) {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	// MEMO: the statements are passed in a file, because they can exceed the limit of the size of the environment variables.
+	statements, err := os.CreateTemp("", "ddlctl_hook_statements_*.sql")
+	if err != nil {
+		return apperr.Errorf("os.CreateTemp: %w", err)
+	}
+	defer func() {
+		if err2 := os.Remove(statements.Name()); err2 != nil && err == nil {
+			err = apperr.Errorf("os.Remove: %w", err2)
+		}
+	}()
+	for _, i := range indexes {
+		if _, err := statements.WriteString(r.progress.Statements[i].Query + ";\n"); err != nil {
+			_ = statements.Close()
+			return apperr.Errorf("statements.WriteString: %w", err)
+		}
+	}
+	if err := statements.Close(); err != nil {
+		return apperr.Errorf("statements.Close: %w", err)
+	}
+
+	host, database := dsnHostDatabase(r.dialect, r.dsn)
+	env := append(os.Environ(),
+		"DDLCTL_HOOK_EVENT="+string(event),
+		"DDLCTL_HOOK_DIALECT="+r.dialect,
+		"DDLCTL_HOOK_DSN="+redact.String(r.dsn),
+		"DDLCTL_HOOK_HOST="+host,
+		"DDLCTL_HOOK_DATABASE="+database,
+		"DDLCTL_HOOK_TABLE="+table,
+		"DDLCTL_HOOK_STATEMENTS_FILE="+statements.Name(),
+	)
+	if r.hooks.RawDSN {
+		env = append(env, "DDLCTL_HOOK_RAW_DSN="+r.dsn)
+	}
+
+	for _, hook := range hooks {
+		_, _ = fmt.Fprintf(os.Stdout, "running %s hook: %s\n", event, hook)
+		if hook.SQL != "" {
+			b, err := os.ReadFile(hook.SQL)
+			if err != nil {
+				return apperr.Errorf("os.ReadFile: %w", err)
+			}
+			// MEMO: the hook is split into the queries in the same way as the DDL, because the drivers may not support the multiple statements.
+			for _, stmt := range NewProgress(r.dialect, r.dsn, string(b)).Statements {
+				if _, err := r.conn.ExecContext(ctx, stmt.Query); err != nil {
+					return apperr.Errorf("event=%s: hook=%s: q=%s: %v: %w", event, hook, stmt.Query, err, apperr.ErrHookFailed)
+				}
+			}
+			continue
+		}
+
+		cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command) //nolint:gosec
+		cmd.Env = env
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			return apperr.Errorf("event=%s: hook=%s: %v: %w", event, hook, err, apperr.ErrHookFailed)
+		}
+	}
+	return nil
+}
+
+// dsnHostDatabase returns the host and the database of dsn of dialect, or empty if they cannot be found.
+// The host of spanner is the endpoint such as of the emulator, which is usually empty.
+//
+//nolint:cyclop
+func dsnHostDatabase(dialect, dsn string) (host, database string) {
+	switch dialect {
+	case ddlspanner.Dialect:
+		// MEMO: [host:port/]projects/PROJECT/instances/INSTANCE/databases/DATABASE[;key=value...]
+		dsn, _, _ = strings.Cut(dsn, ";")
+		endpoint, path, ok := strings.Cut(dsn, "projects/")
+		if !ok {
+			return "", ""
+		}
+		_, database, _ = strings.Cut(path, "/databases/")
+		return strings.TrimSuffix(endpoint, "/"), database
+	case ddlmysql.Dialect:
+		// MEMO: [user[:password]@][net[(addr)]]/dbname[?param1=value1&...], whose password may have @ and /.
+		i := strings.LastIndex(dsn, "/")
+		if i < 0 {
+			return "", ""
+		}
+		database, _, _ = strings.Cut(dsn[i+1:], "?")
+		addr := dsn[:i]
+		if j := strings.LastIndex(addr, "@"); j >= 0 {
+			addr = addr[j+1:]
+		}
+		if open := strings.Index(addr, "("); open >= 0 && strings.HasSuffix(addr, ")") {
+			addr = addr[open+1 : len(addr)-1]
+		}
+		return addr, database
+	default:
+		if strings.Contains(dsn, "://") {
+			u, err := url.Parse(dsn)
+			if err != nil {
+				return "", ""
+			}
+			return u.Host, strings.TrimPrefix(u.Path, "/")
+		}
+		// MEMO: key/value such as host=localhost port=5432 dbname=postgres
+		var port string
+		for _, kv := range strings.Fields(dsn) {
+			k, v, _ := strings.Cut(kv, "=")
+			v = strings.Trim(v, "'")
+			switch k {
+			case "host":
+				host = v
+			case "port":
+				port = v
+			case "dbname":
+				database = v
+			}
+		}
+		if host != "" && port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+		return host, database
+	}
+}
+
+// statementTable returns the name of the table that query is on, or empty if query cannot be parsed.
+func statementTable(dialect, query string) string {
+	query += ";\n"
+	var (
+		table string
+		err   error
+	)
+	switch dialect {
+	case ddlcrdb.Dialect:
+		var d *ddlcrdb.DDL
+		if d, err = ddlcrdb.NewParser(ddlcrdb.NewLexer(query)).Parse(); err == nil && len(d.Stmts) > 0 {
+			table = ddlcrdb.TargetTable(d.Stmts[0])
+		}
+	case ddlmysql.Dialect:
+		var d *ddlmysql.DDL
+		if d, err = ddlmysql.NewParser(ddlmysql.NewLexer(query)).Parse(); err == nil && len(d.Stmts) > 0 {
+			table = ddlmysql.TargetTable(d.Stmts[0])
+		}
+	case ddlpostgres.Dialect:
+		var d *ddlpostgres.DDL
+		if d, err = ddlpostgres.NewParser(ddlpostgres.NewLexer(query)).Parse(); err == nil && len(d.Stmts) > 0 {
+			table = ddlpostgres.TargetTable(d.Stmts[0])
+		}
+	case ddlspanner.Dialect:
+		var d *ddlspanner.DDL
+		if d, err = ddlspanner.NewParser(ddlspanner.NewLexer(query)).Parse(); err == nil && len(d.Stmts) > 0 {
+			table = ddlspanner.TargetTable(d.Stmts[0])
+		}
+	}
+	if err != nil {
+		logs.Debug.Printf("statementTable: query=%q: %v", query, err)
+	}
+	if table == "" {
+		// MEMO: the parsers are for the schema, so ALTER TABLE and DROP are found by the syntax instead.
+		if m := statementTableRegex.FindStringSubmatch(query); m != nil {
+			table = strings.NewReplacer(`"`, "", "`", "").Replace(m[1])
+		}
+	}
+	return table
+}
+
+// statementTableRegex matches the table of ALTER TABLE, DROP TABLE, and CREATE INDEX or DROP INDEX with ON.
+var statementTableRegex = regexp.MustCompile(`(?is)^\s*(?:ALTER\s+TABLE(?:\s+IF\s+EXISTS)?(?:\s+ONLY)?|DROP\s+TABLE(?:\s+IF\s+EXISTS)?|(?:CREATE|DROP)\s+[^;]*?\bINDEX\b[^;]*?\s+ON(?:\s+ONLY)?)\s+([^\s(;,]+)`)
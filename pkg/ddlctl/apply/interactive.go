@@ -0,0 +1,195 @@
+package apply
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddllint "github.com/hakadoriya/ddlctl/pkg/lint"
+)
+
+// isTerminal reports whether f is a terminal, where the operator can answer the interactive prompts.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// selectStatements asks the operator to accept, skip or edit each pending statement of progress, showing the risks in findings.
+// findings is nil if the safety has not been checked, such as on --resume, and the risk is shown as unknown.
+//
+//nolint:cyclop
+func selectStatements(ctx context.Context, scanner *bufio.Scanner, out io.Writer, progress *Progress, findings []*ddllint.SafetyFinding) error {
+	pending := make([]int, 0, len(progress.Statements))
+	for i, stmt := range progress.Statements {
+		if !stmt.Status.done() {
+			pending = append(pending, i)
+		}
+	}
+
+	for n, i := range pending {
+		stmt := progress.Statements[i]
+	ask:
+		for {
+			stmtFindings := findings
+			if stmt.OriginalQuery != "" {
+				// MEMO: the edited query has not been checked.
+				stmtFindings = nil
+			}
+			_, _ = fmt.Fprintf(out, "\n[%d/%d] risk: %s\n%s;\n", n+1, len(pending), riskLabel(stmtFindings, stmt.Query), stmt.Query)
+			for _, f := range statementFindings(stmtFindings, stmt.Query) {
+				_, _ = fmt.Fprintf(out, "    %s: %s (%s)\n", f.Severity, f.Message, f.RuleID)
+			}
+			_, _ = io.WriteString(out, "Apply this DDL query? [y]es, [s]kip, [e]dit, [q]uit: ")
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return apperr.Errorf("scanner.Scan: %w", err)
+				}
+				return apperr.Errorf("stdin closed: %w", apperr.ErrCanceled)
+			}
+
+			switch input := strings.TrimSpace(scanner.Text()); input {
+			case "y", "yes":
+				break ask
+			case "s", "skip":
+				stmt.Status = StatementExcluded
+				break ask
+			case "e", "edit":
+				edited, err := editQuery(ctx, progress.Dialect, stmt.Query)
+				if err != nil {
+					return apperr.Errorf("editQuery: %w", err)
+				}
+				if edited == "" || edited == stmt.Query {
+					continue
+				}
+				if stmt.OriginalQuery == "" {
+					stmt.OriginalQuery = stmt.Query
+				}
+				stmt.Query = edited
+				// MEMO: ask again, to show the edited query and accept it explicitly.
+			case "q", "quit":
+				return apperr.Errorf("input=%q: %w", input, apperr.ErrCanceled)
+			default:
+				_, _ = fmt.Fprintf(out, "unknown input: %q\n", input)
+			}
+		}
+	}
+
+	return nil
+}
+
+// editQuery opens query in $VISUAL or $EDITOR, and returns the edited query of dialect without the comments and the trailing semicolon.
+func editQuery(ctx context.Context, dialect, query string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := os.CreateTemp("", "ddlctl-*.sql")
+	if err != nil {
+		return "", apperr.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("-- edit the DDL query, and save and quit the editor. empty to cancel the edit.\n" + query + ";\n"); err != nil {
+		_ = f.Close()
+		return "", apperr.Errorf("f.WriteString: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", apperr.Errorf("f.Close: %w", err)
+	}
+
+	// MEMO: $EDITOR may have the arguments, such as "code --wait".
+	args := append(strings.Fields(editor), f.Name())
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", apperr.Errorf("cmd.Run: editor=%s: %w", editor, err)
+	}
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", apperr.Errorf("os.ReadFile: %w", err)
+	}
+	return strings.Join(splitStatements(dialect, string(b)), ";\n"), nil
+}
+
+// statementFindings returns the findings about query.
+func statementFindings(findings []*ddllint.SafetyFinding, query string) []*ddllint.SafetyFinding {
+	matched := make([]*ddllint.SafetyFinding, 0)
+	for _, f := range findings {
+		// MEMO: SafetyFinding.Statement is the statement in a line, so compare them ignoring the line breaks and the indents.
+		if strings.Join(strings.Fields(f.Statement), " ") == strings.Join(strings.Fields(query), " ") {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
+
+// riskLabel returns the severities and the rule IDs of the findings about query, such as "error (table-rewrite)".
+func riskLabel(findings []*ddllint.SafetyFinding, query string) string {
+	if findings == nil {
+		return "unknown"
+	}
+	labels := make([]string, 0)
+	for _, f := range statementFindings(findings, query) {
+		labels = append(labels, string(f.Severity)+" ("+f.RuleID+")")
+	}
+	if len(labels) == 0 {
+		return "none"
+	}
+	return strings.Join(labels, ", ")
+}
+
+// printSelection prints the statements of progress to be executed and the ones excluded.
+func printSelection(out io.Writer, progress *Progress) {
+	_, _ = io.WriteString(out, "\nselected DDL queries:\n")
+	for i, stmt := range progress.Statements {
+		if stmt.Status.done() && stmt.Status != StatementExcluded {
+			continue
+		}
+		mark := "apply"
+		switch {
+		case stmt.Status == StatementExcluded:
+			mark = "exclude"
+		case stmt.OriginalQuery != "":
+			mark = "edited"
+		}
+		query, _, _ := strings.Cut(strings.TrimSpace(stmt.Query), "\n")
+		_, _ = fmt.Fprintf(out, "%3d. %-7s %s\n", i+1, mark, query)
+	}
+}
+
+// record is a line of the record file, which is the selection of an interactive apply and its result.
+type record struct {
+	Time time.Time `json:"time"`
+	*Progress
+}
+
+// appendRecord appends the selection and the status of the statements in progress to path as a JSON line, to audit the interactive applies.
+func appendRecord(path string, progress *Progress) error {
+	b, err := json.Marshal(&record{Time: time.Now(), Progress: progress})
+	if err != nil {
+		return apperr.Errorf("json.Marshal: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:mnd
+	if err != nil {
+		return apperr.Errorf("os.OpenFile: %w", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return apperr.Errorf("f.Write: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return apperr.Errorf("f.Close: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hakadoriya/z.go/databasez/sqlz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+// lockPollInterval is the interval to try the lock again while waiting for --apply-lock-wait.
+const lockPollInterval = 1 * time.Second
+
+// Lock takes the lock of ddlctl apply on dsn, so that two applies cannot run at once, and returns the function to release it.
+// It waits for timeout if another apply holds the lock, and returns apperr.ErrLocked if timeout is zero or exceeded.
+//
+// PostgreSQL and MySQL use the session-level advisory locks, which are released when the connection is closed even if ddlctl is killed.
+// CockroachDB and Spanner, which have no advisory locks, use a row in the lock table, which must be deleted by hand if ddlctl is killed.
+//
+//nolint:cyclop,funlen
+func Lock(ctx context.Context, dialect, dsn string, timeout time.Duration) (unlock func(ctx context.Context) error, err error) {
+	driverName := func() string {
+		switch dialect {
+		case ddlcrdb.Dialect:
+			return ddlcrdb.DriverName
+		case ddlpostgres.Dialect:
+			return ddlpostgres.DriverName
+		default:
+			return dialect
+		}
+	}()
+
+	db, err := sqlz.OpenContext(ctx, driverName, dsn)
+	if err != nil {
+		return nil, apperr.Errorf("sqlz.OpenContext: %w", err)
+	}
+	// MEMO: the advisory locks belong to the session, so the lock and the unlock must run on the same connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		_ = db.Close()
+		return nil, apperr.Errorf("db.Conn: %w", err)
+	}
+	closeDB := func() error {
+		if err := conn.Close(); err != nil {
+			_ = db.Close()
+			return apperr.Errorf("conn.Close: %w", err)
+		}
+		if err := db.Close(); err != nil {
+			return apperr.Errorf("db.Close: %w", err)
+		}
+		return nil
+	}
+
+	var tryLock func(ctx context.Context) (bool, error)
+	var release func(ctx context.Context) error
+	switch dialect {
+	case ddlpostgres.Dialect:
+		tryLock = func(ctx context.Context) (bool, error) {
+			const q = `SELECT pg_try_advisory_lock(hashtext($1))`
+			var locked bool
+			if err := conn.QueryRowContext(ctx, q, consts.LockName).Scan(&locked); err != nil {
+				return false, apperr.Errorf("conn.QueryRowContext: q=%s: %w", q, err)
+			}
+			return locked, nil
+		}
+		release = func(ctx context.Context) error {
+			const q = `SELECT pg_advisory_unlock(hashtext($1))`
+			if _, err := conn.ExecContext(ctx, q, consts.LockName); err != nil {
+				return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
+			}
+			return nil
+		}
+	case ddlmysql.Dialect:
+		tryLock = func(ctx context.Context) (bool, error) {
+			const q = `SELECT COALESCE(GET_LOCK(?, 0), 0)`
+			var locked int
+			if err := conn.QueryRowContext(ctx, q, consts.LockName).Scan(&locked); err != nil {
+				return false, apperr.Errorf("conn.QueryRowContext: q=%s: %w", q, err)
+			}
+			return locked == 1, nil
+		}
+		release = func(ctx context.Context) error {
+			const q = `SELECT RELEASE_LOCK(?)`
+			if _, err := conn.ExecContext(ctx, q, consts.LockName); err != nil {
+				return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
+			}
+			return nil
+		}
+	case ddlcrdb.Dialect, ddlspanner.Dialect:
+		createTable := `CREATE TABLE IF NOT EXISTS ` + consts.LockTableName + ` (name STRING PRIMARY KEY, holder STRING NOT NULL, acquired_at TIMESTAMPTZ NOT NULL DEFAULT now())`
+		insert := `INSERT INTO ` + consts.LockTableName + ` (name, holder) VALUES ($1, $2) ON CONFLICT (name) DO NOTHING`
+		remove := `DELETE FROM ` + consts.LockTableName + ` WHERE name = $1 AND holder = $2`
+		if dialect == ddlspanner.Dialect {
+			createTable = `CREATE TABLE IF NOT EXISTS ` + consts.LockTableName + ` (name STRING(MAX) NOT NULL, holder STRING(MAX) NOT NULL, acquired_at TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true)) PRIMARY KEY (name)`
+			insert = `INSERT OR IGNORE INTO ` + consts.LockTableName + ` (name, holder, acquired_at) VALUES (?, ?, PENDING_COMMIT_TIMESTAMP())`
+			remove = `DELETE FROM ` + consts.LockTableName + ` WHERE name = ? AND holder = ?`
+		}
+		if _, err := conn.ExecContext(ctx, createTable); err != nil {
+			_ = closeDB()
+			return nil, apperr.Errorf("conn.ExecContext: q=%s: %w", createTable, err)
+		}
+		holder := lockHolder()
+		tryLock = func(ctx context.Context) (bool, error) {
+			result, err := conn.ExecContext(ctx, insert, consts.LockName, holder)
+			if err != nil {
+				return false, apperr.Errorf("conn.ExecContext: q=%s: %w", insert, err)
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return false, apperr.Errorf("result.RowsAffected: %w", err)
+			}
+			return n == 1, nil
+		}
+		release = func(ctx context.Context) error {
+			if _, err := conn.ExecContext(ctx, remove, consts.LockName, holder); err != nil {
+				return apperr.Errorf("conn.ExecContext: q=%s: %w", remove, err)
+			}
+			return nil
+		}
+	default:
+		_ = closeDB()
+		return nil, apperr.Errorf("dialect=%s: %w", dialect, apperr.ErrNotSupported)
+	}
+
+	if err := waitLock(ctx, timeout, tryLock); err != nil {
+		_ = closeDB()
+		return nil, apperr.Errorf("waitLock: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		if err := release(ctx); err != nil {
+			_ = closeDB()
+			return apperr.Errorf("release: %w", err)
+		}
+		return closeDB()
+	}, nil
+}
+
+// waitLock calls tryLock until it takes the lock or timeout is exceeded.
+func waitLock(ctx context.Context, timeout time.Duration, tryLock func(ctx context.Context) (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for i := 0; ; i++ {
+		locked, err := tryLock(ctx)
+		if err != nil {
+			return apperr.Errorf("tryLock: %w", err)
+		}
+		if locked {
+			return nil
+		}
+		if !time.Now().Add(lockPollInterval).Before(deadline) {
+			return apperr.Errorf("lock=%s timeout=%s: %w", consts.LockName, timeout, apperr.ErrLocked)
+		}
+		if i == 0 {
+			logs.Info.Printf("waiting for the lock of another ddlctl apply for %s", timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return apperr.Errorf("ctx.Done: %w", ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// lockHolder returns the identifier of this process, which is stored in the lock row to release only its own lock.
+func lockHolder() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%d", hostname, os.Getpid(), time.Now().UnixNano())
+}
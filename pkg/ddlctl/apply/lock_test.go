@@ -0,0 +1,83 @@
+//nolint:testpackage
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+)
+
+func TestWaitLock(t *testing.T) {
+	t.Parallel()
+
+	// tryLockAfter returns tryLock which takes the lock at the n-th call, and the number of the calls.
+	tryLockAfter := func(n int) (func(ctx context.Context) (bool, error), *int) {
+		calls := new(int)
+		return func(_ context.Context) (bool, error) {
+			*calls++
+			return *calls >= n, nil
+		}, calls
+	}
+
+	t.Run("success,locked", func(t *testing.T) {
+		t.Parallel()
+
+		tryLock, calls := tryLockAfter(1)
+		require.NoError(t, waitLock(context.Background(), 0, tryLock))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("success,wait", func(t *testing.T) {
+		t.Parallel()
+
+		tryLock, calls := tryLockAfter(2)
+		require.NoError(t, waitLock(context.Background(), 3*lockPollInterval, tryLock))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("failure,apperr.ErrLocked,no_timeout", func(t *testing.T) {
+		t.Parallel()
+
+		tryLock, calls := tryLockAfter(2)
+		err := waitLock(context.Background(), 0, tryLock)
+		require.ErrorIs(t, err, apperr.ErrLocked)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("failure,apperr.ErrLocked,timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the next try would be after the timeout, so it gives up without waiting for it.
+		tryLock, calls := tryLockAfter(3)
+		err := waitLock(context.Background(), lockPollInterval+lockPollInterval/2, tryLock)
+		require.ErrorIs(t, err, apperr.ErrLocked)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("failure,context.Canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		tryLock, calls := tryLockAfter(2)
+		err := waitLock(ctx, time.Hour, tryLock)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("failure,tryLock", func(t *testing.T) {
+		t.Parallel()
+
+		errTryLock := errors.New("connection refused")
+		err := waitLock(context.Background(), time.Hour, func(_ context.Context) (bool, error) {
+			return false, errTryLock
+		})
+		require.ErrorIs(t, err, errTryLock)
+	})
+}
@@ -0,0 +1,284 @@
+package apply
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+type StatementStatus string
+
+const (
+	StatementPending   StatementStatus = "pending"
+	StatementSucceeded StatementStatus = "succeeded"
+	// StatementSkipped is the statement whose error is ignored, such as "already exists".
+	StatementSkipped StatementStatus = "skipped"
+	StatementFailed  StatementStatus = "failed"
+	// StatementExcluded is the statement that the operator skipped in the interactive mode, which is not executed.
+	StatementExcluded StatementStatus = "excluded"
+)
+
+// Progress is the progress of each DDL query of apply, which is saved to the state file to resume the apply that failed halfway,
+// because the DDL of MySQL and Spanner is not transactional.
+//
+//nolint:tagliatelle
+type Progress struct {
+	Dialect string `json:"dialect"`
+	// DSNHash is the SHA-256 of the DSN, to check that the apply is resumed on the same database without saving the credentials.
+	DSNHash    string               `json:"dsn_sha256"`
+	Statements []*StatementProgress `json:"statements"`
+
+	path string
+}
+
+//nolint:tagliatelle
+type StatementProgress struct {
+	Query string `json:"query"`
+	// OriginalQuery is the query before the operator edited it in the interactive mode, or empty if not edited.
+	OriginalQuery string          `json:"original_query,omitempty"`
+	Status        StatementStatus `json:"status"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// NewProgress returns the Progress of the DDL queries in ddlStr, all of which are pending.
+func NewProgress(dialect, dsn, ddlStr string) *Progress {
+	queries := splitStatements(dialect, ddlStr)
+
+	p := &Progress{Dialect: dialect, DSNHash: dsnHash(dsn), Statements: make([]*StatementProgress, 0, len(queries))}
+	for _, q := range queries {
+		if strings.TrimSpace(q) == "" {
+			// skip empty query
+			continue
+		}
+		p.Statements = append(p.Statements, &StatementProgress{Query: q, Status: StatementPending})
+	}
+	return p
+}
+
+// splitStatements splits ddlStr into the statements that end with ";" at the end of a line, removing the comments and the empty lines.
+// The quoted strings are kept as they are, such as the body of a function quoted with $$, which has ";" at the end of its lines.
+//
+//nolint:cyclop
+func splitStatements(dialect, ddlStr string) []string {
+	var stmts []string
+	var buf []byte
+	lineStart := 0
+	endLine := func() {
+		if strings.TrimSpace(string(buf[lineStart:])) == "" {
+			buf = buf[:lineStart]
+		} else {
+			buf = append(buf, '\n')
+		}
+		lineStart = len(buf)
+	}
+	endStmt := func() {
+		if q := strings.TrimSpace(string(buf)); q != "" {
+			stmts = append(stmts, q)
+		}
+		buf, lineStart = buf[:0], 0
+	}
+
+	for i := 0; i < len(ddlStr); {
+		switch c := ddlStr[i]; {
+		case c == '\n':
+			endLine()
+			i++
+		case strings.HasPrefix(ddlStr[i:], "--"):
+			end := strings.IndexByte(ddlStr[i:], '\n')
+			if end == -1 {
+				end = len(ddlStr) - i
+			}
+			buf = append(buf[:lineStart], strings.TrimRight(string(buf[lineStart:]), " \t")...)
+			i += end
+		case c == ';':
+			rest := strings.TrimLeft(ddlStr[i+1:], " \t")
+			if rest == "" || rest[0] == '\n' || strings.HasPrefix(rest, "--") {
+				endStmt()
+				i = len(ddlStr) - len(rest)
+				continue
+			}
+			buf = append(buf, c)
+			i++
+		default:
+			if n := quotedLen(dialect, ddlStr, i); n > 0 {
+				buf = append(buf, ddlStr[i:i+n]...)
+				i += n
+				continue
+			}
+			buf = append(buf, c)
+			i++
+		}
+	}
+	endStmt()
+
+	return stmts
+}
+
+// quotedLen returns the length of the quoted string or identifier of dialect at s[i:], or 0 if s[i] does not start it.
+// The length of the unterminated one is up to the end of s.
+//
+//nolint:cyclop
+func quotedLen(dialect, s string, i int) int {
+	switch c := s[i]; c {
+	case '\'', '"', '`':
+		quote := s[i : i+1]
+		// MEMO: spanner has the triple-quoted strings, which can have the quotes inside.
+		if dialect == ddlspanner.Dialect && c != '`' && strings.HasPrefix(s[i:], strings.Repeat(quote, 3)) { //nolint:mnd
+			quote = strings.Repeat(quote, 3) //nolint:mnd
+		}
+		backslash := c != '`' && (dialect == ddlmysql.Dialect || dialect == ddlspanner.Dialect)
+		if c == '\'' && (dialect == ddlpostgres.Dialect || dialect == ddlcrdb.Dialect) && i > 0 && (s[i-1] == 'E' || s[i-1] == 'e') && (i == 1 || !isIdentByte(s[i-2])) {
+			backslash = true // E'...'
+		}
+		for j := i + len(quote); j < len(s); j++ {
+			switch {
+			case backslash && s[j] == '\\':
+				j++
+			case strings.HasPrefix(s[j:], quote):
+				return j + len(quote) - i
+			}
+		}
+		return len(s) - i
+	case '$':
+		if dialect != ddlpostgres.Dialect && dialect != ddlcrdb.Dialect || i > 0 && isIdentByte(s[i-1]) {
+			return 0
+		}
+		end := strings.IndexByte(s[i+1:], '$')
+		if end == -1 {
+			return 0
+		}
+		tag := s[i : i+end+2]
+		for k := 1; k < len(tag)-1; k++ {
+			if !isIdentByte(tag[k]) || k == 1 && '0' <= tag[k] && tag[k] <= '9' {
+				return 0 // such as $1
+			}
+		}
+		closing := strings.Index(s[i+len(tag):], tag)
+		if closing == -1 {
+			return len(s) - i
+		}
+		return len(tag) + closing + len(tag)
+	}
+	return 0
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// LoadProgress loads the Progress saved to path, and subsequent updates are saved to path.
+func LoadProgress(path string) (*Progress, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperr.Errorf("os.ReadFile: %w", err)
+	}
+	p := new(Progress)
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, apperr.Errorf("json.Unmarshal: path=%s: %w", path, err)
+	}
+	p.path = path
+	return p, nil
+}
+
+// SaveTo saves p to path, and subsequent updates are saved to path too.
+func (p *Progress) SaveTo(path string) error {
+	p.path = path
+	return p.save()
+}
+
+func (p *Progress) save() error {
+	if p.path == "" {
+		return nil
+	}
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return apperr.Errorf("json.MarshalIndent: %w", err)
+	}
+	if err := os.WriteFile(p.path, append(b, '\n'), 0o600); err != nil { //nolint:mnd
+		return apperr.Errorf("os.WriteFile: %w", err)
+	}
+	return nil
+}
+
+// set sets the status of the statement i, and saves p.
+// The failure of saving is only logged, because it must not stop applying.
+func (p *Progress) set(i int, status StatementStatus, err error) {
+	p.Statements[i].Status = status
+	p.Statements[i].Error = ""
+	if err != nil {
+		p.Statements[i].Error = err.Error()
+	}
+	if err := p.save(); err != nil {
+		logs.Warn.Printf("save: %v", err)
+	}
+}
+
+// Done reports whether all the statements have succeeded, been skipped or been excluded.
+func (p *Progress) Done() bool {
+	for _, s := range p.Statements {
+		if !s.Status.done() {
+			return false
+		}
+	}
+	return true
+}
+
+// Excluded reports whether any statement has been excluded in the interactive mode.
+func (p *Progress) Excluded() bool {
+	for _, s := range p.Statements {
+		if s.Status == StatementExcluded {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether p is the progress of the apply on dsn of dialect.
+func (p *Progress) Matches(dialect, dsn string) bool {
+	return p.Dialect == dialect && p.DSNHash == dsnHash(dsn)
+}
+
+// Remaining returns the DDL of the statements that have not succeeded, been skipped or been excluded.
+func (p *Progress) Remaining() string {
+	var str string
+	for _, s := range p.Statements {
+		if !s.Status.done() {
+			str += s.Query + ";\n"
+		}
+	}
+	return str
+}
+
+// Report writes the status of each statement to w.
+func (p *Progress) Report(w io.Writer) {
+	for i, s := range p.Statements {
+		query, _, _ := strings.Cut(strings.TrimSpace(s.Query), "\n")
+		if s.OriginalQuery != "" {
+			query += " (edited)"
+		}
+		_, _ = fmt.Fprintf(w, "%3d. %-9s %s\n", i+1, s.Status, query)
+		if s.Error != "" {
+			_, _ = fmt.Fprintf(w, "     %s\n", s.Error)
+		}
+	}
+}
+
+func (s StatementStatus) done() bool {
+	return s == StatementSucceeded || s == StatementSkipped || s == StatementExcluded
+}
+
+func dsnHash(dsn string) string {
+	h := sha256.Sum256([]byte(dsn))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,116 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/show"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+// shadowDB is the access to the databases of verifyShadow, which is replaced in the tests.
+type shadowDB struct {
+	show  func(ctx context.Context, dialect, dsn string) (string, error)
+	apply func(ctx context.Context, dialect, dsn, ddlStr string, opts ...ApplyOption) error
+}
+
+func newShadowDB() *shadowDB {
+	return &shadowDB{show: show.Show, apply: Apply}
+}
+
+// verifyShadow applies the current schema of dsn and ddlStr to shadowDSN, and diffs shadowDSN against src,
+// to find the statements that the database ignores or normalizes differently before touching dsn.
+// shadowDSN must be empty, because the schema left in it would be mixed with the current schema, and the schema applied to it is dropped after the verification.
+// If excluded, which means some DDL queries are excluded from ddlStr, the remaining DDL is expected and only printed.
+// applyOpts are the options of the apply of ddlStr, and opts are the options of the diff against src.
+//
+//nolint:cyclop
+func verifyShadow(ctx context.Context, db *shadowDB, dialect, language, dsn, shadowDSN, src, ddlStr string, excluded bool, applyOpts []ApplyOption, opts ...diff.DiffOption) (err error) {
+	shadowDDL, err := db.show(ctx, dialect, shadowDSN)
+	if err != nil {
+		return apperr.Errorf("db.show: %w", err)
+	}
+	if strings.TrimSpace(shadowDDL) != "" {
+		return apperr.Errorf("shadow: %w", apperr.ErrShadowDatabaseNotEmpty)
+	}
+	// MEMO: the cleanup runs even if the verification fails, so that the shadow database is empty for the next run.
+	defer func() {
+		if err2 := cleanupShadow(context.WithoutCancel(ctx), db, dialect, shadowDSN, opts); err2 != nil {
+			if err == nil {
+				err = apperr.Errorf("cleanupShadow: %w", err2)
+				return
+			}
+			logs.Warn.Printf("cleanupShadow: %v", err2)
+		}
+	}()
+
+	currentDDL, err := db.show(ctx, dialect, dsn)
+	if err != nil {
+		return apperr.Errorf("db.show: %w", err)
+	}
+	if strings.TrimSpace(currentDDL) != "" {
+		logs.Debug.Print("shadow: loading the current schema")
+		if err := db.apply(ctx, dialect, shadowDSN, currentDDL); err != nil {
+			return apperr.Errorf("db.apply: %w", err)
+		}
+	}
+
+	logs.Debug.Print("shadow: applying the DDL")
+	if err := db.apply(ctx, dialect, shadowDSN, ddlStr, applyOpts...); err != nil {
+		return apperr.Errorf("db.apply: %w", err)
+	}
+
+	appliedDDL, err := db.show(ctx, dialect, shadowDSN)
+	if err != nil {
+		return apperr.Errorf("db.show: %w", err)
+	}
+	srcDDL, err := diff.Resolve(ctx, language, dialect, src)
+	if err != nil {
+		return apperr.Errorf("diff.Resolve: %w", err)
+	}
+	remaining := new(strings.Builder)
+	if err := diff.DiffDDL(remaining, dialect, appliedDDL, srcDDL, opts...); err != nil {
+		if errors.Is(err, ddl.ErrNoDifference) {
+			return nil
+		}
+		return apperr.Errorf("diff.DiffDDL: %w", err)
+	}
+
+	if excluded {
+		_, _ = fmt.Fprintf(os.Stdout, "the following DDL remains on the shadow database, because some DDL queries are excluded:\n\n%s\n", remaining)
+		return nil
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "the following DDL remains after applying to the shadow database:\n\n%s\n", remaining)
+	return apperr.Errorf("shadow: %w", apperr.ErrShadowVerificationFailed)
+}
+
+// cleanupShadow drops all the objects in shadowDSN, which verifyShadow has created in the empty one.
+// opts are the options of the diff against the source, which are overridden to drop everything.
+func cleanupShadow(ctx context.Context, db *shadowDB, dialect, shadowDSN string, opts []diff.DiffOption) error {
+	shadowDDL, err := db.show(ctx, dialect, shadowDSN)
+	if err != nil {
+		return apperr.Errorf("db.show: %w", err)
+	}
+
+	opts = append(slices.Clip(opts), diff.DiffWithIgnores(nil), diff.DiffWithTargets(nil), diff.DiffWithCheckSafety(false), diff.DiffWithPhase(""))
+	dropDDL := new(strings.Builder)
+	if err := diff.DiffDDL(dropDDL, dialect, shadowDDL, "", opts...); err != nil {
+		if errors.Is(err, ddl.ErrNoDifference) {
+			return nil
+		}
+		return apperr.Errorf("diff.DiffDDL: %w", err)
+	}
+
+	logs.Debug.Print("shadow: dropping the schema")
+	if err := db.apply(ctx, dialect, shadowDSN, dropDDL.String()); err != nil {
+		return apperr.Errorf("db.apply: %w", err)
+	}
+	return nil
+}
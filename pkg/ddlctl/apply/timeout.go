@@ -0,0 +1,120 @@
+package apply
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hakadoriya/z.go/errorz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+type ApplyConfig struct {
+	LockTimeout      time.Duration
+	StatementTimeout time.Duration
+	Hooks            *Hooks
+	// Logger is the logger of the failed DDL queries to retry. Default: logs.Warn
+	Logger logs.Logger
+}
+
+type ApplyOption interface {
+	apply(c *ApplyConfig)
+}
+
+func newApplyConfig(opts []ApplyOption) *ApplyConfig {
+	cfg := &ApplyConfig{Logger: logs.Warn}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+	return cfg
+}
+
+// ApplyWithLogger makes the apply log the failed DDL queries to retry to logger instead of logs.Warn.
+func ApplyWithLogger(logger logs.Logger) ApplyOption { //nolint:ireturn
+	return &applyConfigLogger{
+		logger: logger,
+	}
+}
+
+type applyConfigLogger struct {
+	logger logs.Logger
+}
+
+func (o *applyConfigLogger) apply(c *ApplyConfig) {
+	c.Logger = o.logger
+}
+
+// ApplyWithLockTimeout makes each DDL query give up waiting for the lock of the table after timeout, instead of blocking the queries queued behind it.
+// The query is retried with backoff. It is not supported on spanner, which has no table locks.
+func ApplyWithLockTimeout(timeout time.Duration) ApplyOption { //nolint:ireturn
+	return &applyConfigLockTimeout{
+		lockTimeout: timeout,
+	}
+}
+
+type applyConfigLockTimeout struct {
+	lockTimeout time.Duration
+}
+
+func (o *applyConfigLockTimeout) apply(c *ApplyConfig) {
+	c.LockTimeout = o.lockTimeout
+}
+
+// ApplyWithStatementTimeout makes each DDL query fail after timeout.
+func ApplyWithStatementTimeout(timeout time.Duration) ApplyOption { //nolint:ireturn
+	return &applyConfigStatementTimeout{
+		statementTimeout: timeout,
+	}
+}
+
+type applyConfigStatementTimeout struct {
+	statementTimeout time.Duration
+}
+
+func (o *applyConfigStatementTimeout) apply(c *ApplyConfig) {
+	c.StatementTimeout = o.statementTimeout
+}
+
+// setupSession sets the timeouts of cfg to the session of conn.
+func setupSession(ctx context.Context, conn *sql.Conn, dialect string, cfg *ApplyConfig) error {
+	for _, q := range sessionQueries(dialect, cfg) {
+		logs.Debug.Printf("setup session: %s", q)
+		if _, err := conn.ExecContext(ctx, q); err != nil {
+			return apperr.Errorf("conn.ExecContext: q=%s: %w", q, err)
+		}
+	}
+	return nil
+}
+
+// sessionQueries returns the queries to set the timeouts of cfg to the session of dialect.
+func sessionQueries(dialect string, cfg *ApplyConfig) []string {
+	queries := make([]string, 0)
+	switch dialect {
+	case ddlpostgres.Dialect, ddlcrdb.Dialect:
+		if cfg.LockTimeout > 0 {
+			queries = append(queries, fmt.Sprintf("SET lock_timeout = '%dms'", cfg.LockTimeout.Milliseconds()))
+		}
+		if cfg.StatementTimeout > 0 {
+			queries = append(queries, fmt.Sprintf("SET statement_timeout = '%dms'", cfg.StatementTimeout.Milliseconds()))
+		}
+	case ddlmysql.Dialect:
+		// MEMO: lock_wait_timeout is in seconds, and 0 is not allowed.
+		if cfg.LockTimeout > 0 {
+			queries = append(queries, fmt.Sprintf("SET SESSION lock_wait_timeout = %d", max(1, int64(cfg.LockTimeout.Round(time.Second).Seconds()))))
+		}
+		// NOTE: MySQL has no timeout of DDL, so the statement timeout is the deadline of the context of each query, as well as spanner.
+	}
+	return queries
+}
+
+// isLockTimeout reports whether err is the timeout of the lock of the table, which is worth retrying.
+func isLockTimeout(err error) bool {
+	return errorz.Contains(err, "lock timeout") || // PostgreSQL and CockroachDB: canceling statement due to lock timeout
+		errorz.Contains(err, "Lock wait timeout exceeded") // MySQL: Error 1205
+}
@@ -0,0 +1,79 @@
+//nolint:testpackage
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+func TestSessionQueries(t *testing.T) {
+	t.Parallel()
+
+	cfg := newApplyConfig([]ApplyOption{ApplyWithLockTimeout(5 * time.Second), ApplyWithStatementTimeout(10 * time.Minute)})
+
+	t.Run("success,postgres", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"SET lock_timeout = '5000ms'", "SET statement_timeout = '600000ms'"}, sessionQueries(ddlpostgres.Dialect, cfg))
+	})
+
+	t.Run("success,cockroachdb", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"SET lock_timeout = '5000ms'", "SET statement_timeout = '600000ms'"}, sessionQueries(ddlcrdb.Dialect, cfg))
+	})
+
+	t.Run("success,mysql", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"SET SESSION lock_wait_timeout = 5"}, sessionQueries(ddlmysql.Dialect, cfg))
+		// MEMO: lock_wait_timeout is in seconds, so less than a second is rounded up to 1 instead of 0.
+		assert.Equal(t, []string{"SET SESSION lock_wait_timeout = 1"}, sessionQueries(ddlmysql.Dialect, newApplyConfig([]ApplyOption{ApplyWithLockTimeout(100 * time.Millisecond)})))
+	})
+
+	t.Run("success,spanner", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{}, sessionQueries(ddlspanner.Dialect, cfg))
+	})
+
+	t.Run("success,no_timeout", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{}, sessionQueries(ddlpostgres.Dialect, newApplyConfig(nil)))
+		assert.Equal(t, []string{}, sessionQueries(ddlmysql.Dialect, newApplyConfig(nil)))
+	})
+}
+
+func TestIsLockTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "postgres", err: errors.New("pq: canceling statement due to lock timeout"), want: true},
+		{name: "mysql", err: errors.New("Error 1205 (HY000): Lock wait timeout exceeded; try restarting transaction"), want: true},
+		{name: "wrapped", err: fmt.Errorf("db.ExecContext: %w", errors.New("ERROR: canceling statement due to lock timeout (SQLSTATE 55P03)")), want: true},
+		{name: "statement_timeout", err: errors.New("pq: canceling statement due to statement timeout"), want: false},
+		{name: "other", err: errors.New("Error 1452 (23000): Cannot add or update a child row: a foreign key constraint fails"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run("success,"+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, isLockTimeout(tt.err))
+		})
+	}
+}
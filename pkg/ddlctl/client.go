@@ -0,0 +1,234 @@
+package ddlctl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpostgres "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/apply"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/generate"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/show"
+	ddlctlgo "github.com/hakadoriya/ddlctl/pkg/internal/lang/go"
+	"github.com/hakadoriya/ddlctl/pkg/logs"
+)
+
+// Options is the settings of Client, which are the counterparts of the options of the command.
+type Options struct {
+	// Dialect is the SQL dialect, such as postgres. Required.
+	Dialect string
+	// Language is the programming language of the sources to generate the DDL from. Default: go
+	Language string
+	// ColumnTagGo, DDLTagGo and PKTagGo are the tags of the Go sources. Default: db, ddlctl and pk
+	ColumnTagGo string
+	DDLTagGo    string
+	PKTagGo     string
+	// InferTypeGo infers the column types from the types of the Go fields without the DDL tags or with only the constraints,
+	// and TypeOverridesGo is the column types of the Go types, such as uuid.UUID, which take precedence over the inferred ones.
+	InferTypeGo     bool
+	TypeOverridesGo map[string]string
+	// Logger is the logger of the warnings, such as the statements skipped by Lenient. Default: logs.Warn
+	Logger logs.Logger
+
+	// Lenient skips the unsupported statements with the warnings instead of failing.
+	Lenient bool
+	// Ignores and Targets are the patterns of the tables to exclude from the diff and to limit it to, such as order_*.
+	Ignores []string
+	Targets []string
+	// CheckSafety logs the findings of the safety checks of the diff, and fails if any finding is of error severity.
+	CheckSafety bool
+	// EngineVersion is the version of the database engine for the safety checks.
+	EngineVersion string
+	// IndexConcurrently and SafeConstraints are the options of the postgres dialect.
+	IndexConcurrently bool
+	SafeConstraints   bool
+
+	// LockTimeout and StatementTimeout are the timeouts of each DDL query of Apply.
+	LockTimeout      time.Duration
+	StatementTimeout time.Duration
+}
+
+// Client is the library API of ddlctl, which takes the settings from Options instead of the flags and the environment variables.
+// It is safe for concurrent use, and the clients with different Options can be used at once in a process.
+type Client struct {
+	opts Options
+}
+
+// New returns a Client with opts.
+func New(opts Options) (*Client, error) {
+	switch opts.Dialect {
+	case ddlcrdb.Dialect, ddlmysql.Dialect, ddlpostgres.Dialect, ddlspanner.Dialect:
+	case "":
+		return nil, apperr.Errorf("dialect=%s: %w", opts.Dialect, apperr.ErrDialectIsEmpty)
+	default:
+		return nil, apperr.Errorf("dialect=%s: %w", opts.Dialect, apperr.ErrNotSupported)
+	}
+
+	if opts.Language == "" {
+		opts.Language = ddlctlgo.Language
+	}
+	if opts.ColumnTagGo == "" {
+		opts.ColumnTagGo = "db"
+	}
+	if opts.DDLTagGo == "" {
+		opts.DDLTagGo = "ddlctl"
+	}
+	if opts.PKTagGo == "" {
+		opts.PKTagGo = "pk"
+	}
+	if opts.Logger == nil {
+		opts.Logger = logs.Warn
+	}
+	// MEMO: the slices are copied, not to be changed by the caller while the client is in use.
+	opts.Ignores = slices.Clone(opts.Ignores)
+	opts.Targets = slices.Clone(opts.Targets)
+	opts.TypeOverridesGo = maps.Clone(opts.TypeOverridesGo)
+
+	return &Client{opts: opts}, nil
+}
+
+// context returns ctx with the settings that the generators read from the context.
+func (c *Client) context(ctx context.Context) context.Context {
+	return ddlctlgo.WithOptions(ctx, &ddlctlgo.Options{
+		Column:        c.opts.ColumnTagGo,
+		DDL:           c.opts.DDLTagGo,
+		PK:            c.opts.PKTagGo,
+		InferType:     c.opts.InferTypeGo,
+		TypeOverrides: c.opts.TypeOverridesGo,
+	})
+}
+
+func (c *Client) diffOptions() []diff.DiffOption {
+	return []diff.DiffOption{
+		diff.DiffWithLenient(c.opts.Lenient),
+		diff.DiffWithIgnores(c.opts.Ignores),
+		diff.DiffWithTargets(c.opts.Targets),
+		diff.DiffWithCheckSafety(c.opts.CheckSafety),
+		diff.DiffWithEngineVersion(c.opts.EngineVersion),
+		diff.DiffWithIndexConcurrently(c.opts.IndexConcurrently),
+		diff.DiffWithSafeConstraints(c.opts.SafeConstraints),
+		diff.DiffWithWarnings(c.opts.Logger),
+	}
+}
+
+// Generate writes the DDL generated from src, which is a source file or directory of Language, to w.
+func (c *Client) Generate(ctx context.Context, w io.Writer, src string) error {
+	if err := generate.Generate(c.context(ctx), w, src, c.opts.Dialect, c.opts.Language); err != nil {
+		return apperr.Errorf("generate.Generate: %w", err)
+	}
+	return nil
+}
+
+// Show writes the DDL of all the tables in db to w.
+func (c *Client) Show(ctx context.Context, w io.Writer, db *sql.DB) error {
+	ddlStr, err := show.ShowDB(ctx, c.opts.Dialect, db)
+	if err != nil {
+		return apperr.Errorf("show.ShowDB: %w", err)
+	}
+	if _, err := io.WriteString(w, ddlStr); err != nil {
+		return apperr.Errorf("io.WriteString: %w", err)
+	}
+	return nil
+}
+
+// Diff writes the DDL to migrate from before to after to w, or returns ddl.ErrNoDifference if there is no difference.
+func (c *Client) Diff(ctx context.Context, w io.Writer, before, after Source) error {
+	beforeDDL, err := before.ddl(ctx, c)
+	if err != nil {
+		return apperr.Errorf("before: %w", err)
+	}
+	afterDDL, err := after.ddl(ctx, c)
+	if err != nil {
+		return apperr.Errorf("after: %w", err)
+	}
+	if err := diff.DiffDDL(w, c.opts.Dialect, beforeDDL, afterDDL, c.diffOptions()...); err != nil {
+		return apperr.Errorf("diff.DiffDDL: %w", err)
+	}
+	return nil
+}
+
+// Apply migrates db to after, and returns the DDL applied, or empty if there is no difference.
+// It does not take the lock of ddlctl apply, so the caller must not apply to db at once, such as in the other tests.
+func (c *Client) Apply(ctx context.Context, db *sql.DB, after Source) (string, error) {
+	b := new(strings.Builder)
+	if err := c.Diff(ctx, b, DB(db), after); err != nil {
+		if errors.Is(err, ddl.ErrNoDifference) {
+			return "", nil
+		}
+		return "", apperr.Errorf("Diff: %w", err)
+	}
+	ddlStr := b.String()
+
+	if err := apply.ApplyDB(ctx, c.opts.Dialect, db, ddlStr,
+		apply.ApplyWithLockTimeout(c.opts.LockTimeout),
+		apply.ApplyWithStatementTimeout(c.opts.StatementTimeout),
+		apply.ApplyWithLogger(c.opts.Logger),
+	); err != nil {
+		return "", apperr.Errorf("apply.ApplyDB: %w", err)
+	}
+	return ddlStr, nil
+}
+
+// Source is the DDL to diff, which is SQL, a file or directory, or the schema of a database.
+type Source interface {
+	ddl(ctx context.Context, c *Client) (string, error)
+}
+
+// SQL returns the Source of the DDL itself.
+func SQL(ddl string) Source { //nolint:ireturn
+	return sqlSource(ddl)
+}
+
+type sqlSource string
+
+func (s sqlSource) ddl(_ context.Context, _ *Client) (string, error) {
+	return string(s), nil
+}
+
+// Path returns the Source of a SQL file, or a directory of the sources of Options.Language to generate the DDL from.
+func Path(path string) Source { //nolint:ireturn
+	return pathSource(path)
+}
+
+type pathSource string
+
+func (s pathSource) ddl(ctx context.Context, c *Client) (string, error) {
+	// MEMO: diff.Resolve takes a path that does not exist as a DSN, so it is checked here.
+	if _, err := os.Stat(string(s)); err != nil {
+		return "", apperr.Errorf("os.Stat: %w", err)
+	}
+	ddlStr, err := diff.Resolve(c.context(ctx), c.opts.Language, c.opts.Dialect, string(s))
+	if err != nil {
+		return "", apperr.Errorf("diff.Resolve: %w", err)
+	}
+	return ddlStr, nil
+}
+
+// DB returns the Source of the current schema of db.
+func DB(db *sql.DB) Source { //nolint:ireturn
+	return &dbSource{db: db}
+}
+
+type dbSource struct {
+	db *sql.DB
+}
+
+func (s *dbSource) ddl(ctx context.Context, c *Client) (string, error) {
+	ddlStr, err := show.ShowDB(ctx, c.opts.Dialect, s.db)
+	if err != nil {
+		return "", apperr.Errorf("show.ShowDB: %w", err)
+	}
+	return ddlStr, nil
+}
@@ -0,0 +1,139 @@
+//nolint:testpackage
+package ddlctl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// testClientSource is the Go source with the tags of the 2 dialects.
+const testClientSource = `package model
+
+// User is a user.
+//
+//pgddl:table   public.users
+//spanddl:table Users
+type User struct {
+	UserID   string ` + "`" + `db:"user_id"  pgddl:"TEXT NOT NULL" pk:"true" spanner:"UserId"   spanddl:"STRING(36) NOT NULL" spanpk:"true"` + "`" + `
+	Username string ` + "`" + `db:"username" pgddl:"TEXT NOT NULL"            spanner:"Username" spanddl:"STRING(255) NOT NULL"` + "`" + `
+}
+`
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ignores := []string{"schema_migrations"}
+		c, err := New(Options{Dialect: "postgres", Ignores: ignores})
+		require.NoError(t, err)
+		assert.Equal(t, "db", c.opts.ColumnTagGo)
+		assert.Equal(t, "ddlctl", c.opts.DDLTagGo)
+		assert.Equal(t, "pk", c.opts.PKTagGo)
+		// MEMO: the caller's slice is not shared with the client.
+		ignores[0] = "users"
+		assert.Equal(t, []string{"schema_migrations"}, c.opts.Ignores)
+	})
+
+	t.Run("failure,apperr.ErrDialectIsEmpty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(Options{})
+		require.ErrorIs(t, err, apperr.ErrDialectIsEmpty)
+	})
+
+	t.Run("failure,apperr.ErrNotSupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(Options{Dialect: "sqlite"})
+		require.ErrorIs(t, err, apperr.ErrNotSupported)
+	})
+}
+
+// TestClient_concurrent runs the clients with different tags at once, which is meant to be run with -race.
+func TestClient_concurrent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "model.go")
+	require.NoError(t, os.WriteFile(src, []byte(testClientSource), 0o600))
+
+	pg, err := New(Options{Dialect: "postgres", DDLTagGo: "pgddl"})
+	require.NoError(t, err)
+	spanner, err := New(Options{Dialect: "spanner", ColumnTagGo: "spanner", DDLTagGo: "spanddl", PKTagGo: "spanpk"})
+	require.NoError(t, err)
+
+	// MEMO: the results of each client alone are the expected ones at once.
+	generate := func(c *Client) (string, error) {
+		b := new(strings.Builder)
+		err := c.Generate(context.Background(), b, src)
+		return b.String(), err
+	}
+	diff := func(c *Client) (string, error) {
+		b := new(strings.Builder)
+		err := c.Diff(context.Background(), b, SQL(""), Path(dir))
+		return b.String(), err
+	}
+	expected := make(map[*Client][2]string)
+	for _, c := range []*Client{pg, spanner} {
+		generated, err := generate(c)
+		require.NoError(t, err)
+		diffed, err := diff(c)
+		require.NoError(t, err)
+		expected[c] = [2]string{generated, diffed}
+	}
+	assert.Equal(t, `-- pgddl:table   public.users
+-- spanddl:table Users
+CREATE TABLE public.users (
+    "user_id" TEXT NOT NULL,
+    "username" TEXT NOT NULL,
+    CONSTRAINT users_pkey PRIMARY KEY ("user_id")
+);
+`, expected[pg][1])
+	assert.Equal(t, "-- pgddl:table   public.users\n-- spanddl:table Users\nCREATE TABLE Users (\n"+
+		"    `UserId` STRING(36) NOT NULL,\n    `Username` STRING(255) NOT NULL\n) PRIMARY KEY (`UserId`);\n", expected[spanner][1])
+
+	const n = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for range n {
+		for _, c := range []*Client{pg, spanner} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				generated, err := generate(c)
+				if err == nil && generated != expected[c][0] {
+					err = errors.New("unexpected generated DDL:\n" + generated)
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+				diffed, err := diff(c)
+				if err == nil && diffed != expected[c][1] {
+					err = errors.New("unexpected diff:\n" + diffed)
+				}
+				if err != nil && !errors.Is(err, ddl.ErrNoDifference) {
+					errs <- err
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
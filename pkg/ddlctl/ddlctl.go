@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hakadoriya/z.go/buildinfoz"
 	"github.com/hakadoriya/z.go/cliz"
@@ -12,8 +15,11 @@ import (
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/apply"
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/format"
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/generate"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/lint"
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/show"
+	"github.com/hakadoriya/ddlctl/pkg/internal/config"
 	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
 )
 
@@ -31,6 +37,72 @@ var (
 		Description: "SQL dialect to generate DDL",
 		Default:     "",
 	}
+	optIndexConcurrently = &cliz.BoolOption{
+		Name:        consts.OptionIndexConcurrently,
+		Env:         consts.EnvKeyIndexConcurrently,
+		Description: "use CREATE INDEX CONCURRENTLY and DROP INDEX CONCURRENTLY (postgres only)",
+		Default:     false,
+	}
+	optSafeConstraints = &cliz.BoolOption{
+		Name:        consts.OptionSafeConstraints,
+		Env:         consts.EnvKeySafeConstraints,
+		Description: "add FOREIGN KEY and CHECK constraints as NOT VALID, then VALIDATE CONSTRAINT separately, and SET NOT NULL via a CHECK constraint (postgres only)",
+		Default:     false,
+	}
+	optTarget = &cliz.StringOption{
+		Name:        consts.OptionTarget,
+		Env:         consts.EnvKeyTarget,
+		Description: "comma-separated tables to limit the DDL to, such as users,order_*, including their indexes and constraints",
+		Default:     "",
+	}
+	optDSN = &cliz.StringOption{
+		Name:        consts.OptionDSN,
+		Env:         consts.EnvKeyDSN,
+		Description: "DSN to use if the arguments are omitted, such as in an environment of ddlctl.yaml",
+		Default:     "",
+	}
+	optSource = &cliz.StringOption{
+		Name:        consts.OptionSource,
+		Env:         consts.EnvKeySource,
+		Description: "DDL source to use with --dsn if the arguments are omitted, such as in an environment of ddlctl.yaml",
+		Default:     "",
+	}
+	optIgnore = &cliz.StringOption{
+		Name:        consts.OptionIgnore,
+		Env:         consts.EnvKeyIgnore,
+		Description: "comma-separated tables to exclude from the DDL, such as schema_migrations,tmp_*, including their indexes and constraints",
+		Default:     "",
+	}
+	optCheckSafety = &cliz.BoolOption{
+		Name:        consts.OptionCheckSafety,
+		Env:         consts.EnvKeyCheckSafety,
+		Description: "check the generated DDL against the locks and the table rewrites of the dialect, print the findings with safer plans to stderr, and exit with non-zero status on the error findings",
+		Default:     false,
+	}
+	optEngineVersion = &cliz.StringOption{
+		Name:        consts.OptionEngineVersion,
+		Env:         consts.EnvKeyEngineVersion,
+		Description: "version of the database engine for --check-safety, such as 16 or 8.0.29 (default: latest)",
+		Default:     "",
+	}
+	optPhased = &cliz.BoolOption{
+		Name:        consts.OptionPhased,
+		Env:         consts.EnvKeyPhased,
+		Description: "split the generated DDL into the expand, migrate and contract phases, and write them to 1_expand.sql, 2_migrate.sql and 3_contract.sql in --phase-dir",
+		Default:     false,
+	}
+	optPhaseDir = &cliz.StringOption{
+		Name:        consts.OptionPhaseDir,
+		Env:         consts.EnvKeyPhaseDir,
+		Description: "directory to write the phases of --phased to",
+		Default:     ".",
+	}
+	optLenient = &cliz.BoolOption{
+		Name:        consts.OptionLenient,
+		Env:         consts.EnvKeyLenient,
+		Description: "skip unsupported statements such as GRANT with warnings, instead of failing",
+		Default:     false,
+	}
 	opts = []cliz.Option{
 		optLanguage,
 		optDialect,
@@ -53,6 +125,18 @@ var (
 			Description: "primary key annotation key for Go struct tag",
 			Default:     "pk",
 		},
+		&cliz.BoolOption{
+			Name:        consts.OptionGoInferType,
+			Env:         consts.EnvKeyGoInferType,
+			Description: "infer the column type from the Go field type if the DDL annotation is absent or has only constraints",
+			Default:     false,
+		},
+		&cliz.StringOption{
+			Name:        consts.OptionGoTypeOverride,
+			Env:         consts.EnvKeyGoTypeOverride,
+			Description: "comma-separated column types of Go types for --go-infer-type, such as uuid.UUID=UUID,decimal.Decimal=NUMERIC(10,2)",
+			Default:     "",
+		},
 	}
 )
 
@@ -80,28 +164,120 @@ func DDLCtl(ctx context.Context) error {
 				Aliases:     []string{"gen"},
 				Usage:       "ddlctl generate [options] --dialect <DDL dialect> <source> <destination>",
 				Description: "generate DDL from source (file or directory) to destination (file or directory).",
-				Options:     opts,
-				ExecFunc:    generate.Command,
+				Options: append(opts,
+					&cliz.StringOption{
+						Name:        consts.OptionOutput,
+						Env:         consts.EnvKeyOutput,
+						Description: "comma-separated <dialect>=<go-ddl-tag>:<destination> to generate the DDL of the dialects from <source> parsed once, such as postgres=pgddl:schema/pg.sql,spanner=spanddl:schema/spanner.sql, instead of --dialect, --go-ddl-tag and <destination>",
+						Default:     "",
+					},
+				),
+				ExecFunc: generate.Command,
 			},
 			{
 				Name:        "show",
 				Usage:       "ddlctl show --dialect <DDL dialect> <DSN>",
 				Description: "show DDL from DSN like `SHOW CREATE TABLE`.",
-				Options:     []cliz.Option{optDialect},
+				Options:     []cliz.Option{optDialect, optDSN},
 				ExecFunc:    show.Command,
 			},
 			{
 				Name:        "diff",
 				Usage:       "ddlctl diff [options] --dialect <DDL dialect> <before DDL source> <after DDL source>",
 				Description: "diff DDL from <before DDL source> to <after DDL source>.",
-				Options:     opts,
-				ExecFunc:    diff.Command,
+				Options: append(opts,
+					optIndexConcurrently,
+					optSafeConstraints,
+					optLenient,
+					optTarget,
+					optIgnore,
+					optDSN,
+					optSource,
+					optCheckSafety,
+					optEngineVersion,
+					optPhased,
+					optPhaseDir,
+					&cliz.StringOption{
+						Name:        consts.OptionPhase,
+						Env:         consts.EnvKeyPhase,
+						Description: "print only the phase of the generated DDL (expand, migrate, contract)",
+						Default:     "",
+					},
+				),
+				ExecFunc: diff.Command,
 			},
 			{
 				Name:        "apply",
 				Usage:       "ddlctl apply [options] --dialect <DDL dialect> <DSN to apply> <DDL source>",
 				Description: "apply DDL from <DDL source> to <DSN to apply>.",
 				Options: append(opts,
+					optIndexConcurrently,
+					optSafeConstraints,
+					optLenient,
+					optTarget,
+					optIgnore,
+					optDSN,
+					optSource,
+					&cliz.StringOption{
+						Name:        consts.OptionPhase,
+						Env:         consts.EnvKeyPhase,
+						Description: "apply only the phase of the DDL (expand, contract)",
+						Default:     "",
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionShadowDSN,
+						Env:         consts.EnvKeyShadowDSN,
+						Description: "DSN of an empty database to apply the current schema and the DDL to, and verify that no difference remains before applying to <DSN to apply>",
+						Default:     "",
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionApplyLockWait,
+						Env:         consts.EnvKeyApplyLockWait,
+						Description: "time to wait for the lock of another ddlctl apply on <DSN to apply>, such as 1m (default: fail immediately)",
+						Default:     "",
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionLockTimeout,
+						Env:         consts.EnvKeyLockTimeout,
+						Description: "time to wait for the table lock of each DDL query, such as 5s, which is retried with backoff on timeout (default: no limit)",
+						Default:     "",
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionStatementTimeout,
+						Env:         consts.EnvKeyStatementTimeout,
+						Description: "time to cancel each DDL query after, such as 10m (default: no limit)",
+						Default:     "",
+					},
+					&cliz.BoolOption{
+						Name:        consts.OptionResume,
+						Env:         consts.EnvKeyResume,
+						Description: "continue the apply that failed halfway from the failed statement, with the progress saved to --state-file, instead of diffing again",
+						Default:     false,
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionStateFile,
+						Env:         consts.EnvKeyStateFile,
+						Description: "file to save the progress of each DDL query to, which is removed when all succeed",
+						Default:     ".ddlctl_apply_state.json",
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionHooks,
+						Env:         consts.EnvKeyHooks,
+						Description: "JSON file of the SQL files or the shell commands to run before and after the whole apply, and before and after the DDL queries on the specific tables",
+						Default:     "",
+					},
+					&cliz.BoolOption{
+						Name:        consts.OptionInteractive,
+						Env:         consts.EnvKeyInteractive,
+						Description: "list each DDL query with its risk, and accept, skip or edit it one by one, instead of approving the whole DDL (ignored if stdin is not a terminal)",
+						Default:     false,
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionRecordFile,
+						Env:         consts.EnvKeyRecordFile,
+						Description: "file to append the selection of --interactive and its result to, as a JSON line per apply",
+						Default:     ".ddlctl_apply_record.jsonl",
+					},
 					&cliz.BoolOption{
 						Name:        consts.OptionAutoApprove,
 						Env:         consts.EnvKeyAutoApprove,
@@ -111,6 +287,42 @@ func DDLCtl(ctx context.Context) error {
 				),
 				ExecFunc: apply.Command,
 			},
+			{
+				Name:        "fmt",
+				Aliases:     []string{"format"},
+				Usage:       "ddlctl fmt [options] --dialect <DDL dialect> <DDL file>...",
+				Description: "format DDL files in the canonical layout.",
+				Options: []cliz.Option{
+					optDialect,
+					&cliz.BoolOption{
+						Name:        consts.OptionCheck,
+						Env:         consts.EnvKeyCheck,
+						Description: "do not rewrite files, but print the files that are not formatted and exit with non-zero status",
+						Default:     false,
+					},
+				},
+				ExecFunc: format.Command,
+			},
+			{
+				Name:        "lint",
+				Usage:       "ddlctl lint [options] --dialect <DDL dialect> [<before DDL source>] <DDL source>",
+				Description: "lint DDL from <DDL source>, and the changes from <before DDL source> if specified.",
+				Options: append(opts,
+					&cliz.StringOption{
+						Name:        consts.OptionFormat,
+						Env:         consts.EnvKeyFormat,
+						Description: "output format (text, sarif)",
+						Default:     lint.FormatText,
+					},
+					&cliz.StringOption{
+						Name:        consts.OptionLintConfig,
+						Env:         consts.EnvKeyLintConfig,
+						Description: "JSON file to configure the severities and the options of the lint rules",
+						Default:     "",
+					},
+				),
+				ExecFunc: lint.Command,
+			},
 		},
 		Options: []cliz.Option{
 			&cliz.BoolOption{
@@ -125,9 +337,23 @@ func DDLCtl(ctx context.Context) error {
 				Description: "debug mode",
 				Default:     false,
 			},
+			&cliz.StringOption{
+				Name:        consts.OptionEnv,
+				Env:         consts.EnvKeyEnv,
+				Description: "environment in ddlctl.yaml to use, such as dev or prod",
+				Default:     "",
+			},
 		},
 	}
 
+	wd, err := os.Getwd()
+	if err != nil {
+		return apperr.Errorf("os.Getwd: %w", err)
+	}
+	if err := applyProject(&cmd, wd, os.Args[1:]); err != nil {
+		return apperr.Errorf("applyProject: %w", err)
+	}
+
 	if err := cmd.Exec(ctx, os.Args); err != nil {
 		if errors.Is(err, cliz.ErrHelp) {
 			return nil
@@ -138,3 +364,150 @@ func DDLCtl(ctx context.Context) error {
 
 	return nil
 }
+
+// projectPathOptions is the options of the paths to read, which are relative to the directory of ddlctl.yaml in it if they exist there.
+// MEMO: source may be a DSN such as postgres://..., which must not be joined to the directory.
+//
+//nolint:gochecknoglobals
+var projectPathOptions = []string{
+	consts.OptionSource,
+	consts.OptionLintConfig,
+	consts.OptionPhaseDir,
+	consts.OptionHooks,
+}
+
+// projectOutputPathOptions is the options of the paths to write, which are relative to the directory of ddlctl.yaml in it.
+//
+//nolint:gochecknoglobals
+var projectOutputPathOptions = []string{
+	consts.OptionStateFile,
+	consts.OptionRecordFile,
+}
+
+// applyProject sets the values in ddlctl.yaml, which is discovered from dir upward, as the defaults of the options of cmd,
+// so that the flags and the environment variables override them. The environment is --env in args or DDLCTL_ENV.
+// ddlctl.yaml is not read for version and help, so that a broken one does not break them.
+//
+//nolint:cyclop,funlen
+func applyProject(cmd *cliz.Command, dir string, args []string) error {
+	if !projectRequired(args) {
+		return nil
+	}
+	env := projectEnv(args)
+
+	path, err := config.FindProjectFile(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			if env != "" {
+				return apperr.Errorf("env=%s: %s: %w", env, consts.ProjectFileName, apperr.ErrProjectFileNotFound)
+			}
+			return nil
+		}
+		return apperr.Errorf("config.FindProjectFile: %w", err)
+	}
+	project, err := config.LoadProjectFile(path)
+	if err != nil {
+		return apperr.Errorf("config.LoadProjectFile: %w", err)
+	}
+	values, err := project.OptionsOf(env)
+	if err != nil {
+		return apperr.Errorf("project.OptionsOf: %w", err)
+	}
+
+	options := make(map[string][]cliz.Option)
+	walkOptions(cmd, func(o cliz.Option) {
+		switch o := o.(type) {
+		case *cliz.StringOption:
+			options[o.Name] = append(options[o.Name], o)
+		case *cliz.BoolOption:
+			options[o.Name] = append(options[o.Name], o)
+		}
+	})
+
+	for name, value := range values {
+		if _, ok := options[name]; !ok {
+			return apperr.Errorf("path=%s: unknown option %s: %w", path, name, apperr.ErrInvalidProjectFile)
+		}
+		switch {
+		case slices.Contains(projectPathOptions, name):
+			value = project.ResolveExistingPath(value)
+		case slices.Contains(projectOutputPathOptions, name):
+			value = project.ResolvePath(value)
+		case name == consts.OptionOutput:
+			if value, err = resolveProjectOutputs(project, value); err != nil {
+				return apperr.Errorf("path=%s: resolveProjectOutputs: %w", path, err)
+			}
+		}
+		for _, o := range options[name] {
+			switch o := o.(type) {
+			case *cliz.StringOption:
+				o.Default = value
+			case *cliz.BoolOption:
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return apperr.Errorf("path=%s: %s=%s: %v: %w", path, name, value, err, apperr.ErrInvalidProjectFile)
+				}
+				o.Default = b
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveProjectOutputs returns the comma-separated outputs of --output in value with the destinations relative to the directory of ddlctl.yaml.
+func resolveProjectOutputs(project *config.Project, value string) (string, error) {
+	outputs := make([]string, 0)
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		o, err := config.ParseOutput(s)
+		if err != nil {
+			return "", apperr.Errorf("config.ParseOutput: %w", err)
+		}
+		o.Path = project.ResolvePath(o.Path)
+		outputs = append(outputs, o.String())
+	}
+	return strings.Join(outputs, ","), nil
+}
+
+// projectRequired reports whether the subcommand in args uses ddlctl.yaml, which is not the case for version, help or no subcommand.
+func projectRequired(args []string) bool {
+	if slices.Contains(args, "-h") || slices.Contains(args, "--help") {
+		return false
+	}
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--"+consts.OptionEnv:
+			i++
+		case strings.HasPrefix(arg, "-"):
+			continue
+		default:
+			return arg != "version" && arg != "help"
+		}
+	}
+	return false
+}
+
+// projectEnv returns the value of --env in args or DDLCTL_ENV, which is needed before parsing args to set the defaults of the options.
+func projectEnv(args []string) string {
+	for i, arg := range args {
+		if v, ok := strings.CutPrefix(arg, "--"+consts.OptionEnv+"="); ok {
+			return v
+		}
+		if arg == "--"+consts.OptionEnv && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv(consts.EnvKeyEnv)
+}
+
+func walkOptions(cmd *cliz.Command, f func(o cliz.Option)) {
+	for _, o := range cmd.Options {
+		f(o)
+	}
+	for _, sub := range cmd.SubCommands {
+		walkOptions(sub, f)
+	}
+}
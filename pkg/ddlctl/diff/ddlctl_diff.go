@@ -3,8 +3,11 @@ package diff
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hakadoriya/z.go/cliz"
@@ -18,6 +21,7 @@ import (
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/generate"
 	"github.com/hakadoriya/ddlctl/pkg/ddlctl/show"
 	"github.com/hakadoriya/ddlctl/pkg/internal/config"
+	ddllint "github.com/hakadoriya/ddlctl/pkg/lint"
 	"github.com/hakadoriya/ddlctl/pkg/logs"
 )
 
@@ -27,26 +31,144 @@ func Command(c *cliz.Command, args []string) error {
 		return apperr.Errorf("config.Load: %w", err)
 	}
 
+	args = config.Args(args)
 	const beforeAndAfterForDiff = 2
 	if len(args) != beforeAndAfterForDiff {
 		return apperr.Errorf("args=%v: %w", args, apperr.ErrTwoArgumentsRequired)
 	}
+	args, err := config.ResolveDSNs(ctx, args)
+	if err != nil {
+		return apperr.Errorf("config.ResolveDSNs: %w", err)
+	}
 
 	dialect := config.Dialect()
 	language := config.Language()
 	leftArg, rightArg := args[0], args[1]
 
-	if err := Diff(ctx, os.Stdout, dialect, language, leftArg, rightArg); err != nil {
+	if err := ValidatePhase(config.Phase(), ddl.AllPhases()...); err != nil {
+		return apperr.Errorf("ValidatePhase: %w", err)
+	}
+
+	if err := Diff(ctx, os.Stdout, dialect, language, leftArg, rightArg, ConfigOptions()...); err != nil {
 		if errors.Is(err, ddl.ErrNoDifference) {
 			logs.Debug.Print(ddl.ErrNoDifference.Error())
 			return nil
 		}
+		PrintParseError(os.Stderr, err)
 		return apperr.Errorf("diff: %w", err)
 	}
 
 	return nil
 }
 
+// PrintParseError prints the location and the source excerpt of each *ddl.ParseError in err to w.
+func PrintParseError(w io.Writer, err error) {
+	for _, parseErr := range ddl.AsParseErrors(err) {
+		_, _ = fmt.Fprintf(w, "%s\n%s", parseErr.Error(), parseErr.Excerpt())
+	}
+}
+
+// withFilename sets arg as the filename of each *ddl.ParseError in err, if arg is a SQL file.
+func withFilename(err error, arg string) error {
+	if isFile(arg) {
+		for _, parseErr := range ddl.AsParseErrors(err) {
+			parseErr.Filename = arg
+		}
+	}
+	return err
+}
+
+// printParseWarnings prints the statements skipped in the lenient mode to w, with the location and the source excerpt.
+func printParseWarnings(w io.Writer, warnings []*ddl.ParseError, arg string) {
+	for _, warning := range warnings {
+		if isFile(arg) {
+			warning.Filename = arg
+		}
+		_, _ = fmt.Fprintf(w, "WARN: %s, skipped\n%s", warning.Error(), warning.Excerpt())
+	}
+}
+
+// printTargetWarnings prints the statements skipped by --target that the other statements depend on to w.
+func printTargetWarnings(w io.Writer, warnings []string) {
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(w, "WARN: %s\n", warning)
+	}
+}
+
+// reportSafety passes findings to the callback of DiffWithSafetyFindings,
+// and if --check-safety, prints them to w and returns apperr.ErrUnsafeMigration if any finding is of error severity.
+// It is called before the DDL is written, so that the findings on stderr come first.
+func reportSafety(w io.Writer, cfg *DiffConfig, findings []*ddllint.SafetyFinding) error {
+	if cfg.SafetyFindings != nil {
+		cfg.SafetyFindings(findings)
+	}
+	if !cfg.CheckSafety {
+		return nil
+	}
+	for _, f := range findings {
+		_, _ = fmt.Fprintln(w, f.String())
+	}
+	if ddllint.HasSafetyErrors(findings) {
+		return apperr.Errorf("findings=%d: %w", len(findings), apperr.ErrUnsafeMigration)
+	}
+	return nil
+}
+
+// writeResult writes result to out, or the phase of --phase, or the files of all the phases in --phase-dir if --phased.
+// phase returns the DDL of the phase split from result.
+func writeResult(out io.Writer, cfg *DiffConfig, result fmt.Stringer, phase func(p ddl.Phase) fmt.Stringer) error {
+	if p := cfg.Phase; p != "" {
+		phaseDDL := phase(ddl.Phase(p)).String()
+		if phaseDDL == "" {
+			return apperr.Errorf("phase=%s: %w", p, ddl.ErrNoDifference)
+		}
+		if _, err := io.WriteString(out, phaseDDL); err != nil {
+			return apperr.Errorf("io.WriteString: %w", err)
+		}
+		return nil
+	}
+
+	if !cfg.Phased {
+		if _, err := io.WriteString(out, result.String()); err != nil {
+			return apperr.Errorf("io.WriteString: %w", err)
+		}
+		return nil
+	}
+
+	dir := cfg.PhaseDir
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec,mnd
+		return apperr.Errorf("os.MkdirAll: %w", err)
+	}
+	for i, p := range ddl.AllPhases() {
+		phaseDDL := phase(p).String()
+		if phaseDDL == "" {
+			logs.Debug.Printf("phase=%s: %s", p, ddl.ErrNoDifference)
+			continue
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%d_%s.sql", i+1, p))
+		if err := os.WriteFile(path, []byte(phaseDDL), 0o644); err != nil { //nolint:gosec,mnd
+			return apperr.Errorf("os.WriteFile: %w", err)
+		}
+		if _, err := fmt.Fprintln(out, path); err != nil {
+			return apperr.Errorf("fmt.Fprintln: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidatePhase returns apperr.ErrInvalidPhase if phase is not empty and not one of phases.
+func ValidatePhase(phase string, phases ...ddl.Phase) error {
+	if phase == "" {
+		return nil
+	}
+	for _, p := range phases {
+		if ddl.Phase(phase) == p {
+			return nil
+		}
+	}
+	return apperr.Errorf("phase=%s: %w", phase, apperr.ErrInvalidPhase)
+}
+
 func exists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
@@ -57,8 +179,10 @@ func isFile(path string) bool {
 	return err == nil && !info.IsDir()
 }
 
+// Resolve returns the DDL of arg, which is a SQL file, a directory of the source code to generate DDL from, or a DSN.
+//
 //nolint:cyclop
-func resolve(ctx context.Context, language, dialect, arg string) (ddl string, err error) {
+func Resolve(ctx context.Context, language, dialect, arg string) (ddl string, err error) {
 	switch {
 	case isFile(arg): // NOTE: expect SQL file
 		ddlBytes, err := os.ReadFile(arg)
@@ -84,101 +208,264 @@ func resolve(ctx context.Context, language, dialect, arg string) (ddl string, er
 }
 
 //nolint:cyclop,funlen,gocognit
-func Diff(ctx context.Context, out io.Writer, dialect, language, src string, dst string) error {
-	srcDDL, err := resolve(ctx, language, dialect, src)
+func Diff(ctx context.Context, out io.Writer, dialect, language, src string, dst string, opts ...DiffOption) error {
+	srcDDL, err := Resolve(ctx, language, dialect, src)
 	if err != nil {
-		return apperr.Errorf("resolve: %w", err)
+		return apperr.Errorf("Resolve: %w", err)
 	}
 
-	dstDDL, err := resolve(ctx, language, dialect, dst)
+	dstDDL, err := Resolve(ctx, language, dialect, dst)
 	if err != nil {
-		return apperr.Errorf("resolve: %w", err)
+		return apperr.Errorf("Resolve: %w", err)
+	}
+
+	if err := diffDDL(out, dialect, src, srcDDL, dst, dstDDL, newDiffConfig(opts)); err != nil {
+		return apperr.Errorf("diffDDL: %w", err)
 	}
+	return nil
+}
+
+// DiffDDL writes the DDL from srcDDL to dstDDL to out, which are the DDL themselves instead of the DDL sources of Diff.
+func DiffDDL(out io.Writer, dialect, srcDDL, dstDDL string, opts ...DiffOption) error {
+	if err := diffDDL(out, dialect, "", srcDDL, "", dstDDL, newDiffConfig(opts)); err != nil {
+		return apperr.Errorf("diffDDL: %w", err)
+	}
+	return nil
+}
 
+// diffDDL writes the DDL from srcDDL to dstDDL to out. src and dst are the DDL sources of them, or empty, to locate the parse errors.
+//
+//nolint:cyclop,funlen,gocognit,gocyclo,maintidx
+func diffDDL(out io.Writer, dialect, src, srcDDL, dst, dstDDL string, cfg *DiffConfig) error {
 	logs.Trace.Printf("srcDDL: %q", srcDDL)
 	logs.Trace.Printf("dstDDL: %q", dstDDL)
 
 	switch dialect {
 	case ddlmysql.Dialect:
-		leftDDL, err := ddlmysql.NewParser(ddlmysql.NewLexer(srcDDL)).Parse()
+		leftParser := ddlmysql.NewParser(ddlmysql.NewLexer(srcDDL), ddlmysql.ParserUseLenient(cfg.Lenient))
+		leftDDL, err := leftParser.Parse()
 		if err != nil {
-			return apperr.Errorf("myddl.NewParser: %w", err)
+			return apperr.Errorf("myddl.NewParser: %w", withFilename(err, src))
 		}
-		rightDDL, err := ddlmysql.NewParser(ddlmysql.NewLexer(dstDDL)).Parse()
+		printParseWarnings(cfg.Warnings, leftParser.Warnings(), src)
+		rightParser := ddlmysql.NewParser(ddlmysql.NewLexer(dstDDL), ddlmysql.ParserUseLenient(cfg.Lenient))
+		rightDDL, err := rightParser.Parse()
 		if err != nil {
-			return apperr.Errorf("myddl.NewParser: %w", err)
+			return apperr.Errorf("myddl.NewParser: %w", withFilename(err, dst))
 		}
+		printParseWarnings(cfg.Warnings, rightParser.Warnings(), dst)
 
 		result, err := ddlmysql.Diff(leftDDL, rightDDL)
 		if err != nil {
 			return apperr.Errorf("myddl.Diff: %w", err)
 		}
 
-		if _, err := io.WriteString(out, result.String()); err != nil {
-			return apperr.Errorf("io.WriteString: %w", err)
+		if ignores := cfg.Ignores; len(ignores) > 0 {
+			ignored, _, err := ddlmysql.FilterTargets(leftDDL, result, ignores)
+			if err != nil {
+				return apperr.Errorf("myddl.FilterTargets: %w", err)
+			}
+			result.Stmts = slices.DeleteFunc(result.Stmts, func(stmt ddlmysql.Stmt) bool { return slices.Contains(ignored.Stmts, stmt) })
+		}
+		if targets := cfg.Targets; len(targets) > 0 {
+			var warnings []string
+			result, warnings, err = ddlmysql.FilterTargets(leftDDL, result, targets)
+			if err != nil {
+				return apperr.Errorf("myddl.FilterTargets: %w", err)
+			}
+			printTargetWarnings(cfg.Warnings, warnings)
+		}
+		if len(result.Stmts) == 0 {
+			return apperr.Errorf("targets=%v: ignores=%v: %w", cfg.Targets, cfg.Ignores, ddl.ErrNoDifference)
 		}
 
-		return nil
+		// MEMO: the findings are printed before the DDL, so that they are not buried under it.
+		var unsafeErr error
+		if cfg.CheckSafety || cfg.SafetyFindings != nil {
+			findings, err := ddllint.CheckSafetyMySQL(leftDDL, result, cfg.EngineVersion)
+			if err != nil {
+				return apperr.Errorf("ddllint.CheckSafetyMySQL: %w", err)
+			}
+			if err := reportSafety(cfg.Warnings, cfg, findings); err != nil {
+				unsafeErr = apperr.Errorf("reportSafety: %w", err)
+			}
+		}
+
+		if err := writeResult(out, cfg, result, func(p ddl.Phase) fmt.Stringer { return ddlmysql.SplitPhases(result).Phase(p) }); err != nil {
+			return apperr.Errorf("writeResult: %w", err)
+		}
+
+		return unsafeErr
 	case ddlpg.Dialect:
-		leftDDL, err := ddlpg.NewParser(ddlpg.NewLexer(srcDDL)).Parse()
+		leftParser := ddlpg.NewParser(ddlpg.NewLexer(srcDDL), ddlpg.ParserUseLenient(cfg.Lenient))
+		leftDDL, err := leftParser.Parse()
 		if err != nil {
-			return apperr.Errorf("pgddl.NewParser: %w", err)
+			return apperr.Errorf("pgddl.NewParser: %w", withFilename(err, src))
 		}
-		rightDDL, err := ddlpg.NewParser(ddlpg.NewLexer(dstDDL)).Parse()
+		printParseWarnings(cfg.Warnings, leftParser.Warnings(), src)
+		rightParser := ddlpg.NewParser(ddlpg.NewLexer(dstDDL), ddlpg.ParserUseLenient(cfg.Lenient))
+		rightDDL, err := rightParser.Parse()
 		if err != nil {
-			return apperr.Errorf("pgddl.NewParser: %w", err)
+			return apperr.Errorf("pgddl.NewParser: %w", withFilename(err, dst))
 		}
+		printParseWarnings(cfg.Warnings, rightParser.Warnings(), dst)
 
-		result, err := ddlpg.Diff(leftDDL, rightDDL)
+		result, err := ddlpg.Diff(
+			leftDDL,
+			rightDDL,
+			ddlpg.DiffUseIndexConcurrently(cfg.IndexConcurrently),
+			ddlpg.DiffUseSafeConstraints(cfg.SafeConstraints),
+		)
 		if err != nil {
 			return apperr.Errorf("pgddl.Diff: %w", err)
 		}
 
-		if _, err := io.WriteString(out, result.String()); err != nil {
-			return apperr.Errorf("io.WriteString: %w", err)
+		if ignores := cfg.Ignores; len(ignores) > 0 {
+			ignored, _, err := ddlpg.FilterTargets(leftDDL, result, ignores)
+			if err != nil {
+				return apperr.Errorf("pgddl.FilterTargets: %w", err)
+			}
+			result.Stmts = slices.DeleteFunc(result.Stmts, func(stmt ddlpg.Stmt) bool { return slices.Contains(ignored.Stmts, stmt) })
+		}
+		if targets := cfg.Targets; len(targets) > 0 {
+			var warnings []string
+			result, warnings, err = ddlpg.FilterTargets(leftDDL, result, targets)
+			if err != nil {
+				return apperr.Errorf("pgddl.FilterTargets: %w", err)
+			}
+			printTargetWarnings(cfg.Warnings, warnings)
+		}
+		if len(result.Stmts) == 0 {
+			return apperr.Errorf("targets=%v: ignores=%v: %w", cfg.Targets, cfg.Ignores, ddl.ErrNoDifference)
 		}
 
-		return nil
+		// MEMO: the findings are printed before the DDL, so that they are not buried under it.
+		var unsafeErr error
+		if cfg.CheckSafety || cfg.SafetyFindings != nil {
+			findings, err := ddllint.CheckSafetyPostgres(leftDDL, result, cfg.EngineVersion)
+			if err != nil {
+				return apperr.Errorf("ddllint.CheckSafetyPostgres: %w", err)
+			}
+			if err := reportSafety(cfg.Warnings, cfg, findings); err != nil {
+				unsafeErr = apperr.Errorf("reportSafety: %w", err)
+			}
+		}
+
+		if err := writeResult(out, cfg, result, func(p ddl.Phase) fmt.Stringer { return ddlpg.SplitPhases(result).Phase(p) }); err != nil {
+			return apperr.Errorf("writeResult: %w", err)
+		}
+
+		return unsafeErr
 	case ddlcrdb.Dialect:
-		leftDDL, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(srcDDL)).Parse()
+		leftParser := ddlcrdb.NewParser(ddlcrdb.NewLexer(srcDDL), ddlcrdb.ParserUseLenient(cfg.Lenient))
+		leftDDL, err := leftParser.Parse()
 		if err != nil {
-			return apperr.Errorf("pgddl.NewParser: %w", err)
+			return apperr.Errorf("pgddl.NewParser: %w", withFilename(err, src))
 		}
-		rightDDL, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(dstDDL)).Parse()
+		printParseWarnings(cfg.Warnings, leftParser.Warnings(), src)
+		rightParser := ddlcrdb.NewParser(ddlcrdb.NewLexer(dstDDL), ddlcrdb.ParserUseLenient(cfg.Lenient))
+		rightDDL, err := rightParser.Parse()
 		if err != nil {
-			return apperr.Errorf("pgddl.NewParser: %w", err)
+			return apperr.Errorf("pgddl.NewParser: %w", withFilename(err, dst))
 		}
+		printParseWarnings(cfg.Warnings, rightParser.Warnings(), dst)
 
 		result, err := ddlcrdb.Diff(leftDDL, rightDDL)
 		if err != nil {
 			return apperr.Errorf("pgddl.Diff: %w", err)
 		}
 
-		if _, err := io.WriteString(out, result.String()); err != nil {
-			return apperr.Errorf("io.WriteString: %w", err)
+		if ignores := cfg.Ignores; len(ignores) > 0 {
+			ignored, _, err := ddlcrdb.FilterTargets(leftDDL, result, ignores)
+			if err != nil {
+				return apperr.Errorf("pgddl.FilterTargets: %w", err)
+			}
+			result.Stmts = slices.DeleteFunc(result.Stmts, func(stmt ddlcrdb.Stmt) bool { return slices.Contains(ignored.Stmts, stmt) })
+		}
+		if targets := cfg.Targets; len(targets) > 0 {
+			var warnings []string
+			result, warnings, err = ddlcrdb.FilterTargets(leftDDL, result, targets)
+			if err != nil {
+				return apperr.Errorf("pgddl.FilterTargets: %w", err)
+			}
+			printTargetWarnings(cfg.Warnings, warnings)
+		}
+		if len(result.Stmts) == 0 {
+			return apperr.Errorf("targets=%v: ignores=%v: %w", cfg.Targets, cfg.Ignores, ddl.ErrNoDifference)
 		}
 
-		return nil
+		// MEMO: the findings are printed before the DDL, so that they are not buried under it.
+		var unsafeErr error
+		if cfg.CheckSafety || cfg.SafetyFindings != nil {
+			findings, err := ddllint.CheckSafetyCockroachDB(leftDDL, result, cfg.EngineVersion)
+			if err != nil {
+				return apperr.Errorf("ddllint.CheckSafetyCockroachDB: %w", err)
+			}
+			if err := reportSafety(cfg.Warnings, cfg, findings); err != nil {
+				unsafeErr = apperr.Errorf("reportSafety: %w", err)
+			}
+		}
+
+		if err := writeResult(out, cfg, result, func(p ddl.Phase) fmt.Stringer { return ddlcrdb.SplitPhases(result).Phase(p) }); err != nil {
+			return apperr.Errorf("writeResult: %w", err)
+		}
+
+		return unsafeErr
 	case ddlspanner.Dialect:
-		leftDDL, err := ddlspanner.NewParser(ddlspanner.NewLexer(srcDDL)).Parse()
+		leftParser := ddlspanner.NewParser(ddlspanner.NewLexer(srcDDL), ddlspanner.ParserUseLenient(cfg.Lenient))
+		leftDDL, err := leftParser.Parse()
 		if err != nil {
-			return apperr.Errorf("spanddl.NewParser: %w", err)
+			return apperr.Errorf("spanddl.NewParser: %w", withFilename(err, src))
 		}
-		rightDDL, err := ddlspanner.NewParser(ddlspanner.NewLexer(dstDDL)).Parse()
+		printParseWarnings(cfg.Warnings, leftParser.Warnings(), src)
+		rightParser := ddlspanner.NewParser(ddlspanner.NewLexer(dstDDL), ddlspanner.ParserUseLenient(cfg.Lenient))
+		rightDDL, err := rightParser.Parse()
 		if err != nil {
-			return apperr.Errorf("spanddl.NewParser: %w", err)
+			return apperr.Errorf("spanddl.NewParser: %w", withFilename(err, dst))
 		}
+		printParseWarnings(cfg.Warnings, rightParser.Warnings(), dst)
 
 		result, err := ddlspanner.Diff(leftDDL, rightDDL)
 		if err != nil {
 			return apperr.Errorf("spanddl.Diff: %w", err)
 		}
 
-		if _, err := io.WriteString(out, result.String()); err != nil {
-			return apperr.Errorf("io.WriteString: %w", err)
+		if ignores := cfg.Ignores; len(ignores) > 0 {
+			ignored, _, err := ddlspanner.FilterTargets(leftDDL, result, ignores)
+			if err != nil {
+				return apperr.Errorf("spanddl.FilterTargets: %w", err)
+			}
+			result.Stmts = slices.DeleteFunc(result.Stmts, func(stmt ddlspanner.Stmt) bool { return slices.Contains(ignored.Stmts, stmt) })
+		}
+		if targets := cfg.Targets; len(targets) > 0 {
+			var warnings []string
+			result, warnings, err = ddlspanner.FilterTargets(leftDDL, result, targets)
+			if err != nil {
+				return apperr.Errorf("spanddl.FilterTargets: %w", err)
+			}
+			printTargetWarnings(cfg.Warnings, warnings)
+		}
+		if len(result.Stmts) == 0 {
+			return apperr.Errorf("targets=%v: ignores=%v: %w", cfg.Targets, cfg.Ignores, ddl.ErrNoDifference)
 		}
 
-		return nil
+		// MEMO: the findings are printed before the DDL, so that they are not buried under it.
+		var unsafeErr error
+		if cfg.CheckSafety || cfg.SafetyFindings != nil {
+			findings, err := ddllint.CheckSafetySpanner(leftDDL, result, cfg.EngineVersion)
+			if err != nil {
+				return apperr.Errorf("ddllint.CheckSafetySpanner: %w", err)
+			}
+			if err := reportSafety(cfg.Warnings, cfg, findings); err != nil {
+				unsafeErr = apperr.Errorf("reportSafety: %w", err)
+			}
+		}
+
+		if err := writeResult(out, cfg, result, func(p ddl.Phase) fmt.Stringer { return ddlspanner.SplitPhases(result).Phase(p) }); err != nil {
+			return apperr.Errorf("writeResult: %w", err)
+		}
+
+		return unsafeErr
 	case "":
 		return apperr.Errorf("dialect=%s: %w", dialect, apperr.ErrDialectIsEmpty)
 	default:
@@ -0,0 +1,229 @@
+package diff
+
+import (
+	"io"
+	"os"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/config"
+	ddllint "github.com/hakadoriya/ddlctl/pkg/lint"
+)
+
+type DiffConfig struct {
+	// SafetyFindings is called with the findings of the safety checks of the result, even without CheckSafety.
+	SafetyFindings func(findings []*ddllint.SafetyFinding)
+	// Lenient skips the unsupported statements with the warnings instead of failing.
+	Lenient bool
+	// Ignores and Targets are the patterns of the tables to exclude from the result and to keep in it.
+	Ignores []string
+	Targets []string
+	// CheckSafety prints the findings of the safety checks of the result, and fails if any finding is of error severity.
+	CheckSafety bool
+	// EngineVersion is the version of the database engine for the safety checks.
+	EngineVersion string
+	// IndexConcurrently and SafeConstraints are the options of the postgres dialect.
+	IndexConcurrently bool
+	SafeConstraints   bool
+	// Phase writes only the DDL of the phase, and Phased writes the files of all the phases in PhaseDir.
+	Phase    string
+	Phased   bool
+	PhaseDir string
+	// Warnings is the writer of the warnings and the safety findings. Default: os.Stderr
+	Warnings io.Writer
+}
+
+type DiffOption interface {
+	apply(c *DiffConfig)
+}
+
+func newDiffConfig(opts []DiffOption) *DiffConfig {
+	cfg := &DiffConfig{Warnings: os.Stderr}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+	return cfg
+}
+
+// ConfigOptions returns the options of the command, such as --lenient and --target, for Diff.
+func ConfigOptions() []DiffOption {
+	opts := []DiffOption{
+		DiffWithLenient(config.Lenient()),
+		DiffWithIgnores(config.Ignores()),
+		DiffWithTargets(config.Targets()),
+		DiffWithCheckSafety(config.CheckSafety()),
+		DiffWithEngineVersion(config.EngineVersion()),
+		DiffWithIndexConcurrently(config.IndexConcurrently()),
+		DiffWithSafeConstraints(config.SafeConstraints()),
+		DiffWithPhase(config.Phase()),
+	}
+	if config.Phased() {
+		opts = append(opts, DiffWithPhaseDir(config.PhaseDir()))
+	}
+	return opts
+}
+
+// DiffWithSafetyFindings makes Diff check the safety of the result and pass the findings to f, even without --check-safety,
+// which only prints them and fails on the error findings.
+func DiffWithSafetyFindings(f func(findings []*ddllint.SafetyFinding)) DiffOption { //nolint:ireturn
+	return &diffConfigSafetyFindings{
+		safetyFindings: f,
+	}
+}
+
+type diffConfigSafetyFindings struct {
+	safetyFindings func(findings []*ddllint.SafetyFinding)
+}
+
+func (o *diffConfigSafetyFindings) apply(c *DiffConfig) {
+	c.SafetyFindings = o.safetyFindings
+}
+
+// DiffWithLenient makes Diff skip the unsupported statements with the warnings instead of failing, as --lenient.
+func DiffWithLenient(lenient bool) DiffOption { //nolint:ireturn
+	return &diffConfigLenient{
+		lenient: lenient,
+	}
+}
+
+type diffConfigLenient struct {
+	lenient bool
+}
+
+func (o *diffConfigLenient) apply(c *DiffConfig) {
+	c.Lenient = o.lenient
+}
+
+// DiffWithIgnores makes Diff exclude the tables matched by patterns from the result, as --ignore.
+func DiffWithIgnores(patterns []string) DiffOption { //nolint:ireturn
+	return &diffConfigIgnores{
+		ignores: patterns,
+	}
+}
+
+type diffConfigIgnores struct {
+	ignores []string
+}
+
+func (o *diffConfigIgnores) apply(c *DiffConfig) {
+	c.Ignores = o.ignores
+}
+
+// DiffWithTargets makes Diff keep only the tables matched by patterns in the result, as --target.
+func DiffWithTargets(patterns []string) DiffOption { //nolint:ireturn
+	return &diffConfigTargets{
+		targets: patterns,
+	}
+}
+
+type diffConfigTargets struct {
+	targets []string
+}
+
+func (o *diffConfigTargets) apply(c *DiffConfig) {
+	c.Targets = o.targets
+}
+
+// DiffWithCheckSafety makes Diff print the findings of the safety checks of the result and fail on the error findings, as --check-safety.
+func DiffWithCheckSafety(check bool) DiffOption { //nolint:ireturn
+	return &diffConfigCheckSafety{
+		checkSafety: check,
+	}
+}
+
+type diffConfigCheckSafety struct {
+	checkSafety bool
+}
+
+func (o *diffConfigCheckSafety) apply(c *DiffConfig) {
+	c.CheckSafety = o.checkSafety
+}
+
+// DiffWithEngineVersion sets the version of the database engine for the safety checks, as --engine-version.
+func DiffWithEngineVersion(version string) DiffOption { //nolint:ireturn
+	return &diffConfigEngineVersion{
+		engineVersion: version,
+	}
+}
+
+type diffConfigEngineVersion struct {
+	engineVersion string
+}
+
+func (o *diffConfigEngineVersion) apply(c *DiffConfig) {
+	c.EngineVersion = o.engineVersion
+}
+
+// DiffWithIndexConcurrently makes Diff create and drop the indexes concurrently on postgres, as --index-concurrently.
+func DiffWithIndexConcurrently(concurrently bool) DiffOption { //nolint:ireturn
+	return &diffConfigIndexConcurrently{
+		indexConcurrently: concurrently,
+	}
+}
+
+type diffConfigIndexConcurrently struct {
+	indexConcurrently bool
+}
+
+func (o *diffConfigIndexConcurrently) apply(c *DiffConfig) {
+	c.IndexConcurrently = o.indexConcurrently
+}
+
+// DiffWithSafeConstraints makes Diff add the constraints as NOT VALID and validate them separately on postgres, as --safe-constraints.
+func DiffWithSafeConstraints(safe bool) DiffOption { //nolint:ireturn
+	return &diffConfigSafeConstraints{
+		safeConstraints: safe,
+	}
+}
+
+type diffConfigSafeConstraints struct {
+	safeConstraints bool
+}
+
+func (o *diffConfigSafeConstraints) apply(c *DiffConfig) {
+	c.SafeConstraints = o.safeConstraints
+}
+
+// DiffWithPhase makes Diff write only the DDL of phase, such as expand, as --phase. Empty is the whole DDL.
+func DiffWithPhase(phase string) DiffOption { //nolint:ireturn
+	return &diffConfigPhase{
+		phase: phase,
+	}
+}
+
+type diffConfigPhase struct {
+	phase string
+}
+
+func (o *diffConfigPhase) apply(c *DiffConfig) {
+	c.Phase = o.phase
+}
+
+// DiffWithPhaseDir makes Diff write the files of all the phases in dir and their paths instead of the DDL, as --phased.
+func DiffWithPhaseDir(dir string) DiffOption { //nolint:ireturn
+	return &diffConfigPhaseDir{
+		phaseDir: dir,
+	}
+}
+
+type diffConfigPhaseDir struct {
+	phaseDir string
+}
+
+func (o *diffConfigPhaseDir) apply(c *DiffConfig) {
+	c.Phased = true
+	c.PhaseDir = o.phaseDir
+}
+
+// DiffWithWarnings makes Diff print the warnings and the safety findings to w instead of os.Stderr.
+func DiffWithWarnings(w io.Writer) DiffOption { //nolint:ireturn
+	return &diffConfigWarnings{
+		warnings: w,
+	}
+}
+
+type diffConfigWarnings struct {
+	warnings io.Writer
+}
+
+func (o *diffConfigWarnings) apply(c *DiffConfig) {
+	c.Warnings = o.warnings
+}
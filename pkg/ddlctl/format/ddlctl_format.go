@@ -0,0 +1,190 @@
+package format
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
+	"github.com/hakadoriya/ddlctl/pkg/internal/config"
+)
+
+//nolint:cyclop
+func Command(c *cliz.Command, args []string) error {
+	ctx := c.Context()
+	if _, err := config.Load(ctx); err != nil {
+		return apperr.Errorf("config.Load: %w", err)
+	}
+
+	if len(args) == 0 {
+		return apperr.Errorf("args=%v: %w", args, apperr.ErrOneOrMoreArgumentsRequired)
+	}
+
+	dialect := config.Dialect()
+	unformatted := make([]string, 0)
+	for _, filename := range args {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return apperr.Errorf("os.Stat: %w", err)
+		}
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return apperr.Errorf("os.ReadFile: %w", err)
+		}
+
+		formatted, err := Format(dialect, string(src))
+		if err != nil {
+			for _, parseErr := range ddl.AsParseErrors(err) {
+				parseErr.Filename = filename
+			}
+			diff.PrintParseError(os.Stderr, err)
+			return apperr.Errorf("file=%s: Format: %w", filename, err)
+		}
+
+		if formatted == string(src) {
+			continue
+		}
+
+		if config.Check() {
+			// NOTE: like `gofmt -l`, print the files that are not formatted.
+			if _, err := fmt.Fprintln(os.Stdout, filename); err != nil {
+				return apperr.Errorf("fmt.Fprintln: %w", err)
+			}
+			unformatted = append(unformatted, filename)
+			continue
+		}
+
+		if err := os.WriteFile(filename, []byte(formatted), info.Mode().Perm()); err != nil {
+			return apperr.Errorf("os.WriteFile: %w", err)
+		}
+	}
+
+	if len(unformatted) > 0 {
+		return apperr.Errorf("files=%v: %w", unformatted, apperr.ErrNotFormatted)
+	}
+
+	return nil
+}
+
+// Format parses src as DDL of dialect and returns it in the canonical layout.
+// Comments that precede statements, column definitions and table constraints, the ones inside column definitions
+// and the ones before the closing parenthesis of CREATE TABLE are kept.
+// If any other comment in src would be lost, such as the one inside CREATE INDEX, Format returns apperr.ErrCommentsLost.
+//
+//nolint:cyclop
+func Format(dialect, src string) (string, error) {
+	var formatted string
+	var comments func(s string) []string
+	switch dialect {
+	case ddlmysql.Dialect:
+		d, err := ddlmysql.NewParser(ddlmysql.NewLexer(src)).Parse()
+		if err != nil {
+			return "", apperr.Errorf("myddl.NewParser: %w", err)
+		}
+		formatted, comments = ddlmysql.Format(d), mysqlComments
+	case ddlpg.Dialect:
+		d, err := ddlpg.NewParser(ddlpg.NewLexer(src)).Parse()
+		if err != nil {
+			return "", apperr.Errorf("pgddl.NewParser: %w", err)
+		}
+		formatted, comments = ddlpg.Format(d), postgresComments
+	case ddlcrdb.Dialect:
+		d, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(src)).Parse()
+		if err != nil {
+			return "", apperr.Errorf("crdbddl.NewParser: %w", err)
+		}
+		formatted, comments = ddlcrdb.Format(d), cockroachdbComments
+	case ddlspanner.Dialect:
+		d, err := ddlspanner.NewParser(ddlspanner.NewLexer(src)).Parse()
+		if err != nil {
+			return "", apperr.Errorf("spanddl.NewParser: %w", err)
+		}
+		formatted, comments = ddlspanner.Format(d), spannerComments
+	case "":
+		return "", apperr.Errorf("dialect=%s: %w", dialect, apperr.ErrDialectIsEmpty)
+	default:
+		return "", apperr.Errorf("dialect=%s: %w", dialect, apperr.ErrNotSupported)
+	}
+
+	if err := checkComments(comments(src), comments(formatted)); err != nil {
+		return "", apperr.Errorf("checkComments: %w", err)
+	}
+	return formatted, nil
+}
+
+// checkComments returns apperr.ErrCommentsLost if any comment of src is not in formatted,
+// so that `ddlctl fmt` does not overwrite the file with the comments that the parser does not keep.
+func checkComments(src, formatted []string) error {
+	counts := make(map[string]int)
+	for _, comment := range formatted {
+		counts[comment]++
+	}
+	var lost []string
+	for _, comment := range src {
+		if comment == "" {
+			continue
+		}
+		if counts[comment] > 0 {
+			counts[comment]--
+			continue
+		}
+		lost = append(lost, comment)
+	}
+	if len(lost) > 0 {
+		return apperr.Errorf("comments=%q: %w", lost, apperr.ErrCommentsLost)
+	}
+	return nil
+}
+
+// MEMO: the tokens of each dialect are different types, so the comments are lexed by each function below.
+
+func mysqlComments(s string) []string {
+	var comments []string
+	for l := ddlmysql.NewLexer(s); ; {
+		tok := l.NextToken()
+		comments = append(comments, tok.Comments...)
+		if tok.Type == ddlmysql.TOKEN_EOF {
+			return comments
+		}
+	}
+}
+
+func postgresComments(s string) []string {
+	var comments []string
+	for l := ddlpg.NewLexer(s); ; {
+		tok := l.NextToken()
+		comments = append(comments, tok.Comments...)
+		if tok.Type == ddlpg.TOKEN_EOF {
+			return comments
+		}
+	}
+}
+
+func cockroachdbComments(s string) []string {
+	var comments []string
+	for l := ddlcrdb.NewLexer(s); ; {
+		tok := l.NextToken()
+		comments = append(comments, tok.Comments...)
+		if tok.Type == ddlcrdb.TOKEN_EOF {
+			return comments
+		}
+	}
+}
+
+func spannerComments(s string) []string {
+	var comments []string
+	for l := ddlspanner.NewLexer(s); ; {
+		tok := l.NextToken()
+		comments = append(comments, tok.Comments...)
+		if tok.Type == ddlspanner.TOKEN_EOF {
+			return comments
+		}
+	}
+}
@@ -0,0 +1,90 @@
+//nolint:testpackage
+package format
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect  string
+		input    string
+		expected string
+	}{
+		{
+			dialect:  ddlpg.Dialect,
+			input:    "CREATE TABLE users (\n id INTEGER -- inside column\n NOT NULL,\n name TEXT\n -- before close\n);",
+			expected: "CREATE TABLE users (\n    -- inside column\n    id   INTEGER NOT NULL,\n    name TEXT\n    -- before close\n);\n",
+		},
+		{
+			dialect:  ddlcrdb.Dialect,
+			input:    "CREATE TABLE users (\n id INTEGER -- inside column\n NOT NULL,\n name TEXT\n -- before close\n);",
+			expected: "CREATE TABLE users (\n    -- inside column\n    id   INTEGER NOT NULL,\n    name TEXT\n    -- before close\n);\n",
+		},
+		{
+			dialect:  ddlmysql.Dialect,
+			input:    "CREATE TABLE users (\n id INTEGER -- inside column\n NOT NULL,\n name TEXT\n -- before close\n);",
+			expected: "CREATE TABLE users (\n    -- inside column\n    id   INTEGER NOT NULL,\n    name TEXT NULL\n    -- before close\n);\n",
+		},
+		{
+			dialect:  ddlspanner.Dialect,
+			input:    "CREATE TABLE users (\n id INT64 -- inside column\n NOT NULL,\n name STRING(36)\n -- before close\n) PRIMARY KEY (id);",
+			expected: "CREATE TABLE users (\n    -- inside column\n    id   INT64 NOT NULL,\n    name STRING(36)\n    -- before close\n) PRIMARY KEY (id);\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("success,"+tt.dialect, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Format(tt.dialect, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+
+		t.Run("failure,apperr.ErrCommentsLost,"+tt.dialect, func(t *testing.T) {
+			t.Parallel()
+
+			// MEMO: the parser does not keep the comment inside CREATE INDEX, so the file must not be overwritten without it.
+			_, err := Format(tt.dialect, "CREATE INDEX users_idx_name ON users -- lost\n (name);\n")
+			require.ErrorIs(t, err, apperr.ErrCommentsLost)
+		})
+	}
+
+	t.Run("failure,apperr.ErrDialectIsEmpty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Format("", "")
+		require.ErrorIs(t, err, apperr.ErrDialectIsEmpty)
+	})
+}
+
+func TestCheckComments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		// MEMO: the order does not matter, and the blank lines are not comments.
+		require.NoError(t, checkComments([]string{"a", "", "b", "a"}, []string{"b", "a", "a"}))
+	})
+
+	t.Run("failure,apperr.ErrCommentsLost", func(t *testing.T) {
+		t.Parallel()
+
+		err := checkComments([]string{"a", "b", "a"}, []string{"a", "b"})
+		require.ErrorIs(t, err, apperr.ErrCommentsLost)
+		require.ErrorContains(t, err, `comments=["a"]`)
+	})
+}
@@ -1,10 +1,12 @@
 package generate
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/hakadoriya/z.go/cliz"
 
@@ -25,6 +27,16 @@ func Command(c *cliz.Command, args []string) error {
 		return apperr.Errorf("config.Load: %w", err)
 	}
 
+	if outputs := config.Outputs(); len(outputs) > 0 {
+		if len(args) != 1 {
+			return apperr.Errorf("args=%v: %w", args, apperr.ErrOneArgumentRequired)
+		}
+		if err := commandOutputs(ctx, args[0], config.Language(), outputs); err != nil {
+			return apperr.Errorf("commandOutputs: %w", err)
+		}
+		return nil
+	}
+
 	dialect := config.Dialect()
 	language := config.Language()
 	src := args[0]
@@ -35,12 +47,7 @@ func Command(c *cliz.Command, args []string) error {
 	logs.Info.Printf("source: %s", src)
 	logs.Info.Printf("destination: %s", dst)
 
-	if info, err := os.Stat(dst); err == nil && info.IsDir() {
-		dst = filepath.Join(dst, "ddlctl.gen.sql")
-	}
-
-	const rw_r__r__ = 0o644 //nolint:revive
-	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, rw_r__r__)
+	dstFile, err := os.OpenFile(destination(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, rw_r__r__)
 	if err != nil {
 		return apperr.Errorf("os.OpenFile: %w", err)
 	}
@@ -51,6 +58,49 @@ func Command(c *cliz.Command, args []string) error {
 	return nil
 }
 
+const rw_r__r__ = 0o644 //nolint:revive
+
+// destination returns dst, or ddlctl.gen.sql in dst if dst is a directory.
+func destination(dst string) string {
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		return filepath.Join(dst, "ddlctl.gen.sql")
+	}
+	return dst
+}
+
+// commandOutputs generates the DDL of each output of --output from src, which is parsed once for all the outputs.
+// The files are written after all the DDL are generated, not to leave some of them updated on failure.
+func commandOutputs(ctx context.Context, src, language string, outputs []*config.Output) error {
+	ctx = ddlctlgo.WithParseCache(ctx)
+
+	logs.Info.Printf("language: %s", language)
+	logs.Info.Printf("source: %s", src)
+
+	dsts := make([]string, len(outputs))
+	bufs := make([]*bytes.Buffer, len(outputs))
+	for i, o := range outputs {
+		dsts[i] = destination(o.Path)
+		if j := slices.Index(dsts[:i], dsts[i]); j >= 0 {
+			return apperr.Errorf("output=%s: the destination is the same as output=%s: %w", o, outputs[j], apperr.ErrInvalidOutput)
+		}
+		logs.Info.Printf("output: dialect=%s go-ddl-tag=%s destination=%s", o.Dialect, o.DDLTagGo, dsts[i])
+
+		opts := ddlctlgo.ConfigOptions()
+		opts.DDL = o.DDLTagGo
+		bufs[i] = bytes.NewBuffer(nil)
+		if err := Generate(ddlctlgo.WithOptions(ctx, opts), bufs[i], src, o.Dialect, language); err != nil {
+			return apperr.Errorf("output=%s: Generate: %w", o, err)
+		}
+	}
+
+	for i, dst := range dsts {
+		if err := os.WriteFile(dst, bufs[i].Bytes(), rw_r__r__); err != nil {
+			return apperr.Errorf("os.WriteFile: %w", err)
+		}
+	}
+	return nil
+}
+
 func Generate(ctx context.Context, dst io.Writer, src, dialect, language string) error {
 	ddl, err := Parse(ctx, language, src)
 	if err != nil {
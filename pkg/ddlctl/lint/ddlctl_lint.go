@@ -0,0 +1,225 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hakadoriya/z.go/buildinfoz"
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+	"github.com/hakadoriya/ddlctl/pkg/ddlctl/diff"
+	"github.com/hakadoriya/ddlctl/pkg/internal/config"
+	ddllint "github.com/hakadoriya/ddlctl/pkg/lint"
+)
+
+const (
+	FormatText  = "text"
+	FormatSARIF = "sarif"
+)
+
+//nolint:cyclop
+func Command(c *cliz.Command, args []string) error {
+	ctx := c.Context()
+	if _, err := config.Load(ctx); err != nil {
+		return apperr.Errorf("config.Load: %w", err)
+	}
+
+	args, err := config.ResolveDSNs(ctx, args)
+	if err != nil {
+		return apperr.Errorf("config.ResolveDSNs: %w", err)
+	}
+
+	// NOTE: lint <DDL source>, or lint <before DDL source> <after DDL source> to check the changes as well.
+	var before, after string
+	switch len(args) {
+	case 1:
+		after = args[0]
+	case 2: //nolint:mnd
+		before, after = args[0], args[1]
+	default:
+		return apperr.Errorf("args=%v: %w", args, apperr.ErrOneOrTwoArgumentsRequired)
+	}
+
+	format := config.Format()
+	if format != FormatText && format != FormatSARIF {
+		return apperr.Errorf("format=%s: %w", format, apperr.ErrNotSupported)
+	}
+
+	var cfg *ddllint.Config
+	if filename := config.LintConfig(); filename != "" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return apperr.Errorf("os.Open: %w", err)
+		}
+		defer f.Close()
+		cfg, err = ddllint.LoadConfig(f)
+		if err != nil {
+			return apperr.Errorf("lint.LoadConfig: %w", err)
+		}
+	}
+
+	findings, err := Lint(ctx, config.Dialect(), config.Language(), cfg, before, after)
+	if err != nil {
+		diff.PrintParseError(os.Stderr, err)
+		return apperr.Errorf("Lint: %w", err)
+	}
+
+	if err := Write(os.Stdout, format, findings); err != nil {
+		return apperr.Errorf("Write: %w", err)
+	}
+
+	if ddllint.HasErrors(findings) {
+		return apperr.Errorf("findings=%d: %w", len(findings), apperr.ErrLintFindingsFound)
+	}
+
+	return nil
+}
+
+// Write writes findings to w in format, which is FormatText or FormatSARIF.
+func Write(w io.Writer, format string, findings []*ddllint.Finding) error {
+	switch format {
+	case FormatSARIF:
+		if err := ddllint.WriteSARIF(w, findings, buildinfoz.BuildVersion()); err != nil {
+			return apperr.Errorf("lint.WriteSARIF: %w", err)
+		}
+	default:
+		for _, f := range findings {
+			if _, err := fmt.Fprintln(w, f.String()); err != nil {
+				return apperr.Errorf("fmt.Fprintln: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// withFilename sets arg as the filename of each *ddl.ParseError in err, if arg is a SQL file.
+func withFilename(err error, arg string) error {
+	if isFile(arg) {
+		for _, parseErr := range ddl.AsParseErrors(err) {
+			parseErr.Filename = arg
+		}
+	}
+	return err
+}
+
+// Lint runs the lint rules over the DDL of after.
+// If before is not empty, the changes from before to after, such as the columns added, are checked as well.
+//
+//nolint:cyclop,funlen,gocognit
+func Lint(ctx context.Context, dialect, language string, cfg *ddllint.Config, before, after string) ([]*ddllint.Finding, error) {
+	afterDDL, err := diff.Resolve(ctx, language, dialect, after)
+	if err != nil {
+		return nil, apperr.Errorf("Resolve: %w", err)
+	}
+	var beforeDDL string
+	if before != "" {
+		beforeDDL, err = diff.Resolve(ctx, language, dialect, before)
+		if err != nil {
+			return nil, apperr.Errorf("Resolve: %w", err)
+		}
+	}
+
+	var schema *ddllint.Schema
+	switch dialect {
+	case ddlmysql.Dialect:
+		right, err := ddlmysql.NewParser(ddlmysql.NewLexer(afterDDL)).Parse()
+		if err != nil {
+			return nil, apperr.Errorf("myddl.NewParser: %w", withFilename(err, after))
+		}
+		schema = ddllint.FromMySQL(right)
+		if before != "" {
+			left, err := ddlmysql.NewParser(ddlmysql.NewLexer(beforeDDL)).Parse()
+			if err != nil {
+				return nil, apperr.Errorf("myddl.NewParser: %w", withFilename(err, before))
+			}
+			result, err := ddlmysql.Diff(left, right)
+			if err != nil && !errors.Is(err, ddl.ErrNoDifference) {
+				return nil, apperr.Errorf("myddl.Diff: %w", err)
+			}
+			schema.AddedColumns = ddllint.FromMySQL(result).AddedColumns
+		}
+	case ddlpg.Dialect:
+		right, err := ddlpg.NewParser(ddlpg.NewLexer(afterDDL)).Parse()
+		if err != nil {
+			return nil, apperr.Errorf("pgddl.NewParser: %w", withFilename(err, after))
+		}
+		schema = ddllint.FromPostgres(right)
+		if before != "" {
+			left, err := ddlpg.NewParser(ddlpg.NewLexer(beforeDDL)).Parse()
+			if err != nil {
+				return nil, apperr.Errorf("pgddl.NewParser: %w", withFilename(err, before))
+			}
+			result, err := ddlpg.Diff(left, right)
+			if err != nil && !errors.Is(err, ddl.ErrNoDifference) {
+				return nil, apperr.Errorf("pgddl.Diff: %w", err)
+			}
+			schema.AddedColumns = ddllint.FromPostgres(result).AddedColumns
+		}
+	case ddlcrdb.Dialect:
+		right, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(afterDDL)).Parse()
+		if err != nil {
+			return nil, apperr.Errorf("crdbddl.NewParser: %w", withFilename(err, after))
+		}
+		schema = ddllint.FromCockroachDB(right)
+		if before != "" {
+			left, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(beforeDDL)).Parse()
+			if err != nil {
+				return nil, apperr.Errorf("crdbddl.NewParser: %w", withFilename(err, before))
+			}
+			result, err := ddlcrdb.Diff(left, right)
+			if err != nil && !errors.Is(err, ddl.ErrNoDifference) {
+				return nil, apperr.Errorf("crdbddl.Diff: %w", err)
+			}
+			schema.AddedColumns = ddllint.FromCockroachDB(result).AddedColumns
+		}
+	case ddlspanner.Dialect:
+		right, err := ddlspanner.NewParser(ddlspanner.NewLexer(afterDDL)).Parse()
+		if err != nil {
+			return nil, apperr.Errorf("spanddl.NewParser: %w", withFilename(err, after))
+		}
+		schema = ddllint.FromSpanner(right)
+		if before != "" {
+			left, err := ddlspanner.NewParser(ddlspanner.NewLexer(beforeDDL)).Parse()
+			if err != nil {
+				return nil, apperr.Errorf("spanddl.NewParser: %w", withFilename(err, before))
+			}
+			result, err := ddlspanner.Diff(left, right)
+			if err != nil && !errors.Is(err, ddl.ErrNoDifference) {
+				return nil, apperr.Errorf("spanddl.Diff: %w", err)
+			}
+			schema.AddedColumns = ddllint.FromSpanner(result).AddedColumns
+		}
+	case "":
+		return nil, apperr.Errorf("dialect=%s: %w", dialect, apperr.ErrDialectIsEmpty)
+	default:
+		return nil, apperr.Errorf("dialect=%s: %w", dialect, apperr.ErrNotSupported)
+	}
+
+	findings, err := ddllint.Lint(schema, cfg)
+	if err != nil {
+		return nil, apperr.Errorf("lint.Lint: %w", err)
+	}
+
+	// NOTE: the positions of the findings are the ones in after, including the columns added.
+	if isFile(after) {
+		for _, f := range findings {
+			f.Filename = after
+		}
+	}
+
+	return findings, nil
+}
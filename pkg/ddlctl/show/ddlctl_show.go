@@ -2,6 +2,7 @@ package show
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"io"
@@ -28,7 +29,18 @@ func Command(c *cliz.Command, args []string) error {
 		return apperr.Errorf("config.Load: %w", err)
 	}
 
-	ddl, err := Show(ctx, config.Dialect(), args[0])
+	if len(args) == 0 && config.DSN() != "" {
+		args = []string{config.DSN()}
+	}
+	if len(args) != 1 {
+		return apperr.Errorf("args=%v: %w", args, apperr.ErrOneArgumentRequired)
+	}
+	dsn, err := config.ResolveDSN(ctx, args[0])
+	if err != nil {
+		return apperr.Errorf("config.ResolveDSN: %w", err)
+	}
+
+	ddl, err := Show(ctx, config.Dialect(), dsn)
 	if err != nil {
 		return apperr.Errorf("diff: %w", err)
 	}
@@ -40,7 +52,6 @@ func Command(c *cliz.Command, args []string) error {
 	return nil
 }
 
-//nolint:cyclop,funlen,gocognit
 func Show(ctx context.Context, dialect string, dsn string) (ddl string, err error) {
 	driverName := func() string {
 		switch dialect {
@@ -64,6 +75,15 @@ func Show(ctx context.Context, dialect string, dsn string) (ddl string, err erro
 		}
 	}()
 
+	ddl, err = ShowDB(ctx, dialect, db)
+	if err != nil {
+		return "", apperr.Errorf("ShowDB: %w", err)
+	}
+	return ddl, nil
+}
+
+// ShowDB returns the DDL of all the tables in db, which is opened by the caller.
+func ShowDB(ctx context.Context, dialect string, db *sql.DB) (string, error) {
 	switch dialect {
 	case myddl.Dialect:
 		ddl, err := myshow.ShowCreateAllTables(ctx, db)
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"context"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+func loadCheck(_ context.Context, cmd *cliz.Command) bool {
+	v, _ := cmd.GetOptionBool(consts.OptionCheck)
+	return v
+}
+
+func Check() bool {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.Check
+}
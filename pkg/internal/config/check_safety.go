@@ -0,0 +1,20 @@
+package config
+
+import (
+	"context"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+func loadCheckSafety(_ context.Context, cmd *cliz.Command) bool {
+	v, _ := cmd.GetOptionBool(consts.OptionCheckSafety)
+	return v
+}
+
+func CheckSafety() bool {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.CheckSafety
+}
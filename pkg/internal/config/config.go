@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/hakadoriya/z.go/cliz"
 	"github.com/hakadoriya/z.go/errorz"
@@ -16,16 +17,45 @@ import (
 //
 //nolint:tagliatelle
 type config struct {
-	Version     bool   `json:"version"`
-	Trace       bool   `json:"trace"`
-	Debug       bool   `json:"debug"`
-	Language    string `json:"language"`
-	Dialect     string `json:"dialect"`
-	AutoApprove bool   `json:"auto_approve"`
+	Version          bool          `json:"version"`
+	Trace            bool          `json:"trace"`
+	Debug            bool          `json:"debug"`
+	Env              string        `json:"env"`
+	Language         string        `json:"language"`
+	Dialect          string        `json:"dialect"`
+	AutoApprove      bool          `json:"auto_approve"`
+	Check            bool          `json:"check"`
+	Lenient          bool          `json:"lenient"`
+	Format           string        `json:"format"`
+	LintConfig       string        `json:"lint_config"`
+	CheckSafety      bool          `json:"check_safety"`
+	EngineVersion    string        `json:"engine_version"`
+	Phased           bool          `json:"phased"`
+	PhaseDir         string        `json:"phase_dir"`
+	Phase            string        `json:"phase"`
+	ShadowDSN        string        `json:"shadow_dsn"`
+	ApplyLockWait    time.Duration `json:"apply_lock_wait"`
+	LockTimeout      time.Duration `json:"lock_timeout"`
+	StatementTimeout time.Duration `json:"statement_timeout"`
+	Resume           bool          `json:"resume"`
+	StateFile        string        `json:"state_file"`
+	Targets          []string      `json:"targets"`
+	Interactive      bool          `json:"interactive"`
+	RecordFile       string        `json:"record_file"`
+	Hooks            string        `json:"hooks"`
+	DSN              string        `json:"dsn"`
+	Source           string        `json:"source"`
+	Ignores          []string      `json:"ignores"`
+	Outputs          []*Output     `json:"outputs"`
+	// PostgreSQL
+	IndexConcurrently bool `json:"index_concurrently"`
+	SafeConstraints   bool `json:"safe_constraints"`
 	// Golang
-	ColumnTagGo string `json:"column_tag_go"`
-	DDLTagGo    string `json:"ddl_tag_go"`
-	PKTagGo     string `json:"pk_tag_go"`
+	ColumnTagGo     string            `json:"column_tag_go"`
+	DDLTagGo        string            `json:"ddl_tag_go"`
+	PKTagGo         string            `json:"pk_tag_go"`
+	InferTypeGo     bool              `json:"infer_type_go"`
+	TypeOverridesGo map[string]string `json:"type_overrides_go"`
 }
 
 //nolint:gochecknoglobals
@@ -64,31 +94,80 @@ func Load(ctx context.Context) (rollback func(), err error) {
 	return rollback, nil
 }
 
-// MEMO: Since there is a possibility of returning some kind of error in the future, the signature is made to return an error.
-//
 //nolint:funlen
-func load(ctx context.Context) (cfg *config, err error) { //nolint:unparam
+func load(ctx context.Context) (cfg *config, err error) {
 	cmd := cliz.MustFromContext(ctx)
 
+	applyLockWait, err := loadApplyLockWait(ctx, cmd)
+	if err != nil {
+		return nil, apperr.Errorf("loadApplyLockWait: %w", err)
+	}
+	lockTimeout, err := loadLockTimeout(ctx, cmd)
+	if err != nil {
+		return nil, apperr.Errorf("loadLockTimeout: %w", err)
+	}
+	statementTimeout, err := loadStatementTimeout(ctx, cmd)
+	if err != nil {
+		return nil, apperr.Errorf("loadStatementTimeout: %w", err)
+	}
+	outputs, err := loadOutputs(ctx, cmd)
+	if err != nil {
+		return nil, apperr.Errorf("loadOutputs: %w", err)
+	}
+	typeOverridesGo, err := loadTypeOverridesGo(ctx, cmd)
+	if err != nil {
+		return nil, apperr.Errorf("loadTypeOverridesGo: %w", err)
+	}
+
 	c := &config{
-		Trace:       loadTrace(ctx, cmd),
-		Debug:       loadDebug(ctx, cmd),
-		Language:    loadLanguage(ctx, cmd),
-		Dialect:     loadDialect(ctx, cmd),
-		AutoApprove: loadAutoApprove(ctx, cmd),
-		ColumnTagGo: loadColumnTagGo(ctx, cmd),
-		DDLTagGo:    loadDDLTagGo(ctx, cmd),
-		PKTagGo:     loadPKTagGo(ctx, cmd),
+		Trace:            loadTrace(ctx, cmd),
+		Debug:            loadDebug(ctx, cmd),
+		Env:              loadEnv(ctx, cmd),
+		Language:         loadLanguage(ctx, cmd),
+		Dialect:          loadDialect(ctx, cmd),
+		AutoApprove:      loadAutoApprove(ctx, cmd),
+		Check:            loadCheck(ctx, cmd),
+		Lenient:          loadLenient(ctx, cmd),
+		Format:           loadFormat(ctx, cmd),
+		LintConfig:       loadLintConfig(ctx, cmd),
+		CheckSafety:      loadCheckSafety(ctx, cmd),
+		EngineVersion:    loadEngineVersion(ctx, cmd),
+		Phased:           loadPhased(ctx, cmd),
+		PhaseDir:         loadPhaseDir(ctx, cmd),
+		Phase:            loadPhase(ctx, cmd),
+		ShadowDSN:        loadShadowDSN(ctx, cmd),
+		ApplyLockWait:    applyLockWait,
+		LockTimeout:      lockTimeout,
+		StatementTimeout: statementTimeout,
+		Resume:           loadResume(ctx, cmd),
+		StateFile:        loadStateFile(ctx, cmd),
+		Targets:          loadTargets(ctx, cmd),
+		Interactive:      loadInteractive(ctx, cmd),
+		RecordFile:       loadRecordFile(ctx, cmd),
+		Hooks:            loadHooks(ctx, cmd),
+		DSN:              loadDSN(ctx, cmd),
+		Source:           loadSource(ctx, cmd),
+		Ignores:          loadIgnores(ctx, cmd),
+		Outputs:          outputs,
+		// PostgreSQL
+		IndexConcurrently: loadIndexConcurrently(ctx, cmd),
+		SafeConstraints:   loadSafeConstraints(ctx, cmd),
+		// Golang
+		ColumnTagGo:     loadColumnTagGo(ctx, cmd),
+		DDLTagGo:        loadDDLTagGo(ctx, cmd),
+		PKTagGo:         loadPKTagGo(ctx, cmd),
+		InferTypeGo:     loadInferTypeGo(ctx, cmd),
+		TypeOverridesGo: typeOverridesGo,
 	}
 
 	switch {
 	case c.Trace:
-		apperr.Errorf = errorz.Errorf //nolint:reassign
+		apperr.Errorf = apperr.WithRedaction(errorz.Errorf) //nolint:reassign
 		logs.Trace = logs.NewTrace()
 		logs.Debug = logs.NewDebug()
 		logs.Trace.Print("trace mode enabled")
 	case c.Debug:
-		apperr.Errorf = errorz.Errorf //nolint:reassign
+		apperr.Errorf = apperr.WithRedaction(errorz.Errorf) //nolint:reassign
 		logs.Debug = logs.NewDebug()
 		logs.Debug.Print("debug mode enabled")
 	}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hakadoriya/z.go/cliz"
+	"golang.org/x/term"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+	"github.com/hakadoriya/ddlctl/pkg/internal/redact"
+)
+
+func loadDSN(_ context.Context, cmd *cliz.Command) string {
+	v, _ := cmd.GetOptionString(consts.OptionDSN)
+	return v
+}
+
+func DSN() string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.DSN
+}
+
+// Args returns args, or --dsn and --source if args is empty and both are set,
+// such as by an environment of the project file.
+func Args(args []string) []string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	if len(args) == 0 && globalConfig.DSN != "" && globalConfig.Source != "" {
+		return []string{globalConfig.DSN, globalConfig.Source}
+	}
+	return args
+}
+
+// The prefixes of the DSN references, which keep the credentials out of the arguments, the shell history and ps.
+const (
+	DSNReferenceEnv    = "env:"
+	DSNReferenceFile   = "file:"
+	DSNReferencePrompt = "prompt:"
+)
+
+// ResolveDSN returns the DSN that ref refers to, or ref itself if it is not a reference, such as a DSN or a DDL source:
+//
+//   - env:NAME is the value of the environment variable NAME.
+//   - file:PATH is the content of the file at PATH, such as a Docker secret, without the leading and trailing spaces.
+//   - prompt:DSN is DSN with the password entered on the terminal, where DSN may be a reference too, such as prompt:env:NAME.
+//
+// The password of the DSN is registered to be redacted from the logs and the errors.
+func ResolveDSN(ctx context.Context, ref string) (string, error) {
+	var dsn string
+	switch {
+	case strings.HasPrefix(ref, DSNReferenceEnv):
+		name := strings.TrimPrefix(ref, DSNReferenceEnv)
+		dsn = os.Getenv(name)
+		if dsn == "" {
+			return "", apperr.Errorf("ref=%s: environment variable is not set: %w", ref, apperr.ErrInvalidDSNReference)
+		}
+	case strings.HasPrefix(ref, DSNReferenceFile):
+		b, err := os.ReadFile(strings.TrimPrefix(ref, DSNReferenceFile))
+		if err != nil {
+			return "", apperr.Errorf("os.ReadFile: %w", err)
+		}
+		dsn = strings.TrimSpace(string(b))
+		if dsn == "" {
+			return "", apperr.Errorf("ref=%s: file is empty: %w", ref, apperr.ErrInvalidDSNReference)
+		}
+	case strings.HasPrefix(ref, DSNReferencePrompt):
+		inner, err := ResolveDSN(ctx, strings.TrimPrefix(ref, DSNReferencePrompt))
+		if err != nil {
+			return "", apperr.Errorf("ResolveDSN: %w", err)
+		}
+		password, err := promptPassword(inner)
+		if err != nil {
+			return "", apperr.Errorf("promptPassword: %w", err)
+		}
+		dsn, err = withPassword(inner, password)
+		if err != nil {
+			return "", apperr.Errorf("withPassword: %w", err)
+		}
+	default:
+		dsn = ref
+	}
+
+	redact.Register(dsn)
+	return dsn, nil
+}
+
+// ResolveDSNs returns args with the DSN references resolved by ResolveDSN.
+func ResolveDSNs(ctx context.Context, args []string) ([]string, error) {
+	resolved := make([]string, 0, len(args))
+	for _, arg := range args {
+		dsn, err := ResolveDSN(ctx, arg)
+		if err != nil {
+			return nil, apperr.Errorf("ResolveDSN: %w", err)
+		}
+		resolved = append(resolved, dsn)
+	}
+	return resolved, nil
+}
+
+// promptPassword asks the password of dsn on the terminal without the echo, even if stdin is redirected.
+func promptPassword(dsn string) (string, error) {
+	in, out := os.Stdin, os.Stderr
+	// MEMO: /dev/tty is the terminal even if stdin is redirected, and stdin is the console on Windows, which does not have /dev/tty.
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		defer tty.Close()
+		in, out = tty, tty
+	}
+
+	_, _ = fmt.Fprintf(out, "password for %s: ", redact.String(dsn))
+	defer func() { _, _ = io.WriteString(out, "\n") }()
+
+	password, err := term.ReadPassword(int(in.Fd()))
+	if err != nil {
+		return "", apperr.Errorf("term.ReadPassword: %w", err)
+	}
+	return string(password), nil
+}
+
+// withPassword returns dsn with password, replacing the one in dsn if any.
+func withPassword(dsn, password string) (string, error) {
+	switch {
+	case strings.Contains(dsn, "://"): // NOTE: URL such as postgres://user@host/db
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", apperr.Errorf("url.Parse: %w", err)
+		}
+		if u.User == nil {
+			return "", apperr.Errorf("dsn=%s: user is required: %w", dsn, apperr.ErrInvalidDSNReference)
+		}
+		u.User = url.UserPassword(u.User.Username(), password)
+		return u.String(), nil
+	case strings.Contains(dsn, "@"): // NOTE: MySQL such as user@tcp(host:3306)/db
+		i := strings.LastIndex(dsn, "@")
+		user, _, _ := strings.Cut(dsn[:i], ":")
+		return user + ":" + password + dsn[i:], nil
+	case strings.Contains(dsn, "="): // NOTE: key/value such as host=localhost user=user
+		escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(password)
+		return dsn + " password='" + escaped + "'", nil
+	default:
+		return "", apperr.Errorf("dsn=%s: password is not supported: %w", dsn, apperr.ErrInvalidDSNReference)
+	}
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"context"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+func loadEngineVersion(_ context.Context, cmd *cliz.Command) string {
+	v, _ := cmd.GetOptionString(consts.OptionEngineVersion)
+	return v
+}
+
+func EngineVersion() string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.EngineVersion
+}
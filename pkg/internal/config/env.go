@@ -0,0 +1,20 @@
+package config
+
+import (
+	"context"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+func loadEnv(_ context.Context, cmd *cliz.Command) string {
+	v, _ := cmd.GetOptionString(consts.OptionEnv)
+	return v
+}
+
+func Env() string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.Env
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+// loadIgnores returns the comma-separated values of --ignore, such as schema_migrations,tmp_*.
+func loadIgnores(_ context.Context, cmd *cliz.Command) []string {
+	v, _ := cmd.GetOptionString(consts.OptionIgnore)
+	ignores := make([]string, 0)
+	for _, ignore := range strings.Split(v, ",") {
+		if ignore = strings.TrimSpace(ignore); ignore != "" {
+			ignores = append(ignores, ignore)
+		}
+	}
+	return ignores
+}
+
+func Ignores() []string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.Ignores
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+func loadLockTimeout(_ context.Context, cmd *cliz.Command) (time.Duration, error) {
+	v, _ := cmd.GetOptionString(consts.OptionLockTimeout)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, apperr.Errorf("time.ParseDuration: %s=%s: %w", consts.OptionLockTimeout, v, err)
+	}
+	return d, nil
+}
+
+func LockTimeout() time.Duration {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.LockTimeout
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+// Output is an output of --output, which is the DDL of Dialect generated from the annotations of DDLTagGo and written to Path.
+//
+//nolint:tagliatelle
+type Output struct {
+	Dialect  string `json:"dialect"`
+	DDLTagGo string `json:"ddl_tag_go"`
+	Path     string `json:"path"`
+}
+
+// ParseOutput parses s in the form of <dialect>=<go-ddl-tag>:<destination>, such as postgres=pgddl:schema/pg.sql.
+func ParseOutput(s string) (*Output, error) {
+	dialect, rest, ok := strings.Cut(s, "=")
+	if !ok {
+		return nil, apperr.Errorf("%q is not <dialect>=<go-ddl-tag>:<destination>: %w", s, apperr.ErrInvalidOutput)
+	}
+	tag, path, ok := strings.Cut(rest, ":")
+	o := &Output{Dialect: strings.TrimSpace(dialect), DDLTagGo: strings.TrimSpace(tag), Path: strings.TrimSpace(path)}
+	if !ok || o.Dialect == "" || o.DDLTagGo == "" || o.Path == "" {
+		return nil, apperr.Errorf("%q is not <dialect>=<go-ddl-tag>:<destination>: %w", s, apperr.ErrInvalidOutput)
+	}
+	return o, nil
+}
+
+func (o *Output) String() string {
+	return o.Dialect + "=" + o.DDLTagGo + ":" + o.Path
+}
+
+// loadOutputs returns the comma-separated outputs of --output, such as postgres=pgddl:schema/pg.sql,spanner=spanddl:schema/spanner.sql.
+func loadOutputs(_ context.Context, cmd *cliz.Command) ([]*Output, error) {
+	v, _ := cmd.GetOptionString(consts.OptionOutput)
+	outputs := make([]*Output, 0)
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		o, err := ParseOutput(s)
+		if err != nil {
+			return nil, apperr.Errorf("%s=%s: ParseOutput: %w", consts.OptionOutput, v, err)
+		}
+		outputs = append(outputs, o)
+	}
+	return outputs, nil
+}
+
+func Outputs() []*Output {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.Outputs
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+// Project is the project configuration file, whose keys are the option names such as dialect or go-column-tag.
+// The values are the defaults of the options, so the flags and the environment variables override them.
+type Project struct {
+	// Path is the path of the file, and the relative paths in the file are relative to its directory.
+	Path string
+	// Options is the values of the options for all the environments.
+	Options map[string]string
+	// Environments is the values of the options for each environment such as dev or prod, which override Options.
+	Environments map[string]map[string]string
+}
+
+// FindProjectFile returns the path of consts.ProjectFileName in dir or its nearest parent, or os.ErrNotExist if none is found.
+func FindProjectFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", apperr.Errorf("filepath.Abs: %w", err)
+	}
+	for {
+		path := filepath.Join(dir, consts.ProjectFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", apperr.Errorf("os.Stat: %w", err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", apperr.Errorf("dir=%s: %w", dir, os.ErrNotExist)
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectFile reads the Project at path.
+func LoadProjectFile(path string) (*Project, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, apperr.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	p, err := LoadProject(f)
+	if err != nil {
+		return nil, apperr.Errorf("LoadProject: path=%s: %w", path, err)
+	}
+	p.Path = path
+	return p, nil
+}
+
+// LoadProject reads Project in YAML from r. The lists such as target are joined with commas, as the flags.
+func LoadProject(r io.Reader) (*Project, error) {
+	raw := make(map[string]any)
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		return nil, apperr.Errorf("yaml.Decode: %v: %w", err, apperr.ErrInvalidProjectFile)
+	}
+
+	p := &Project{Options: make(map[string]string), Environments: make(map[string]map[string]string)}
+	for key, value := range raw {
+		if key != "environments" {
+			v, err := projectValue(key, value)
+			if err != nil {
+				return nil, apperr.Errorf("projectValue: %w", err)
+			}
+			p.Options[key] = v
+			continue
+		}
+
+		envs, ok := value.(map[string]any)
+		if !ok {
+			return nil, apperr.Errorf("key=%s: not a mapping: %w", key, apperr.ErrInvalidProjectFile)
+		}
+		for env, envValue := range envs {
+			options, ok := envValue.(map[string]any)
+			if !ok && envValue != nil {
+				return nil, apperr.Errorf("environment=%s: not a mapping: %w", env, apperr.ErrInvalidProjectFile)
+			}
+			p.Environments[env] = make(map[string]string)
+			for key, value := range options {
+				v, err := projectValue(key, value)
+				if err != nil {
+					return nil, apperr.Errorf("environment=%s: projectValue: %w", env, err)
+				}
+				p.Environments[env][key] = v
+			}
+		}
+	}
+	return p, nil
+}
+
+func projectValue(key string, value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, float64:
+		return fmt.Sprint(v), nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, elem := range v {
+			s, err := projectValue(key, elem)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, s)
+		}
+		return strings.Join(values, ","), nil
+	default:
+		return "", apperr.Errorf("key=%s: unsupported value %v: %w", key, value, apperr.ErrInvalidProjectFile)
+	}
+}
+
+// OptionsOf returns the values of the options for env, or for all the environments if env is empty.
+func (p *Project) OptionsOf(env string) (map[string]string, error) {
+	options := make(map[string]string, len(p.Options))
+	for k, v := range p.Options {
+		options[k] = v
+	}
+	if env == "" {
+		return options, nil
+	}
+
+	envOptions, ok := p.Environments[env]
+	if !ok {
+		return nil, apperr.Errorf("env=%s: path=%s: %w", env, p.Path, apperr.ErrEnvironmentNotFound)
+	}
+	for k, v := range envOptions {
+		options[k] = v
+	}
+	return options, nil
+}
+
+// ResolvePath returns path relative to the directory of the file, unless it is absolute or empty.
+func (p *Project) ResolvePath(path string) string {
+	if path == "" || filepath.IsAbs(path) || p.Path == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(p.Path), path)
+}
+
+// ResolveExistingPath returns path relative to the directory of the file if it exists there, or path as it is,
+// such as a DSN or a path relative to the working directory.
+func (p *Project) ResolveExistingPath(path string) string {
+	resolved := p.ResolvePath(path)
+	if _, err := os.Stat(resolved); err != nil {
+		return path
+	}
+	return resolved
+}
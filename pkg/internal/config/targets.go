@@ -0,0 +1,28 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+// loadTargets returns the comma-separated values of --target, such as users,order_*.
+func loadTargets(_ context.Context, cmd *cliz.Command) []string {
+	v, _ := cmd.GetOptionString(consts.OptionTarget)
+	targets := make([]string, 0)
+	for _, target := range strings.Split(v, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+func Targets() []string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.Targets
+}
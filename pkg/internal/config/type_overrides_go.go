@@ -0,0 +1,55 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hakadoriya/z.go/cliz"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
+)
+
+// loadTypeOverridesGo returns the comma-separated <Go type>=<column type> of --go-type-override, such as uuid.UUID=UUID.
+// The commas in the parentheses, such as NUMERIC(10,2), do not separate them.
+func loadTypeOverridesGo(_ context.Context, cmd *cliz.Command) (map[string]string, error) {
+	v, _ := cmd.GetOptionString(consts.OptionGoTypeOverride)
+	overrides := make(map[string]string)
+	for _, override := range splitOutsideParentheses(v) {
+		if override = strings.TrimSpace(override); override == "" {
+			continue
+		}
+		goType, columnType, ok := strings.Cut(override, "=")
+		goType, columnType = strings.TrimSpace(goType), strings.TrimSpace(columnType)
+		if !ok || goType == "" || columnType == "" {
+			return nil, apperr.Errorf("%s=%s: %q is not <Go type>=<column type>: %w", consts.OptionGoTypeOverride, v, override, apperr.ErrInvalidTypeOverride)
+		}
+		overrides[goType] = columnType
+	}
+	return overrides, nil
+}
+
+func splitOutsideParentheses(s string) []string {
+	elems := make([]string, 0)
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				elems = append(elems, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(elems, s[start:])
+}
+
+func TypeOverridesGo() map[string]string {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig.TypeOverridesGo
+}
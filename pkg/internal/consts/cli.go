@@ -7,6 +7,9 @@ const (
 	OptionDebug = "debug"
 	EnvKeyDebug = "DDLCTL_DEBUG"
 
+	OptionEnv = "env"
+	EnvKeyEnv = "DDLCTL_ENV"
+
 	OptionLanguage = "lang"
 	EnvKeyLanguage = "DDLCTL_LANGUAGE"
 
@@ -16,6 +19,82 @@ const (
 	OptionAutoApprove = "auto-approve"
 	EnvKeyAutoApprove = "DDLCTL_AUTO_APPROVE"
 
+	OptionCheck = "check"
+	EnvKeyCheck = "DDLCTL_CHECK"
+
+	OptionLenient = "lenient"
+	EnvKeyLenient = "DDLCTL_LENIENT"
+
+	OptionFormat = "format"
+	EnvKeyFormat = "DDLCTL_FORMAT"
+
+	OptionLintConfig = "lint-config"
+	EnvKeyLintConfig = "DDLCTL_LINT_CONFIG"
+
+	OptionCheckSafety = "check-safety"
+	EnvKeyCheckSafety = "DDLCTL_CHECK_SAFETY"
+
+	OptionEngineVersion = "engine-version"
+	EnvKeyEngineVersion = "DDLCTL_ENGINE_VERSION"
+
+	OptionPhased = "phased"
+	EnvKeyPhased = "DDLCTL_PHASED"
+
+	OptionPhaseDir = "phase-dir"
+	EnvKeyPhaseDir = "DDLCTL_PHASE_DIR"
+
+	OptionPhase = "phase"
+	EnvKeyPhase = "DDLCTL_PHASE"
+
+	OptionShadowDSN = "shadow-dsn"
+	EnvKeyShadowDSN = "DDLCTL_SHADOW_DSN"
+
+	OptionApplyLockWait = "apply-lock-wait"
+	EnvKeyApplyLockWait = "DDLCTL_APPLY_LOCK_WAIT"
+
+	OptionLockTimeout = "lock-timeout"
+	EnvKeyLockTimeout = "DDLCTL_LOCK_TIMEOUT"
+
+	OptionStatementTimeout = "statement-timeout"
+	EnvKeyStatementTimeout = "DDLCTL_STATEMENT_TIMEOUT"
+
+	OptionResume = "resume"
+	EnvKeyResume = "DDLCTL_RESUME"
+
+	OptionStateFile = "state-file"
+	EnvKeyStateFile = "DDLCTL_STATE_FILE"
+
+	OptionTarget = "target"
+	EnvKeyTarget = "DDLCTL_TARGET"
+
+	OptionInteractive = "interactive"
+	EnvKeyInteractive = "DDLCTL_INTERACTIVE"
+
+	OptionRecordFile = "record-file"
+	EnvKeyRecordFile = "DDLCTL_RECORD_FILE"
+
+	OptionHooks = "hooks"
+	EnvKeyHooks = "DDLCTL_HOOKS"
+
+	OptionDSN = "dsn"
+	EnvKeyDSN = "DDLCTL_DSN"
+
+	OptionSource = "source"
+	EnvKeySource = "DDLCTL_SOURCE"
+
+	OptionIgnore = "ignore"
+	EnvKeyIgnore = "DDLCTL_IGNORE"
+
+	OptionOutput = "output"
+	EnvKeyOutput = "DDLCTL_OUTPUT"
+
+	// PostgreSQL
+	OptionIndexConcurrently = "index-concurrently"
+	EnvKeyIndexConcurrently = "DDLCTL_INDEX_CONCURRENTLY"
+
+	OptionSafeConstraints = "safe-constraints"
+	EnvKeySafeConstraints = "DDLCTL_SAFE_CONSTRAINTS"
+
 	// Golang
 	OptionGoColumnTag = "go-column-tag"
 	EnvKeyGoColumnTag = "DDLCTL_GO_COLUMN_TAG"
@@ -25,4 +104,10 @@ const (
 
 	OptionGoPKTag = "go-pk-tag"
 	EnvKeyGoPKTag = "DDLCTL_GO_PK_TAG"
+
+	OptionGoInferType = "go-infer-type"
+	EnvKeyGoInferType = "DDLCTL_GO_INFER_TYPE"
+
+	OptionGoTypeOverride = "go-type-override"
+	EnvKeyGoTypeOverride = "DDLCTL_GO_TYPE_OVERRIDE"
 )
@@ -0,0 +1,9 @@
+package consts
+
+const (
+	// LockName is the name of the lock that ddlctl apply takes on the database, so that two applies cannot run at once.
+	LockName = "ddlctl_apply"
+	// LockTableName is the table that holds the lock row on the databases without advisory locks, such as CockroachDB and Spanner.
+	// ddlctl show excludes it, so that ddlctl diff does not drop it.
+	LockTableName = "ddlctl_lock"
+)
@@ -0,0 +1,4 @@
+package consts
+
+// ProjectFileName is the project configuration file, which ddlctl discovers from the working directory upward.
+const ProjectFileName = "ddlctl.yaml"
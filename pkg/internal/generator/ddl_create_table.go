@@ -2,6 +2,7 @@ package generator
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/hakadoriya/ddlctl/pkg/internal/lang/util"
 )
@@ -48,6 +49,36 @@ type CreateTableColumn struct {
 	Comments       []string
 	ColumnName     string
 	TypeConstraint string
+	// InferredType is the type inferred from the field, which the dialect puts before TypeConstraint. Empty if TypeConstraint has the type.
+	InferredType ColumnType
+}
+
+// ColumnType is a column type independent of the dialects, which each dialect maps to its column type.
+type ColumnType string
+
+const (
+	ColumnTypeString    ColumnType = "string"
+	ColumnTypeInt16     ColumnType = "int16"
+	ColumnTypeInt32     ColumnType = "int32"
+	ColumnTypeInt64     ColumnType = "int64"
+	ColumnTypeFloat32   ColumnType = "float32"
+	ColumnTypeFloat64   ColumnType = "float64"
+	ColumnTypeBool      ColumnType = "bool"
+	ColumnTypeTimestamp ColumnType = "timestamp"
+	ColumnTypeBytes     ColumnType = "bytes"
+	ColumnTypeJSON      ColumnType = "json"
+)
+
+// TypeConstraintOf returns the type and the constraints of column in the dialect of types, which maps InferredType to the column type.
+func (column *CreateTableColumn) TypeConstraintOf(types map[ColumnType]string) string {
+	if column.InferredType == "" {
+		return column.TypeConstraint
+	}
+	typ, ok := types[column.InferredType]
+	if !ok {
+		typ = string(column.InferredType)
+	}
+	return strings.TrimSpace(typ + " " + column.TypeConstraint)
 }
 
 type CreateTableConstraint struct {
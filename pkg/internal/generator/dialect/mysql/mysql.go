@@ -14,6 +14,22 @@ const (
 	Quotation     = "`"
 )
 
+// ColumnTypes is the column types of mysql for the types inferred from the fields.
+//
+//nolint:gochecknoglobals
+var ColumnTypes = map[ddlast.ColumnType]string{
+	ddlast.ColumnTypeString:    "VARCHAR(255)",
+	ddlast.ColumnTypeInt16:     "SMALLINT",
+	ddlast.ColumnTypeInt32:     "INT",
+	ddlast.ColumnTypeInt64:     "BIGINT",
+	ddlast.ColumnTypeFloat32:   "FLOAT",
+	ddlast.ColumnTypeFloat64:   "DOUBLE",
+	ddlast.ColumnTypeBool:      "BOOLEAN",
+	ddlast.ColumnTypeTimestamp: "DATETIME",
+	ddlast.ColumnTypeBytes:     "BLOB",
+	ddlast.ColumnTypeJSON:      "JSON",
+}
+
 func Fprint(w io.Writer, ddl *ddlast.DDL) error {
 	var buf string
 
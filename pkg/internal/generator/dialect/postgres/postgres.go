@@ -14,6 +14,22 @@ const (
 	Quotation     = `"`
 )
 
+// ColumnTypes is the column types of postgres and cockroachdb for the types inferred from the fields.
+//
+//nolint:gochecknoglobals
+var ColumnTypes = map[ddlast.ColumnType]string{
+	ddlast.ColumnTypeString:    "TEXT",
+	ddlast.ColumnTypeInt16:     "SMALLINT",
+	ddlast.ColumnTypeInt32:     "INTEGER",
+	ddlast.ColumnTypeInt64:     "BIGINT",
+	ddlast.ColumnTypeFloat32:   "REAL",
+	ddlast.ColumnTypeFloat64:   "DOUBLE PRECISION",
+	ddlast.ColumnTypeBool:      "BOOLEAN",
+	ddlast.ColumnTypeTimestamp: "TIMESTAMPTZ",
+	ddlast.ColumnTypeBytes:     "BYTEA",
+	ddlast.ColumnTypeJSON:      "JSONB",
+}
+
 func Fprint(w io.Writer, ddl *ddlast.DDL) error {
 	var buf string
 
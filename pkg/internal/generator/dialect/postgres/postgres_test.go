@@ -109,6 +109,49 @@ INTERLEAVE IN PARENT SpanParents ON DELETE CASCADE;
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("success,InferredType", func(t *testing.T) {
+		ddl := ddlast.NewDDL(context.Background())
+		ddl.Stmts = []ddlast.Stmt{
+			&ddlast.CreateTableStmt{
+				CreateTable: "CREATE TABLE users",
+				Columns: []*ddlast.CreateTableColumn{
+					{
+						ColumnName:     "id",
+						InferredType:   ddlast.ColumnTypeString,
+						TypeConstraint: "NOT NULL",
+					},
+					{
+						ColumnName:   "name",
+						InferredType: ddlast.ColumnTypeString,
+					},
+					{
+						ColumnName:     "created_at",
+						InferredType:   ddlast.ColumnTypeTimestamp,
+						TypeConstraint: "NOT NULL",
+					},
+				},
+			},
+		}
+
+		const expected = `-- Code generated by ddlctl. DO NOT EDIT.
+--
+
+CREATE TABLE users (
+    "id"         TEXT NOT NULL,
+    "name"       TEXT,
+    "created_at" TIMESTAMPTZ NOT NULL
+);
+`
+
+		buf := bytes.NewBuffer(nil)
+		if err := Fprint(buf, ddl); err != nil {
+			t.Fatalf("failed to Fprint: %+v", err)
+		}
+		actual := buf.String()
+
+		assert.Equal(t, expected, actual)
+	})
+
 	t.Run("failure,Write", func(t *testing.T) {
 		ddl := ddlast.NewDDL(context.Background())
 		ddl.Stmts = []ddlast.Stmt{
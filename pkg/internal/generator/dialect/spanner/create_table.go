@@ -88,7 +88,7 @@ func fprintCreateTableColumn(buf *string, indent string, columns []*ddlast.Creat
 			fprintComment(buf, indent, comment)
 		}
 
-		*buf += indent + fmt.Sprintf(columnNameFormat, Quotation+column.ColumnName+Quotation) + " " + column.TypeConstraint
+		*buf += indent + fmt.Sprintf(columnNameFormat, Quotation+column.ColumnName+Quotation) + " " + column.TypeConstraintOf(ColumnTypes)
 
 		if lastColumn := len(columns) - 1; i == lastColumn && !tailComma {
 			*buf += "\n"
@@ -14,6 +14,22 @@ const (
 	Quotation     = "`"
 )
 
+// ColumnTypes is the column types of spanner for the types inferred from the fields.
+//
+//nolint:gochecknoglobals
+var ColumnTypes = map[ddlast.ColumnType]string{
+	ddlast.ColumnTypeString:    "STRING(MAX)",
+	ddlast.ColumnTypeInt16:     "INT64",
+	ddlast.ColumnTypeInt32:     "INT64",
+	ddlast.ColumnTypeInt64:     "INT64",
+	ddlast.ColumnTypeFloat32:   "FLOAT32",
+	ddlast.ColumnTypeFloat64:   "FLOAT64",
+	ddlast.ColumnTypeBool:      "BOOL",
+	ddlast.ColumnTypeTimestamp: "TIMESTAMP",
+	ddlast.ColumnTypeBytes:     "BYTES(MAX)",
+	ddlast.ColumnTypeJSON:      "JSON",
+}
+
 func Fprint(w io.Writer, ddl *ddlast.DDL) error {
 	var buf string
 
@@ -11,7 +11,6 @@ import (
 	"github.com/hakadoriya/z.go/pathz/filepathz"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
-	"github.com/hakadoriya/ddlctl/pkg/internal/config"
 	"github.com/hakadoriya/ddlctl/pkg/logs"
 )
 
@@ -24,11 +23,10 @@ type ddlSource struct {
 	CommentGroup *ast.CommentGroup
 }
 
+// _DDLTagGoCommentLineRegexes is the cache of DDLTagGoCommentLineRegex by the tag.
+//
 //nolint:gochecknoglobals
-var (
-	_DDLTagGoCommentLineRegex     *regexp.Regexp
-	_DDLTagGoCommentLineRegexOnce sync.Once
-)
+var _DDLTagGoCommentLineRegexes sync.Map
 
 const (
 	//	                                          ________________ <- 1. comment prefix
@@ -38,16 +36,18 @@ const (
 	_DDLTagGoCommentLineRegexContentIndex = /*                               ^^ 3. tag value */ 3
 )
 
-func DDLTagGoCommentLineRegex() *regexp.Regexp {
-	_DDLTagGoCommentLineRegexOnce.Do(func() {
-		_DDLTagGoCommentLineRegex = regexp.MustCompile(fmt.Sprintf(_DDLTagGoCommentLineRegexFormat, config.DDLTagGo()))
-	})
-	return _DDLTagGoCommentLineRegex
+func DDLTagGoCommentLineRegex(tag string) *regexp.Regexp {
+	if re, ok := _DDLTagGoCommentLineRegexes.Load(tag); ok {
+		return re.(*regexp.Regexp) //nolint:forcetypeassert
+	}
+	re, _ := _DDLTagGoCommentLineRegexes.LoadOrStore(tag, regexp.MustCompile(fmt.Sprintf(_DDLTagGoCommentLineRegexFormat, regexp.QuoteMeta(tag))))
+	return re.(*regexp.Regexp) //nolint:forcetypeassert
 }
 
 //
 //nolint:cyclop
-func extractDDLSourceFromDDLTagGo(_ context.Context, fset *token.FileSet, f *ast.File) ([]*ddlSource, error) {
+func extractDDLSourceFromDDLTagGo(ctx context.Context, fset *token.FileSet, f *ast.File) ([]*ddlSource, error) {
+	tag := optionsFromContext(ctx).DDL
 	ddlSrc := make([]*ddlSource, 0)
 
 	for commentedNode, commentGroups := range ast.NewCommentMap(fset, f, f.Comments) {
@@ -56,7 +56,7 @@ func extractDDLSourceFromDDLTagGo(_ context.Context, fset *token.FileSet, f *ast
 			for _, commentLine := range commentGroup.List {
 				logs.Trace.Printf("commentLine=%s: %s", filepathz.ExtractShortPath(fset.Position(commentGroup.Pos()).String()), commentLine.Text)
 				// NOTE: If the comment line matches the DDLTagGo, it is assumed to be a comment line for the struct.
-				if matches := DDLTagGoCommentLineRegex().FindStringSubmatch(commentLine.Text); len(matches) > _DDLTagGoCommentLineRegexContentIndex {
+				if matches := DDLTagGoCommentLineRegex(tag).FindStringSubmatch(commentLine.Text); len(matches) > _DDLTagGoCommentLineRegexContentIndex {
 					s := &ddlSource{
 						Position:     fset.Position(commentLine.Pos()),
 						CommentGroup: commentGroup,
@@ -83,7 +83,7 @@ func extractDDLSourceFromDDLTagGo(_ context.Context, fset *token.FileSet, f *ast
 	}
 
 	if len(ddlSrc) == 0 {
-		return nil, apperr.Errorf("go-ddl-tag=%s: %w", config.DDLTagGo(), apperr.ErrDDLTagGoAnnotationNotFoundInSource)
+		return nil, apperr.Errorf("go-ddl-tag=%s: %w", tag, apperr.ErrDDLTagGoAnnotationNotFoundInSource)
 	}
 
 	return ddlSrc, nil
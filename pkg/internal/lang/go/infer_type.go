@@ -0,0 +1,113 @@
+package ddlctlgo
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/generator"
+)
+
+//nolint:gochecknoglobals
+var (
+	// goColumnTypes is the column types of the Go types of the fields.
+	// MEMO: uint and uint64 are not here, because their values above the max of int64 overflow the signed column types.
+	goColumnTypes = map[string]generator.ColumnType{
+		"string":          generator.ColumnTypeString,
+		"int":             generator.ColumnTypeInt64,
+		"int64":           generator.ColumnTypeInt64,
+		"uint32":          generator.ColumnTypeInt64,
+		"int32":           generator.ColumnTypeInt32,
+		"uint16":          generator.ColumnTypeInt32,
+		"int16":           generator.ColumnTypeInt16,
+		"int8":            generator.ColumnTypeInt16,
+		"uint8":           generator.ColumnTypeInt16,
+		"byte":            generator.ColumnTypeInt16,
+		"float64":         generator.ColumnTypeFloat64,
+		"float32":         generator.ColumnTypeFloat32,
+		"bool":            generator.ColumnTypeBool,
+		"time.Time":       generator.ColumnTypeTimestamp,
+		"[]byte":          generator.ColumnTypeBytes,
+		"json.RawMessage": generator.ColumnTypeJSON,
+	}
+	// sqlNullTypes is the value types of the nullable types of database/sql.
+	sqlNullTypes = map[string]string{
+		"sql.NullString":  "string",
+		"sql.NullInt64":   "int64",
+		"sql.NullInt32":   "int32",
+		"sql.NullInt16":   "int16",
+		"sql.NullByte":    "byte",
+		"sql.NullFloat64": "float64",
+		"sql.NullBool":    "bool",
+		"sql.NullTime":    "time.Time",
+	}
+	// constraintKeywords is the first words of the column constraints, to tell the DDL tag of only the constraints from the one with the type.
+	constraintKeywords = map[string]bool{
+		"NOT":            true,
+		"NULL":           true,
+		"DEFAULT":        true,
+		"PRIMARY":        true,
+		"UNIQUE":         true,
+		"CHECK":          true,
+		"REFERENCES":     true,
+		"CONSTRAINT":     true,
+		"GENERATED":      true,
+		"COLLATE":        true,
+		"AUTO_INCREMENT": true,
+		"OPTIONS":        true,
+	}
+)
+
+// isConstraintsOnly reports whether typeConstraint, which is the value of the DDL tag, has only the constraints without the type, such as NOT NULL DEFAULT 0.
+func isConstraintsOnly(typeConstraint string) bool {
+	fields := strings.Fields(typeConstraint)
+	if len(fields) == 0 {
+		return false
+	}
+	keyword, _, _ := strings.Cut(strings.ToUpper(fields[0]), "(")
+	return constraintKeywords[keyword]
+}
+
+// inferColumn sets the type of column inferred from expr, which is the type of the field, and constraints, which is the value of the DDL tag without the type.
+// The column is NOT NULL unless expr is a pointer or a nullable type of database/sql, or constraints has NULL.
+// It returns the Go type without the pointer and the nullable type, and false if the column type of it is unknown.
+func inferColumn(column *generator.CreateTableColumn, expr ast.Expr, constraints string, overrides map[string]string) (string, bool) {
+	goType := types.ExprString(expr)
+	nullable := false
+	if strings.HasPrefix(goType, "*") {
+		goType, nullable = strings.TrimPrefix(goType, "*"), true
+	}
+	if valueType, ok := sqlNullTypes[goType]; ok {
+		goType, nullable = valueType, true
+	} else if strings.HasPrefix(goType, "sql.Null[") && strings.HasSuffix(goType, "]") {
+		goType, nullable = strings.TrimSuffix(strings.TrimPrefix(goType, "sql.Null["), "]"), true
+	}
+
+	override, overridden := overrides[goType]
+	columnType, inferred := goColumnTypes[goType]
+	if !overridden && !inferred {
+		return goType, false
+	}
+
+	constraints = strings.TrimSpace(constraints)
+	if !nullable && !hasNullConstraint(constraints) {
+		constraints = strings.TrimSpace("NOT NULL " + constraints)
+	}
+	if overridden {
+		column.TypeConstraint = strings.TrimSpace(override + " " + constraints)
+		return goType, true
+	}
+	column.InferredType = columnType
+	column.TypeConstraint = constraints
+	return goType, true
+}
+
+// hasNullConstraint reports whether constraints has NULL or NOT NULL.
+func hasNullConstraint(constraints string) bool {
+	for _, field := range strings.Fields(strings.ToUpper(constraints)) {
+		if field == "NULL" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+//nolint:testpackage
+package ddlctlgo
+
+import (
+	"context"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/generator"
+)
+
+func Test_inferColumn(t *testing.T) {
+	t.Parallel()
+
+	overrides := map[string]string{"uuid.UUID": "UUID"}
+	tests := []struct {
+		name           string
+		expr           string
+		constraints    string
+		wantGoType     string
+		wantOK         bool
+		wantType       generator.ColumnType
+		wantConstraint string
+	}{
+		{name: "success,string", expr: "string", wantGoType: "string", wantOK: true, wantType: generator.ColumnTypeString, wantConstraint: "NOT NULL"},
+		{name: "success,pointer", expr: "*int64", wantGoType: "int64", wantOK: true, wantType: generator.ColumnTypeInt64},
+		{name: "success,sql.NullTime", expr: "sql.NullTime", wantGoType: "time.Time", wantOK: true, wantType: generator.ColumnTypeTimestamp},
+		{name: "success,sql.Null[int32]", expr: "sql.Null[int32]", wantGoType: "int32", wantOK: true, wantType: generator.ColumnTypeInt32},
+		{name: "success,[]byte", expr: "[]byte", constraints: "DEFAULT ''", wantGoType: "[]byte", wantOK: true, wantType: generator.ColumnTypeBytes, wantConstraint: "NOT NULL DEFAULT ''"},
+		{name: "success,NULL", expr: "bool", constraints: "NULL", wantGoType: "bool", wantOK: true, wantType: generator.ColumnTypeBool, wantConstraint: "NULL"},
+		{name: "success,override", expr: "*uuid.UUID", constraints: "PRIMARY KEY", wantGoType: "uuid.UUID", wantOK: true, wantConstraint: "UUID PRIMARY KEY"},
+		{name: "success,uint32", expr: "uint32", wantGoType: "uint32", wantOK: true, wantType: generator.ColumnTypeInt64, wantConstraint: "NOT NULL"},
+		{name: "failure,unknown", expr: "map[string]any", wantGoType: "map[string]any", wantOK: false},
+		{name: "failure,uint64", expr: "uint64", wantGoType: "uint64", wantOK: false},
+		{name: "failure,uint", expr: "*uint", wantGoType: "uint", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expr, err := parser.ParseExpr(tt.expr)
+			require.NoError(t, err)
+
+			column := &generator.CreateTableColumn{}
+			goType, ok := inferColumn(column, expr, tt.constraints, overrides)
+			assert.Equal(t, tt.wantGoType, goType)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantType, column.InferredType)
+			assert.Equal(t, tt.wantConstraint, column.TypeConstraint)
+		})
+	}
+}
+
+func Test_isConstraintsOnly(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, true, isConstraintsOnly("NOT NULL DEFAULT 0"))
+	assert.Equal(t, true, isConstraintsOnly("default now()"))
+	assert.Equal(t, true, isConstraintsOnly("CHECK(price > 0)"))
+	assert.Equal(t, false, isConstraintsOnly("STRING(36) NOT NULL"))
+	assert.Equal(t, false, isConstraintsOnly("INT64"))
+	assert.Equal(t, false, isConstraintsOnly(""))
+}
+
+func TestParse_inferType(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "user.go")
+	require.NoError(t, os.WriteFile(filename, []byte(`package model
+
+// User is a user.
+//
+//ddlctl: table: CREATE TABLE users
+type User struct {
+	ID        string         `+"`"+`db:"id" ddlctl:"NOT NULL" pk:"true"`+"`"+`
+	Name      *string        `+"`"+`db:"name"`+"`"+`
+	Age       int64          `+"`"+`db:"age" ddlctl:"DEFAULT 0"`+"`"+`
+	Metadata  map[string]any `+"`"+`db:"metadata"`+"`"+`
+	Views     uint64         `+"`"+`db:"views"`+"`"+`
+	CreatedAt time.Time      `+"`"+`db:"created_at" ddlctl:"TIMESTAMP NOT NULL"`+"`"+`
+}
+`), 0o600))
+
+	ctx := WithOptions(context.Background(), &Options{Column: "db", DDL: "ddlctl", PK: "pk", InferType: true})
+	ddl, err := Parse(ctx, filename)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ddl.Stmts))
+
+	stmt, ok := ddl.Stmts[0].(*generator.CreateTableStmt)
+	require.Equal(t, true, ok)
+	require.Equal(t, 4, len(stmt.Columns))
+
+	assert.Equal(t, "id", stmt.Columns[0].ColumnName)
+	assert.Equal(t, generator.ColumnTypeString, stmt.Columns[0].InferredType)
+	assert.Equal(t, "NOT NULL", stmt.Columns[0].TypeConstraint)
+	assert.Equal(t, "name", stmt.Columns[1].ColumnName)
+	assert.Equal(t, generator.ColumnTypeString, stmt.Columns[1].InferredType)
+	assert.Equal(t, "", stmt.Columns[1].TypeConstraint)
+	assert.Equal(t, "age", stmt.Columns[2].ColumnName)
+	assert.Equal(t, generator.ColumnTypeInt64, stmt.Columns[2].InferredType)
+	assert.Equal(t, "NOT NULL DEFAULT 0", stmt.Columns[2].TypeConstraint)
+	assert.Equal(t, "created_at", stmt.Columns[3].ColumnName)
+	assert.Equal(t, generator.ColumnType(""), stmt.Columns[3].InferredType)
+	assert.Equal(t, "TIMESTAMP NOT NULL", stmt.Columns[3].TypeConstraint)
+	// MEMO: uint64 is ignored as map[string]any, because its values may overflow the signed column types.
+	assert.Equal(t, true, strings.Contains(strings.Join(stmt.Comments, "\n"), `the column type of "uint64" cannot be inferred`))
+}
@@ -0,0 +1,48 @@
+package ddlctlgo
+
+import (
+	"context"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/config"
+)
+
+// Options is the options of Parse, which are --go-column-tag, --go-ddl-tag, --go-pk-tag, --go-infer-type and --go-type-override of the command.
+type Options struct {
+	// Column is the struct tag of the column name, such as db.
+	Column string
+	// DDL is the key of the annotation comments and the struct tag of the column type and constraint, such as ddlctl.
+	DDL string
+	// PK is the struct tag of the primary key, such as pk.
+	PK string
+	// InferType infers the column type from the type of the field if the DDL tag is absent or has only the constraints.
+	InferType bool
+	// TypeOverrides is the column types of the Go types, such as uuid.UUID, which take precedence over the inferred ones.
+	TypeOverrides map[string]string
+}
+
+type optionsContextKey struct{}
+
+// WithOptions returns ctx with opts, which Parse uses instead of the options of the command,
+// so that the library API can parse with different options at once.
+func WithOptions(ctx context.Context, opts *Options) context.Context {
+	return context.WithValue(ctx, optionsContextKey{}, opts)
+}
+
+// optionsFromContext returns the options of WithOptions, or the options of the command if ctx has none.
+func optionsFromContext(ctx context.Context) *Options {
+	if opts, ok := ctx.Value(optionsContextKey{}).(*Options); ok && opts != nil {
+		return opts
+	}
+	return ConfigOptions()
+}
+
+// ConfigOptions returns the options of the command.
+func ConfigOptions() *Options {
+	return &Options{
+		Column:        config.ColumnTagGo(),
+		DDL:           config.DDLTagGo(),
+		PK:            config.PKTagGo(),
+		InferType:     config.InferTypeGo(),
+		TypeOverrides: config.TypeOverridesGo(),
+	}
+}
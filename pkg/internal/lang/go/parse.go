@@ -5,8 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -18,7 +16,7 @@ import (
 	"github.com/hakadoriya/z.go/slicez"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
-	"github.com/hakadoriya/ddlctl/pkg/internal/config"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
 	"github.com/hakadoriya/ddlctl/pkg/internal/generator"
 	langutil "github.com/hakadoriya/ddlctl/pkg/internal/lang/util"
 	"github.com/hakadoriya/ddlctl/pkg/internal/util"
@@ -89,12 +87,13 @@ func walkDirFn(ctx context.Context, ddl *generator.DDL) func(path string, d os.D
 
 //nolint:cyclop,funlen,gocognit
 func parseFile(ctx context.Context, filename string) ([]generator.Stmt, error) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	fset, f, err := parseGoFile(ctx, filename)
 	if err != nil {
-		return nil, apperr.Errorf("parser.ParseFile: %w", err)
+		return nil, apperr.Errorf("parseGoFile: %w", err)
 	}
 
+	opts := optionsFromContext(ctx)
+
 	ddlSrc, err := extractDDLSourceFromDDLTagGo(ctx, fset, f)
 	if err != nil {
 		return nil, apperr.Errorf("extractDDLSourceFromDDLTagGo: %w", err)
@@ -117,6 +116,12 @@ func parseFile(ctx context.Context, filename string) ([]generator.Stmt, error) {
 		for _, comment := range comments {
 			logs.Debug.Printf("[COMMENT DETECTED]: %s:%d: %s", createTableStmt.SourceFile, createTableStmt.SourceLine, comment)
 
+			// NOTE: the annotations of the other DDL tags on the same struct, such as spanddl for pgddl, are the comments.
+			if !DDLTagGoCommentLineRegex(opts.DDL).MatchString(comment) {
+				createTableStmt.Comments = append(createTableStmt.Comments, comment)
+				continue
+			}
+
 			// NOTE: CREATE INDEX may be written in CREATE TABLE annotation, so process it here
 			if /* CREATE INDEX */ matches := langutil.StmtRegexCreateIndex.Regex.FindStringSubmatch(comment); len(matches) > langutil.StmtRegexCreateIndex.Index {
 				commentMatchedCreateIndex := comment
@@ -150,7 +155,7 @@ func parseFile(ctx context.Context, filename string) ([]generator.Stmt, error) {
 		if r.TypeSpec != nil && createTableStmt.CreateTable == "" {
 			name := r.TypeSpec.Name.String()
 			source := fset.Position(r.CommentGroup.Pos())
-			createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("WARN: the comment (%s:%d) does not have a key for table (%s: table: CREATE TABLE <table>), so the struct name \"%s\" is used as the table name.", filepathz.ExtractShortPath(source.Filename), source.Line, config.DDLTagGo(), name))
+			createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("WARN: the comment (%s:%d) does not have a key for table (%s: table: CREATE TABLE <table>), so the struct name \"%s\" is used as the table name.", filepathz.ExtractShortPath(source.Filename), source.Line, opts.DDL, name))
 			createTableStmt.SetCreateTable(name)
 		}
 
@@ -164,45 +169,58 @@ func parseFile(ctx context.Context, filename string) ([]generator.Stmt, error) {
 
 				column := &generator.CreateTableColumn{}
 
-				tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+				var tag reflect.StructTag
+				if field.Tag != nil {
+					tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+				}
 
 				// column name
-				switch columnName := tag.Get(config.ColumnTagGo()); columnName {
+				switch columnName := tag.Get(opts.Column); columnName {
 				case "-":
-					createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("NOTE: the \"%s\" struct's \"%s\" field has a tag for column name (`%s:\"-\"`), so the field is ignored.", r.TypeSpec.Name, field.Names[0], config.ColumnTagGo()))
+					createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("NOTE: the \"%s\" struct's \"%s\" field has a tag for column name (`%s:\"-\"`), so the field is ignored.", r.TypeSpec.Name, field.Names[0], opts.Column))
 					continue
 				case "":
 					name := field.Names[0].Name
-					column.Comments = append(column.Comments, fmt.Sprintf("WARN: the \"%s\" struct's \"%s\" field does not have a tag for column name (`%s:\"<ColumnName>\"`), so the field name \"%s\" is used as the column name.", r.TypeSpec.Name, field.Names[0], config.ColumnTagGo(), name))
+					column.Comments = append(column.Comments, fmt.Sprintf("WARN: the \"%s\" struct's \"%s\" field does not have a tag for column name (`%s:\"<ColumnName>\"`), so the field name \"%s\" is used as the column name.", r.TypeSpec.Name, field.Names[0], opts.Column, name))
 					column.ColumnName = name
 				default:
 					column.ColumnName = columnName
 				}
 
 				// column type and constraint
-				switch columnTypeConstraint := tag.Get(config.DDLTagGo()); columnTypeConstraint {
-				case "", "-":
+				switch columnTypeConstraint := tag.Get(opts.DDL); {
+				case columnTypeConstraint == "-", columnTypeConstraint == "" && !opts.InferType:
 					// NOTE: ignore no-annotation fields
-					// column.Comments = append(column.Comments, fmt.Sprintf("ERROR: the \"%s\" struct's \"%s\" field does not have a tag for column type and constraint (`%s:\"<TYPE> [CONSTRAINT]\"`)", r.TypeSpec.Name, field.Names[0], config.DDLTagGo()))
+					// column.Comments = append(column.Comments, fmt.Sprintf("ERROR: the \"%s\" struct's \"%s\" field does not have a tag for column type and constraint (`%s:\"<TYPE> [CONSTRAINT]\"`)", r.TypeSpec.Name, field.Names[0], opts.DDL))
 					// column.TypeConstraint = DDLCTL_ERROR_STRUCT_FIELD_TAG_NOT_FOUND
 					continue
+				case columnTypeConstraint == "":
+					if goType, ok := inferColumn(column, field.Type, "", opts.TypeOverrides); !ok {
+						createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("NOTE: the \"%s\" struct's \"%s\" field does not have a tag for column type and constraint (`%s:\"<TYPE> [CONSTRAINT]\"`), and the column type of \"%s\" cannot be inferred, so the field is ignored.", r.TypeSpec.Name, field.Names[0], opts.DDL, goType))
+						continue
+					}
+				case opts.InferType && isConstraintsOnly(columnTypeConstraint):
+					if goType, ok := inferColumn(column, field.Type, columnTypeConstraint, opts.TypeOverrides); !ok {
+						column.Comments = append(column.Comments, fmt.Sprintf("WARN: the column type of \"%s\" cannot be inferred, so add it to the tag (`%s:\"<TYPE> %s\"`) or --%s.", goType, opts.DDL, columnTypeConstraint, consts.OptionGoTypeOverride))
+						column.TypeConstraint = columnTypeConstraint
+					}
 				default:
 					column.TypeConstraint = columnTypeConstraint
 				}
 
 				// primary key
-				switch primaryKey := tag.Get(config.PKTagGo()); primaryKey {
+				switch primaryKey := tag.Get(opts.PK); primaryKey {
 				case "true", "1":
 					createTableStmt.PrimaryKey = append(createTableStmt.PrimaryKey, column.ColumnName)
 				case "", "-":
 					// do nothing
 				default:
-					column.Comments = append(column.Comments, fmt.Sprintf("WARN: the field \"%s\" does not have valid primary key tag (`%s:\"true\"`), so the column is not used as primary key.", field.Names[0], config.PKTagGo()))
+					column.Comments = append(column.Comments, fmt.Sprintf("WARN: the field \"%s\" does not have valid primary key tag (`%s:\"true\"`), so the column is not used as primary key.", field.Names[0], opts.PK))
 				}
 
 				// comments
 				comments := strings.Split(strings.Trim(field.Doc.Text(), "\n"), "\n")
-				column.Comments = append(column.Comments, langutil.TrimCommentElementTailEmpty(langutil.TrimCommentElementHasPrefix(comments, config.DDLTagGo()))...)
+				column.Comments = append(column.Comments, langutil.TrimCommentElementTailEmpty(langutil.TrimCommentElementHasPrefix(comments, opts.DDL))...)
 
 				createTableStmt.Columns = append(createTableStmt.Columns, column)
 			}
@@ -211,11 +229,11 @@ func parseFile(ctx context.Context, filename string) ([]generator.Stmt, error) {
 		if createTableStmt.CreateTable == "" {
 			// CREATE TABLE (ERROR)
 			source := fset.Position(r.CommentGroup.Pos())
-			createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("WARN: the comment (%s:%d) does not have a key for table (%s: table: CREATE TABLE <table>), or the comment is not associated with struct.", filepathz.ExtractShortPath(source.Filename), source.Line, config.DDLTagGo()))
+			createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("WARN: the comment (%s:%d) does not have a key for table (%s: table: CREATE TABLE <table>), or the comment is not associated with struct.", filepathz.ExtractShortPath(source.Filename), source.Line, opts.DDL))
 		} else if len(createTableStmt.Columns) == 0 {
 			// columns (ERROR)
 			source := fset.Position(r.CommentGroup.Pos())
-			createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("ERROR: the comment (%s:%d) does not have struct fields for column type and constraint (`%s:\"<TYPE> [CONSTRAINT]\"`), or the comment is not associated with struct.", filepathz.ExtractShortPath(source.Filename), source.Line, config.DDLTagGo()))
+			createTableStmt.Comments = append(createTableStmt.Comments, fmt.Sprintf("ERROR: the comment (%s:%d) does not have struct fields for column type and constraint (`%s:\"<TYPE> [CONSTRAINT]\"`), or the comment is not associated with struct.", filepathz.ExtractShortPath(source.Filename), source.Line, opts.DDL))
 		}
 
 		if len(createTableStmt.Columns) > 0 {
@@ -0,0 +1,57 @@
+package ddlctlgo
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sync"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+)
+
+type parsedFile struct {
+	fset *token.FileSet
+	file *ast.File
+	err  error
+}
+
+type parseCache struct {
+	mu    sync.Mutex
+	files map[string]*parsedFile
+}
+
+type parseCacheContextKey struct{}
+
+// WithParseCache returns ctx that keeps the files parsed by Parse,
+// so that Parse with the different options, such as the DDL tags of the dialects, parses each file once.
+func WithParseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, parseCacheContextKey{}, &parseCache{files: make(map[string]*parsedFile)})
+}
+
+// parseGoFile parses filename, or returns the result of the previous parse if ctx has the cache of WithParseCache.
+// The returned file must not be modified, because it is shared.
+func parseGoFile(ctx context.Context, filename string) (*token.FileSet, *ast.File, error) {
+	cache, ok := ctx.Value(parseCacheContextKey{}).(*parseCache)
+	if !ok {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, apperr.Errorf("parser.ParseFile: %w", err)
+		}
+		return fset, f, nil
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	parsed, ok := cache.files[filename]
+	if !ok {
+		parsed = &parsedFile{fset: token.NewFileSet()}
+		parsed.file, parsed.err = parser.ParseFile(parsed.fset, filename, nil, parser.ParseComments)
+		cache.files[filename] = parsed
+	}
+	if parsed.err != nil {
+		return nil, nil, apperr.Errorf("parser.ParseFile: %w", parsed.err)
+	}
+	return parsed.fset, parsed.file, nil
+}
@@ -0,0 +1,56 @@
+//nolint:testpackage
+package ddlctlgo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/generator"
+)
+
+func TestParse_parseCache(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "user.go")
+	require.NoError(t, os.WriteFile(filename, []byte(`package model
+
+// User is a user.
+//
+//pgddl: table: CREATE TABLE users
+//spanddl: table: CREATE TABLE Users
+//spanddl: options: INTERLEAVE IN PARENT Groups ON DELETE CASCADE
+type User struct {
+	ID string `+"`"+`db:"id" pgddl:"TEXT NOT NULL" spanddl:"STRING(36) NOT NULL"`+"`"+`
+}
+`), 0o600))
+
+	ctx := WithParseCache(context.Background())
+	_, first, err := parseGoFile(ctx, filename)
+	require.NoError(t, err)
+	_, second, err := parseGoFile(ctx, filename)
+	require.NoError(t, err)
+	assert.Equal(t, true, first == second)
+
+	pg, err := Parse(WithOptions(ctx, &Options{Column: "db", DDL: "pgddl", PK: "pk"}), filename)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pg.Stmts))
+	pgStmt, ok := pg.Stmts[0].(*generator.CreateTableStmt)
+	require.Equal(t, true, ok)
+	assert.Equal(t, "CREATE TABLE users", pgStmt.CreateTable)
+	assert.Equal(t, 0, len(pgStmt.Options))
+	assert.Equal(t, "TEXT NOT NULL", pgStmt.Columns[0].TypeConstraint)
+
+	spanner, err := Parse(WithOptions(ctx, &Options{Column: "db", DDL: "spanddl", PK: "pk"}), filename)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(spanner.Stmts))
+	spannerStmt, ok := spanner.Stmts[0].(*generator.CreateTableStmt)
+	require.Equal(t, true, ok)
+	assert.Equal(t, "CREATE TABLE Users", spannerStmt.CreateTable)
+	assert.Equal(t, 1, len(spannerStmt.Options))
+	assert.Equal(t, "STRING(36) NOT NULL", spannerStmt.Columns[0].TypeConstraint)
+}
@@ -0,0 +1,11 @@
+package lint
+
+import "errors"
+
+var (
+	ErrUnknownRule     = errors.New("unknown rule")
+	ErrInvalidSeverity = errors.New("invalid severity")
+	ErrInvalidOption   = errors.New("invalid option")
+	// ErrInvalidEngineVersion is returned if the engine version is not in the form of 16.2 or 8.0.29.
+	ErrInvalidEngineVersion = errors.New("invalid engine version")
+)
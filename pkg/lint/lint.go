@@ -0,0 +1,192 @@
+// Package lint runs rules over the DDL of any dialect, and reports the findings with the source positions.
+// It also checks the statements generated by Diff against the locks and the table rewrites of each dialect.
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// Severity is the severity of a finding. The values other than SeverityOff are the levels of SARIF.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+	// SeverityOff disables the rule.
+	SeverityOff Severity = "off"
+)
+
+func (s Severity) valid() bool {
+	switch s {
+	case SeverityError, SeverityWarning, SeverityNote, SeverityOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// Finding is a problem found by a rule.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+	// Filename is the name of the file the DDL is read from. It is empty unless the caller sets it.
+	Filename string
+	Pos      ddl.Position
+}
+
+// Location returns the location of the finding in the form of file:line:column, which editors can jump to.
+func (f *Finding) Location() string {
+	if f.Filename == "" {
+		return f.Pos.String()
+	}
+	return f.Filename + ":" + f.Pos.String()
+}
+
+func (f *Finding) String() string {
+	return f.Location() + ": " + string(f.Severity) + ": " + f.Message + " (" + f.RuleID + ")"
+}
+
+// Rule is a lint rule.
+type Rule struct {
+	ID          string
+	Description string
+	// Severity is the default severity of the rule.
+	Severity Severity
+	// Dialects is the dialects the rule applies to. The rule applies to all dialects if empty.
+	Dialects []string
+	check    func(s *Schema, opts map[string]string) ([]*Finding, error)
+}
+
+func (r *Rule) appliesTo(dialect string) bool {
+	return len(r.Dialects) == 0 || slices.Contains(r.Dialects, dialect)
+}
+
+// Rules returns the built-in rules.
+func Rules() []*Rule {
+	return slices.Clone(rules)
+}
+
+func lookupRule(id string) *Rule {
+	for _, r := range rules {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Config is the configuration of the rules, keyed by the rule ID.
+//
+// The JSON form is:
+//
+//	{
+//	  "rules": {
+//	    "no-primary-key": { "severity": "error" },
+//	    "naming-convention": { "options": { "index": "^idx_[a-z0-9_]+$" } },
+//	    "reserved-word": { "severity": "off" }
+//	  }
+//	}
+type Config struct {
+	Rules map[string]*RuleConfig `json:"rules"`
+}
+
+// RuleConfig overrides the severity and sets the options of a rule.
+type RuleConfig struct {
+	Severity Severity          `json:"severity,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// LoadConfig reads Config in JSON from r.
+func LoadConfig(r io.Reader) (*Config, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	cfg := new(Config)
+	if err := dec.Decode(cfg); err != nil {
+		return nil, apperr.Errorf("json.Decode: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, apperr.Errorf("validate: %w", err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c == nil {
+		return nil
+	}
+	for id, rc := range c.Rules {
+		if lookupRule(id) == nil {
+			return apperr.Errorf("rule=%s: %w", id, ErrUnknownRule)
+		}
+		if rc != nil && rc.Severity != "" && !rc.Severity.valid() {
+			return apperr.Errorf("rule=%s severity=%s: %w", id, rc.Severity, ErrInvalidSeverity)
+		}
+	}
+	return nil
+}
+
+func (c *Config) rule(id string) *RuleConfig {
+	if c == nil || c.Rules[id] == nil {
+		return &RuleConfig{}
+	}
+	return c.Rules[id]
+}
+
+// Lint runs the rules that apply to the dialect of s, and returns the findings sorted by the position.
+// cfg may be nil, in which case the rules run with the default severities and options.
+func Lint(s *Schema, cfg *Config) ([]*Finding, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, apperr.Errorf("validate: %w", err)
+	}
+
+	findings := make([]*Finding, 0)
+	for _, r := range rules {
+		if !r.appliesTo(s.Dialect) {
+			continue
+		}
+		rc := cfg.rule(r.ID)
+		severity := r.Severity
+		if rc.Severity != "" {
+			severity = rc.Severity
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		found, err := r.check(s, rc.Options)
+		if err != nil {
+			return nil, apperr.Errorf("rule=%s: %w", r.ID, err)
+		}
+		for _, f := range found {
+			f.RuleID = r.ID
+			f.Severity = severity
+		}
+		findings = append(findings, found...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Pos.Offset != findings[j].Pos.Offset {
+			return findings[i].Pos.Offset < findings[j].Pos.Offset
+		}
+		return findings[i].RuleID < findings[j].RuleID
+	})
+
+	return findings, nil
+}
+
+// HasErrors reports whether findings contain a finding of SeverityError.
+func HasErrors(findings []*Finding) bool {
+	return slices.ContainsFunc(findings, func(f *Finding) bool { return f.Severity == SeverityError })
+}
+
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
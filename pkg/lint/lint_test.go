@@ -0,0 +1,219 @@
+//nolint:testpackage
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+func findingsString(findings []*Finding) string {
+	var str string
+	for _, f := range findings {
+		str += f.String() + "\n"
+	}
+	return str
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,postgres", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlpg.NewParser(ddlpg.NewLexer(`CREATE TABLE groups (id UUID NOT NULL PRIMARY KEY);
+CREATE TABLE "Users" (
+    id UUID NOT NULL,
+    group_id UUID NOT NULL REFERENCES groups (id),
+    "order" INT,
+    CONSTRAINT "UsersPK" PRIMARY KEY (id)
+);
+CREATE TABLE logs (message TEXT, user_id UUID, CONSTRAINT logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES "Users" (id));
+CREATE INDEX IdxLogs ON logs (user_id, message);
+`)).Parse()
+		require.NoError(t, err)
+
+		expected := `2:1: warning: table "Users" does not match the naming convention ^[a-z][a-z0-9_]*$ (naming-convention)
+4:5: warning: foreign key "Users_group_id_fkey" on "Users" (group_id) has no index whose leading columns are the referencing columns (foreign-key-without-index)
+4:5: warning: foreign key "Users_group_id_fkey" does not match the naming convention ^[a-z][a-z0-9_]*$ (naming-convention)
+5:5: warning: column "order" is a reserved word in postgres (reserved-word)
+6:5: warning: primary key "UsersPK" does not match the naming convention ^[a-z][a-z0-9_]*$ (naming-convention)
+8:1: warning: table "logs" has no primary key (no-primary-key)
+`
+		actual, err := Lint(FromPostgres(d), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+		assert.Equal(t, false, HasErrors(actual))
+	})
+
+	t.Run("success,cockroachdb", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(`CREATE TABLE events (created_at TIMESTAMPTZ NOT NULL, id INT8 NOT NULL, PRIMARY KEY (created_at, id));
+CREATE TABLE counters (id INT8 NOT NULL DEFAULT nextval('counters_seq'), PRIMARY KEY (id));
+CREATE TABLE items (id INT8 NOT NULL DEFAULT unique_rowid(), counter_id INT8 NOT NULL REFERENCES counters (id), PRIMARY KEY (id));
+CREATE INDEX items_counter_id_idx ON items (counter_id);
+CREATE TABLE users (id UUID NOT NULL DEFAULT gen_random_uuid(), PRIMARY KEY (id));
+`)).Parse()
+		require.NoError(t, err)
+
+		expected := `1:22: warning: primary key of "events" starts with "created_at" (TIMESTAMPTZ), which is monotonically increasing and causes hotspots (monotonic-primary-key)
+2:24: warning: primary key of "counters" starts with "id" (sequence), which is monotonically increasing and causes hotspots (monotonic-primary-key)
+3:21: warning: primary key of "items" starts with "id" (unique_rowid()), which is monotonically increasing and causes hotspots (monotonic-primary-key)
+`
+		actual, err := Lint(FromCockroachDB(d), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+	})
+
+	t.Run("success,mysql", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlmysql.NewParser(ddlmysql.NewLexer("CREATE TABLE `select` (id INT NOT NULL AUTO_INCREMENT, user_id INT NOT NULL, PRIMARY KEY (id), CONSTRAINT select_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id));")).Parse()
+		require.NoError(t, err)
+
+		// MEMO: MySQL creates an index for a foreign key, and the name of PRIMARY KEY is not checked.
+		expected := `1:1: warning: table "select" is a reserved word in mysql (reserved-word)
+`
+		actual, err := Lint(FromMySQL(d), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+	})
+
+	t.Run("success,spanner", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlspanner.NewParser(ddlspanner.NewLexer(`CREATE TABLE Events (
+    CreatedAt TIMESTAMP NOT NULL,
+    Id STRING(36) NOT NULL
+) PRIMARY KEY (CreatedAt DESC, Id);
+CREATE TABLE Logs (
+    LoggedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp = true),
+    Id STRING(36) NOT NULL
+) PRIMARY KEY (LoggedAt, Id);
+CREATE TABLE Users (UserId STRING(36) NOT NULL, user_name STRING(MAX)) PRIMARY KEY (UserId);
+CREATE INDEX UsersByUserName ON Users (user_name);
+`)).Parse()
+		require.NoError(t, err)
+
+		expected := `2:5: warning: primary key of "Events" starts with "CreatedAt" (TIMESTAMP), which is monotonically increasing and causes hotspots (monotonic-primary-key)
+6:5: warning: primary key of "Logs" starts with "LoggedAt" (TIMESTAMP), which is monotonically increasing and causes hotspots (monotonic-primary-key)
+9:49: warning: column "user_name" does not match the naming convention ^[A-Z][A-Za-z0-9_]*$ (naming-convention)
+`
+		actual, err := Lint(FromSpanner(d), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+	})
+
+	t.Run("success,not-null-column-added-without-default", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := ddlpg.NewParser(ddlpg.NewLexer(`CREATE TABLE users (id UUID NOT NULL PRIMARY KEY);`)).Parse()
+		require.NoError(t, err)
+		after, err := ddlpg.NewParser(ddlpg.NewLexer(`CREATE TABLE users (
+    id UUID NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL,
+    age INT NOT NULL DEFAULT 0,
+    seq BIGSERIAL NOT NULL,
+    nickname TEXT
+);`)).Parse()
+		require.NoError(t, err)
+		result, err := ddlpg.Diff(before, after)
+		require.NoError(t, err)
+
+		s := FromPostgres(after)
+		s.AddedColumns = FromPostgres(result).AddedColumns
+
+		expected := `3:5: error: column "name" is added to "users" as NOT NULL without a default, which fails if the table has rows (not-null-column-added-without-default)
+`
+		actual, err := Lint(s, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+		assert.Equal(t, true, HasErrors(actual))
+	})
+
+	t.Run("success,Config", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlpg.NewParser(ddlpg.NewLexer(`CREATE TABLE logs (message TEXT);
+CREATE INDEX logs_message ON logs (message);
+`)).Parse()
+		require.NoError(t, err)
+
+		cfg, err := LoadConfig(strings.NewReader(`{
+  "rules": {
+    "no-primary-key": { "severity": "error" },
+    "naming-convention": { "options": { "index": "^idx_[a-z0-9_]+$", "table": "" } }
+  }
+}`))
+		require.NoError(t, err)
+
+		expected := `1:1: error: table "logs" has no primary key (no-primary-key)
+2:1: warning: index "logs_message" does not match the naming convention ^idx_[a-z0-9_]+$ (naming-convention)
+`
+		actual, err := Lint(FromPostgres(d), cfg)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+
+		cfg.Rules["no-primary-key"].Severity = SeverityOff
+		expected = `2:1: warning: index "logs_message" does not match the naming convention ^idx_[a-z0-9_]+$ (naming-convention)
+`
+		actual, err = Lint(FromPostgres(d), cfg)
+		require.NoError(t, err)
+		assert.Equal(t, expected, findingsString(actual))
+	})
+
+	t.Run("success,Filename", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlpg.NewParser(ddlpg.NewLexer(`CREATE TABLE logs (message TEXT);`)).Parse()
+		require.NoError(t, err)
+
+		actual, err := Lint(FromPostgres(d), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(actual))
+		actual[0].Filename = "schema.sql"
+		assert.Equal(t, `schema.sql:1:1: warning: table "logs" has no primary key (no-primary-key)`, actual[0].String())
+	})
+
+	t.Run("failure,ErrUnknownRule", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadConfig(strings.NewReader(`{"rules": {"no-such-rule": {"severity": "error"}}}`))
+		require.ErrorIs(t, err, ErrUnknownRule)
+	})
+
+	t.Run("failure,ErrInvalidSeverity", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadConfig(strings.NewReader(`{"rules": {"no-primary-key": {"severity": "fatal"}}}`))
+		require.ErrorIs(t, err, ErrInvalidSeverity)
+	})
+
+	t.Run("failure,ErrInvalidOption", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := ddlpg.NewParser(ddlpg.NewLexer(`CREATE TABLE logs (message TEXT);`)).Parse()
+		require.NoError(t, err)
+
+		for _, options := range []map[string]string{{"view": "^v_"}, {"index": "("}} {
+			_, err := Lint(FromPostgres(d), &Config{Rules: map[string]*RuleConfig{"naming-convention": {Options: options}}})
+			require.ErrorIs(t, err, ErrInvalidOption)
+		}
+	})
+
+	t.Run("success,nil", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := Lint(FromPostgres(nil), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(actual))
+	})
+}
@@ -0,0 +1,276 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+//nolint:gochecknoglobals
+var rules = []*Rule{
+	{
+		ID:          "no-primary-key",
+		Description: "table has no primary key",
+		Severity:    SeverityWarning,
+		check:       checkNoPrimaryKey,
+	},
+	{
+		ID:          "foreign-key-without-index",
+		Description: "foreign key has no index on the referencing columns, which makes the deletion from the referenced table scan the whole table",
+		Severity:    SeverityWarning,
+		// MEMO: MySQL and Spanner create an index for a foreign key automatically.
+		Dialects: []string{ddlpg.Dialect, ddlcrdb.Dialect},
+		check:    checkForeignKeyWithoutIndex,
+	},
+	{
+		ID:          "not-null-column-added-without-default",
+		Description: "NOT NULL column is added without a default, which fails if the table has rows",
+		Severity:    SeverityError,
+		check:       checkNotNullColumnAddedWithoutDefault,
+	},
+	{
+		ID:          "naming-convention",
+		Description: "name of a table, column, index or constraint does not match the naming convention",
+		Severity:    SeverityWarning,
+		check:       checkNamingConvention,
+	},
+	{
+		ID:          "reserved-word",
+		Description: "identifier is a reserved word, which must be quoted everywhere it is used",
+		Severity:    SeverityWarning,
+		check:       checkReservedWord,
+	},
+	{
+		ID:          "monotonic-primary-key",
+		Description: "primary key starts with a monotonically increasing value such as a timestamp or a sequence, which causes hotspots",
+		Severity:    SeverityWarning,
+		Dialects:    []string{ddlspanner.Dialect, ddlcrdb.Dialect},
+		check:       checkMonotonicPrimaryKey,
+	},
+}
+
+func checkNoPrimaryKey(s *Schema, _ map[string]string) ([]*Finding, error) {
+	findings := make([]*Finding, 0)
+	for _, t := range s.Tables {
+		if pk := t.PrimaryKey(); pk == nil || len(pk.Columns) == 0 {
+			findings = append(findings, &Finding{
+				Message: "table " + quote(t.QualifiedName()) + " has no primary key",
+				Pos:     t.Pos,
+			})
+		}
+	}
+	return findings, nil
+}
+
+func checkForeignKeyWithoutIndex(s *Schema, _ map[string]string) ([]*Finding, error) {
+	findings := make([]*Finding, 0)
+	for _, t := range s.Tables {
+		indexes := make([][]string, 0)
+		for _, c := range t.Constraints {
+			switch c.Kind { //nolint:exhaustive
+			case KindPrimaryKey, KindUnique, KindIndex:
+				indexes = append(indexes, c.Columns)
+			}
+		}
+		for _, i := range s.Indexes {
+			if strings.EqualFold(i.Table, t.QualifiedName()) || strings.EqualFold(i.Table, t.Name.Name) {
+				indexes = append(indexes, i.Columns)
+			}
+		}
+
+	LabelForeignKeys:
+		for _, fk := range t.Constraints {
+			if fk.Kind != KindForeignKey {
+				continue
+			}
+			for _, columns := range indexes {
+				if hasLeadingColumns(columns, fk.Columns) {
+					continue LabelForeignKeys
+				}
+			}
+			findings = append(findings, &Finding{
+				Message: "foreign key " + quote(fk.Name.Name) + " on " + quote(t.QualifiedName()) + " (" + strings.Join(fk.Columns, ", ") + ") has no index whose leading columns are the referencing columns",
+				Pos:     fk.Pos,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// hasLeadingColumns reports whether the leading columns of indexColumns are columns, in any order.
+func hasLeadingColumns(indexColumns, columns []string) bool {
+	if len(columns) == 0 || len(indexColumns) < len(columns) {
+		return false
+	}
+LabelColumns:
+	for _, c := range columns {
+		for _, ic := range indexColumns[:len(columns)] {
+			if strings.EqualFold(ic, c) {
+				continue LabelColumns
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func checkNotNullColumnAddedWithoutDefault(s *Schema, _ map[string]string) ([]*Finding, error) {
+	findings := make([]*Finding, 0)
+	for _, a := range s.AddedColumns {
+		if !a.Column.NotNull || a.Column.Default != "" || a.Column.Generated {
+			continue
+		}
+		findings = append(findings, &Finding{
+			Message: "column " + quote(a.Column.Name.Name) + " is added to " + quote(a.Table) + " as NOT NULL without a default, which fails if the table has rows",
+			Pos:     a.Column.Pos,
+		})
+	}
+	return findings, nil
+}
+
+const (
+	snakeCasePattern   = `^[a-z][a-z0-9_]*$`
+	spannerCasePattern = `^[A-Z][A-Za-z0-9_]*$`
+)
+
+// namingConventions returns the patterns of the names keyed by Kind.
+// The defaults are snake_case, or PascalCase that allows underscores for Spanner, and opts overrides them.
+// An empty pattern disables the check of the kind.
+func namingConventions(dialect string, opts map[string]string) (map[Kind]*regexp.Regexp, error) {
+	def := snakeCasePattern
+	if dialect == ddlspanner.Dialect {
+		def = spannerCasePattern
+	}
+	patterns := map[Kind]string{
+		KindTable:      def,
+		KindColumn:     def,
+		KindIndex:      def,
+		KindPrimaryKey: def,
+		KindForeignKey: def,
+		KindUnique:     def,
+		KindCheck:      def,
+	}
+	for k, v := range opts {
+		if _, ok := patterns[Kind(k)]; !ok {
+			return nil, apperr.Errorf("option=%s: %w", k, ErrInvalidOption)
+		}
+		patterns[Kind(k)] = v
+	}
+
+	conventions := make(map[Kind]*regexp.Regexp, len(patterns))
+	for k, v := range patterns {
+		if v == "" {
+			continue
+		}
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return nil, apperr.Errorf("option=%s: %w: %w", k, ErrInvalidOption, err)
+		}
+		conventions[k] = re
+	}
+	return conventions, nil
+}
+
+func checkNamingConvention(s *Schema, opts map[string]string) ([]*Finding, error) {
+	conventions, err := namingConventions(s.Dialect, opts)
+	if err != nil {
+		return nil, apperr.Errorf("namingConventions: %w", err)
+	}
+
+	findings := make([]*Finding, 0)
+	check := func(kind Kind, name *Name, pos ddl.Position) {
+		re, ok := conventions[kind]
+		if !ok || name == nil || re.MatchString(name.Name) {
+			return
+		}
+		findings = append(findings, &Finding{
+			Message: kind.String() + " " + quote(name.Name) + " does not match the naming convention " + re.String(),
+			Pos:     pos,
+		})
+	}
+
+	forEachName(s, check)
+	return findings, nil
+}
+
+func checkReservedWord(s *Schema, _ map[string]string) ([]*Finding, error) {
+	words := reservedWords[s.Dialect]
+
+	findings := make([]*Finding, 0)
+	check := func(kind Kind, name *Name, pos ddl.Position) {
+		if name == nil {
+			return
+		}
+		if _, ok := words[strings.ToUpper(name.Name)]; !ok {
+			return
+		}
+		findings = append(findings, &Finding{
+			Message: kind.String() + " " + quote(name.Name) + " is a reserved word in " + s.Dialect,
+			Pos:     pos,
+		})
+	}
+
+	forEachName(s, check)
+	return findings, nil
+}
+
+// forEachName calls fn with the names of the tables, the columns, the constraints and the indexes in s.
+// AddedColumns is not included, because the columns also appear in the tables of the schema after the change.
+func forEachName(s *Schema, fn func(kind Kind, name *Name, pos ddl.Position)) {
+	for _, t := range s.Tables {
+		fn(KindTable, t.Name, t.Pos)
+		for _, c := range t.Columns {
+			fn(KindColumn, c.Name, c.Pos)
+		}
+		for _, c := range t.Constraints {
+			fn(c.Kind, c.Name, c.Pos)
+		}
+	}
+	for _, i := range s.Indexes {
+		fn(KindIndex, i.Name, i.Pos)
+	}
+}
+
+func checkMonotonicPrimaryKey(s *Schema, _ map[string]string) ([]*Finding, error) {
+	findings := make([]*Finding, 0)
+	for _, t := range s.Tables {
+		pk := t.PrimaryKey()
+		if pk == nil || len(pk.Columns) == 0 {
+			continue
+		}
+		c := t.Column(pk.Columns[0])
+		if c == nil {
+			continue
+		}
+		if reason := monotonicReason(s.Dialect, c); reason != "" {
+			findings = append(findings, &Finding{
+				Message: "primary key of " + quote(t.QualifiedName()) + " starts with " + quote(c.Name.Name) + " (" + reason + "), which is monotonically increasing and causes hotspots",
+				Pos:     c.Pos,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// monotonicReason returns why the value of c is monotonically increasing, or empty if it is not.
+func monotonicReason(dialect string, c *Column) string {
+	def := strings.ToUpper(strings.ReplaceAll(c.Default, " ", ""))
+	switch {
+	case c.DataType == "TIMESTAMP", c.DataType == "TIMESTAMPTZ", c.DataType == "DATE":
+		return c.DataType
+	case strings.Contains(strings.ToLower(strings.ReplaceAll(c.Options, " ", "")), "allow_commit_timestamp=true"):
+		return "commit timestamp"
+	case strings.Contains(def, "NEXTVAL("):
+		return "sequence"
+	// MEMO: unique_rowid() of CockroachDB, which SERIAL uses by default, is ordered by the time.
+	case dialect == ddlcrdb.Dialect && (isSerial(c.DataType) || strings.Contains(def, "UNIQUE_ROWID(")):
+		return "unique_rowid()"
+	default:
+		return ""
+	}
+}
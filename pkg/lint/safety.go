@@ -0,0 +1,169 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+)
+
+// The IDs of the safety checks.
+const (
+	// SafetyTableRewrite is a statement that rewrites or copies the whole table.
+	SafetyTableRewrite = "table-rewrite"
+	// SafetyTableScan is a statement that scans the whole table while holding a lock.
+	SafetyTableScan = "table-scan"
+	// SafetyBlockingLock is a statement that blocks the reads or the writes of the table while it runs.
+	SafetyBlockingLock = "blocking-lock"
+	// SafetyBackfill is a statement that backfills an index or a column, which is online but long-running.
+	SafetyBackfill = "backfill"
+	// SafetyValidation is a statement that validates all the existing rows, which is long-running and fails if a row violates it.
+	SafetyValidation = "validation"
+	// SafetyUnsupported is a statement that the engine does not support, or supports only experimentally.
+	SafetyUnsupported = "unsupported-change"
+)
+
+// SafetyFinding is a risk of a statement in the result of Diff, such as a lock or a table rewrite.
+type SafetyFinding struct {
+	RuleID   string
+	Severity Severity
+	// Statement is the statement the finding is about, without the comments and the trailing semicolon.
+	Statement string
+	Message   string
+	// Plan is the steps to make the same change more safely. It is empty if no safer way is known.
+	Plan []string
+}
+
+func (f *SafetyFinding) String() string {
+	str := string(f.Severity) + ": " + f.Statement + "\n    " + f.Message + " (" + f.RuleID + ")"
+	if len(f.Plan) > 0 {
+		str += "\n    safer plan:"
+		for i, step := range f.Plan {
+			str += "\n      " + strconv.Itoa(i+1) + ". " + step
+		}
+	}
+	return str
+}
+
+// HasSafetyErrors reports whether findings contain a finding of SeverityError.
+func HasSafetyErrors(findings []*SafetyFinding) bool {
+	return slices.ContainsFunc(findings, func(f *SafetyFinding) bool { return f.Severity == SeverityError })
+}
+
+// engineVersion is the version of the database engine, such as 16.2 or 8.0.29.
+// The empty engineVersion means the latest version.
+type engineVersion []int
+
+func parseEngineVersion(s string) (engineVersion, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ".")
+	v := make(engineVersion, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, apperr.Errorf("version=%s: %w", s, ErrInvalidEngineVersion)
+		}
+		v = append(v, n)
+	}
+	return v, nil
+}
+
+// atLeast reports whether v is the version other or later. The latest version is later than any version.
+func (v engineVersion) atLeast(other ...int) bool {
+	if len(v) == 0 {
+		return true
+	}
+	for i, o := range other {
+		var n int
+		if i < len(v) {
+			n = v[i]
+		}
+		if n != o {
+			return n > o
+		}
+	}
+	return true
+}
+
+// statementString returns the statement without the comment lines and the trailing semicolon.
+func statementString(stmt fmt.Stringer) string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(stmt.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSuffix(strings.Join(lines, " "), ";")
+}
+
+// suffixed returns the identifier name with suffix, quoted with quotationMark.
+func suffixed(name, quotationMark, suffix string) string {
+	return quotationMark + name + suffix + quotationMark
+}
+
+// splitDataType splits a data type such as VARCHAR(255) or NUMERIC(10, 2) into the name in upper case and the parameters.
+// The parameters that are not integers, such as MAX of STRING(MAX), are returned as -1.
+func splitDataType(dataType string) (name string, params []int) {
+	name = dataType
+	if i := strings.Index(dataType, "("); i >= 0 {
+		name = dataType[:i]
+		for _, param := range strings.Split(strings.TrimSuffix(strings.TrimSpace(dataType[i+1:]), ")"), ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(param))
+			if err != nil {
+				n = -1
+			}
+			params = append(params, n)
+		}
+	}
+	name = strings.Join(strings.Fields(strings.ToUpper(name)), " ")
+	if alias, ok := dataTypeAliases[name]; ok {
+		name = alias
+	}
+	return name, params
+}
+
+//nolint:gochecknoglobals
+var dataTypeAliases = map[string]string{
+	"CHARACTER VARYING": "VARCHAR",
+	"CHARACTER":         "CHAR",
+	"DECIMAL":           "NUMERIC",
+	"BOOL":              "BOOLEAN",
+}
+
+// isWidening reports whether the parameters of the same data type are widened, such as VARCHAR(10) to VARCHAR(20).
+// No parameters means unlimited, and -1 such as MAX is also unlimited.
+func isWidening(before, after []int) bool {
+	if len(after) == 0 {
+		return true
+	}
+	if len(before) == 0 || len(before) != len(after) {
+		return false
+	}
+	for i := range before {
+		switch {
+		case after[i] == -1:
+		case before[i] == -1:
+			return false
+		case after[i] < before[i]:
+			return false
+		}
+	}
+	return true
+}
+
+func expandContractPlan(table, column, newColumn, dataType, cast string) []string {
+	return []string{
+		"ALTER TABLE " + table + " ADD COLUMN " + newColumn + " " + dataType + ";",
+		"keep " + newColumn + " in sync with " + column + " on write, by the application or a trigger",
+		"backfill the existing rows in batches: UPDATE " + table + " SET " + newColumn + " = " + cast + " WHERE " + newColumn + " IS NULL AND <primary key in batch>;",
+		"switch the reads and the writes of the application to " + newColumn,
+		"ALTER TABLE " + table + " DROP COLUMN " + column + "; and rename " + newColumn + " to " + column + " if needed",
+	}
+}
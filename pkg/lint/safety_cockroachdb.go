@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+)
+
+// CheckSafetyCockroachDB checks the statements in result, which is the result of Diff from before, against the backfills of CockroachDB.
+// version is the version of CockroachDB such as 23.1, and the latest version is assumed if empty.
+// CockroachDB changes the schema online, so the findings are about the long-running backfills rather than the locks.
+//
+//nolint:cyclop,funlen
+func CheckSafetyCockroachDB(before, result *ddlcrdb.DDL, version string) ([]*SafetyFinding, error) {
+	v, err := parseEngineVersion(version)
+	if err != nil {
+		return nil, apperr.Errorf("parseEngineVersion: %w", err)
+	}
+
+	findings := make([]*SafetyFinding, 0)
+	if result == nil {
+		return findings, nil
+	}
+	created := crdbCreatedTables(result)
+
+	for _, stmt := range result.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlcrdb.CreateIndexStmt:
+			if created[stmt.TableName.StringForDiff()] {
+				continue
+			}
+			findings = append(findings, &SafetyFinding{
+				RuleID:    SafetyBackfill,
+				Severity:  SeverityNote,
+				Statement: statementString(stmt),
+				Message:   "CREATE INDEX backfills the index from all the existing rows of " + stmt.TableName.String() + ", which is online but long-running",
+			})
+		case *ddlcrdb.AlterTableStmt:
+			table := stmt.Name.String()
+			switch a := stmt.Action.(type) {
+			case *ddlcrdb.AlterColumnSetDataType:
+				if bc := crdbBeforeColumn(before, stmt.Name, a.Name); bc != nil && crdbIsMetadataOnlyTypeChange(bc.DataType.String(), a.DataType.String()) {
+					continue
+				}
+				column := a.Name.String()
+				newColumn := suffixed(a.Name.Name, a.Name.QuotationMark, "_new")
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyUnsupported,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "SET DATA TYPE that rewrites the column is experimental, requires SET enable_experimental_alter_column_type_general = true, and cannot run in an explicit transaction",
+					Plan:      expandContractPlan(table, column, newColumn, a.DataType.String(), column+"::"+a.DataType.String()),
+				})
+			case *ddlcrdb.AddColumn:
+				if a.Column.Default == nil && a.Column.As == nil && !a.Column.NotNull {
+					continue
+				}
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyBackfill,
+					Severity:  SeverityNote,
+					Statement: statementString(stmt),
+					Message:   "ADD COLUMN with a DEFAULT, a computed value or NOT NULL backfills the primary index of " + table + ", which is online but long-running",
+				})
+			case *ddlcrdb.AddConstraint:
+				pk, ok := a.Constraint.(*ddlcrdb.PrimaryKeyConstraint)
+				if !ok {
+					continue
+				}
+				columns := make([]string, 0, len(pk.Columns))
+				for _, c := range pk.Columns {
+					columns = append(columns, c.Ident.String())
+				}
+				// MEMO: ALTER PRIMARY KEY is available in CockroachDB 20.1 and later.
+				if !v.atLeast(20, 1) {
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyUnsupported,
+						Severity:  SeverityError,
+						Statement: statementString(stmt),
+						Message:   "changing the primary key is not supported before CockroachDB 20.1",
+					})
+					continue
+				}
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyBackfill,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "changing the primary key rewrites the primary index and all the secondary indexes of " + table + " by a backfill, which is long-running and doubles the storage until it completes",
+					Plan: []string{
+						"run it when the traffic is low, and check the progress by SHOW JOBS",
+						"ALTER TABLE " + table + " ALTER PRIMARY KEY USING COLUMNS (" + strings.Join(columns, ", ") + "); instead of DROP CONSTRAINT and ADD CONSTRAINT, which keeps the old primary key as a unique index",
+					},
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func crdbCreatedTables(d *ddlcrdb.DDL) map[string]bool {
+	created := make(map[string]bool)
+	for _, stmt := range d.Stmts {
+		if stmt, ok := stmt.(*ddlcrdb.CreateTableStmt); ok {
+			created[stmt.GetNameForDiff()] = true
+		}
+	}
+	return created
+}
+
+// crdbBeforeColumn returns the column of the table in before, or nil if not found.
+func crdbBeforeColumn(before *ddlcrdb.DDL, table *ddlcrdb.ObjectName, column *ddlcrdb.Ident) *ddlcrdb.Column {
+	if before == nil {
+		return nil
+	}
+	for _, stmt := range before.Stmts {
+		if stmt, ok := stmt.(*ddlcrdb.CreateTableStmt); ok && stmt.GetNameForDiff() == table.StringForDiff() {
+			for _, c := range stmt.Columns {
+				if c.Name.StringForDiff() == column.StringForDiff() {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// crdbIsMetadataOnlyTypeChange reports whether CockroachDB changes the data type without rewriting the column,
+// such as INT4 to INT8, or VARCHAR(10) to VARCHAR(20) or STRING.
+func crdbIsMetadataOnlyTypeChange(before, after string) bool {
+	beforeName, beforeParams := splitDataType(before)
+	afterName, afterParams := splitDataType(after)
+	// MEMO: INT and INTEGER are INT8 in CockroachDB by default.
+	integerBytes := map[string]int{"INT2": 2, "SMALLINT": 2, "INT4": 4, "INT": 8, "INTEGER": 8, "INT8": 8, "BIGINT": 8}
+	strs := []string{"VARCHAR", "STRING", "TEXT"}
+	beforeBytes, beforeIsInteger := integerBytes[beforeName]
+	afterBytes, afterIsInteger := integerBytes[afterName]
+	switch {
+	case beforeIsInteger && afterIsInteger:
+		return beforeBytes <= afterBytes
+	case slices.Contains(strs, beforeName) && (afterName == "STRING" || afterName == "TEXT"):
+		return len(afterParams) == 0
+	case slices.Contains(strs, beforeName) && afterName == "VARCHAR":
+		return beforeName == "VARCHAR" && isWidening(beforeParams, afterParams)
+	case beforeName == "BYTES" && afterName == "BYTES":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,184 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+)
+
+// CheckSafetyMySQL checks the statements in result, which is the result of Diff from before, against the algorithms of the online DDL of MySQL (InnoDB).
+// version is the version of MySQL such as 8.0.29 or 5.7, and the latest version is assumed if empty.
+//
+//nolint:cyclop,funlen,gocognit
+func CheckSafetyMySQL(before, result *ddlmysql.DDL, version string) ([]*SafetyFinding, error) {
+	v, err := parseEngineVersion(version)
+	if err != nil {
+		return nil, apperr.Errorf("parseEngineVersion: %w", err)
+	}
+
+	findings := make([]*SafetyFinding, 0)
+	if result == nil {
+		return findings, nil
+	}
+
+	onlineSchemaChange := "use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes"
+
+	for _, stmt := range result.Stmts {
+		stmt, ok := stmt.(*ddlmysql.AlterTableStmt)
+		if !ok {
+			continue
+		}
+		table := stmt.Name.String()
+		switch a := stmt.Action.(type) {
+		case *ddlmysql.ModifyColumn:
+			bc := mysqlBeforeColumn(before, stmt.Name, a.Name)
+			switch {
+			case bc == nil:
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "MODIFY may require ALGORITHM=COPY, which copies the whole table and blocks the writes, but the column before the change is unknown",
+					Plan:      []string{"run it with ALGORITHM=INPLACE, LOCK=NONE, which fails instead of copying the table", "alternatively, " + onlineSchemaChange},
+				})
+			case !mysqlIsInplaceTypeChange(bc.DataType.String(), a.DataType.String()) || bc.Collate.StringForDiff() != a.Collate.StringForDiff():
+				column := a.Name.String()
+				newColumn := suffixed(a.Name.Name, a.Name.QuotationMark, "_new")
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "MODIFY that changes the data type or the collation requires ALGORITHM=COPY, which copies the whole table and blocks the writes",
+					Plan: append(
+						expandContractPlan(table, column, newColumn, a.DataType.String(), "CAST("+column+" AS "+a.DataType.String()+")"),
+						"alternatively, "+onlineSchemaChange,
+					),
+				})
+			case bc.NotNull != a.NotNull:
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "MODIFY that changes NULL or NOT NULL rebuilds the whole table in place, which allows the writes but is long-running",
+					Plan:      []string{onlineSchemaChange},
+				})
+			}
+		case *ddlmysql.AddColumn:
+			switch {
+			case a.Column.AutoIncrement:
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "ADD COLUMN with AUTO_INCREMENT requires ALGORITHM=COPY, which copies the whole table and blocks the writes",
+					Plan:      []string{onlineSchemaChange},
+				})
+			// MEMO: MySQL 8.0.12 and later adds a column as the last column by ALGORITHM=INSTANT, which ddlctl always does.
+			case !v.atLeast(8, 0, 12):
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "ADD COLUMN rebuilds the whole table in place before MySQL 8.0.12, which allows the writes but is long-running",
+					Plan:      []string{"upgrade to MySQL 8.0.12 or later, which adds the column by ALGORITHM=INSTANT", "alternatively, " + onlineSchemaChange},
+				})
+			}
+		case *ddlmysql.DropColumn:
+			// MEMO: MySQL 8.0.29 and later drops a column by ALGORITHM=INSTANT.
+			if !v.atLeast(8, 0, 29) {
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "DROP COLUMN rebuilds the whole table in place before MySQL 8.0.29, which allows the writes but is long-running",
+					Plan:      []string{onlineSchemaChange},
+				})
+			}
+		case *ddlmysql.AddConstraint:
+			switch a.Constraint.(type) {
+			case *ddlmysql.ForeignKeyConstraint:
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "ADD FOREIGN KEY requires ALGORITHM=COPY while foreign_key_checks is enabled, which copies the whole table and blocks the writes",
+					Plan: []string{
+						"SET foreign_key_checks = 0; in the same session, which allows ALGORITHM=INPLACE but does not validate the existing rows",
+						statementString(stmt) + ", ALGORITHM=INPLACE, LOCK=NONE;",
+						"SET foreign_key_checks = 1;",
+						"check that no existing row violates the foreign key",
+					},
+				})
+			case *ddlmysql.CheckConstraint:
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "ADD CHECK requires ALGORITHM=COPY, which copies the whole table and blocks the writes",
+					Plan:      []string{onlineSchemaChange},
+				})
+			case *ddlmysql.PrimaryKeyConstraint:
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "ADD PRIMARY KEY rebuilds the whole table in place, which allows the writes but is long-running",
+					Plan:      []string{onlineSchemaChange},
+				})
+			}
+		case *ddlmysql.AlterTableOption:
+			switch strings.ToUpper(a.Name) {
+			case "COMMENT", "AUTO_INCREMENT":
+				continue
+			}
+			findings = append(findings, &SafetyFinding{
+				RuleID:    SafetyTableRewrite,
+				Severity:  SeverityWarning,
+				Statement: statementString(stmt),
+				Message:   "changing the table option " + a.Name + " may rebuild or copy the whole table",
+				Plan:      []string{onlineSchemaChange},
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// mysqlBeforeColumn returns the column of the table in before, or nil if not found.
+func mysqlBeforeColumn(before *ddlmysql.DDL, table *ddlmysql.ObjectName, column *ddlmysql.Ident) *ddlmysql.Column {
+	if before == nil {
+		return nil
+	}
+	for _, stmt := range before.Stmts {
+		if stmt, ok := stmt.(*ddlmysql.CreateTableStmt); ok && stmt.GetNameForDiff() == table.StringForDiff() {
+			for _, c := range stmt.Columns {
+				if c.Name.StringForDiff() == column.StringForDiff() {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// mysqlIsInplaceTypeChange reports whether the data type is unchanged,
+// or is VARCHAR extended without changing the number of the length bytes, which MySQL does by ALGORITHM=INPLACE.
+func mysqlIsInplaceTypeChange(before, after string) bool {
+	beforeName, beforeParams := splitDataType(before)
+	afterName, afterParams := splitDataType(after)
+	if beforeName != afterName {
+		return false
+	}
+	if beforeName != "VARCHAR" {
+		return slices.Equal(beforeParams, afterParams)
+	}
+	if len(beforeParams) != 1 || len(afterParams) != 1 || afterParams[0] < beforeParams[0] {
+		return false
+	}
+	// MEMO: VARCHAR of 255 bytes or less has 1 length byte, and the others have 2 length bytes.
+	// The character set is assumed to be utf8mb4, whose character is up to 4 bytes.
+	const maxCharsFor1LengthByte = 255 / 4
+	return (beforeParams[0] <= maxCharsFor1LengthByte) == (afterParams[0] <= maxCharsFor1LengthByte)
+}
@@ -0,0 +1,256 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+)
+
+// CheckSafetyPostgres checks the statements in result, which is the result of Diff from before, against the locks and the table rewrites of PostgreSQL.
+// version is the version of PostgreSQL such as 16 or 10.5, and the latest version is assumed if empty.
+//
+//nolint:cyclop,funlen,gocognit
+func CheckSafetyPostgres(before, result *ddlpg.DDL, version string) ([]*SafetyFinding, error) {
+	v, err := parseEngineVersion(version)
+	if err != nil {
+		return nil, apperr.Errorf("parseEngineVersion: %w", err)
+	}
+
+	findings := make([]*SafetyFinding, 0)
+	if result == nil {
+		return findings, nil
+	}
+	created := pgCreatedTables(result)
+	// notValidChecks is the expressions of the CHECK constraints added as NOT VALID keyed by the table and the constraint name,
+	// and validChecks is the expressions validated afterwards keyed by the table and the expression.
+	notValidChecks, validChecks := make(map[string]string), make(map[string]bool)
+
+	for _, stmt := range result.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlpg.CreateIndexStmt:
+			if stmt.Concurrently || created[stmt.TableName.StringForDiff()] {
+				continue
+			}
+			findings = append(findings, &SafetyFinding{
+				RuleID:    SafetyBlockingLock,
+				Severity:  SeverityWarning,
+				Statement: statementString(stmt),
+				Message:   "CREATE INDEX blocks the writes to " + stmt.TableName.String() + " until the index is built",
+				Plan: []string{
+					"CREATE INDEX CONCURRENTLY outside of a transaction, which --index-concurrently generates",
+				},
+			})
+		case *ddlpg.AlterTableStmt:
+			table := stmt.Name.String()
+			switch a := stmt.Action.(type) {
+			case *ddlpg.AlterColumnSetDataType:
+				if bc := pgBeforeColumn(before, stmt.Name, a.Name); bc != nil && pgIsBinaryCoercible(bc.DataType.String(), a.DataType.String()) {
+					continue
+				}
+				column := a.Name.String()
+				newColumn := suffixed(a.Name.Name, a.Name.QuotationMark, "_new")
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "SET DATA TYPE rewrites the whole table and its indexes under ACCESS EXCLUSIVE lock, which blocks the reads and the writes",
+					Plan:      expandContractPlan(table, column, newColumn, a.DataType.String(), column+"::"+a.DataType.String()),
+				})
+			case *ddlpg.AddColumn:
+				reason := pgAddColumnRewriteReason(v, a.Column)
+				if reason == "" {
+					continue
+				}
+				column := a.Column.Name.String()
+				plan := []string{
+					"ALTER TABLE " + table + " ADD COLUMN " + column + " " + a.Column.DataType.String() + ";",
+				}
+				if a.Column.Default != nil {
+					plan = append(plan, "ALTER TABLE "+table+" ALTER COLUMN "+column+" SET DEFAULT "+a.Column.Default.Value.String()+"; which applies to the new rows only")
+				}
+				plan = append(plan, "backfill the existing rows in batches: UPDATE "+table+" SET "+column+" = ... WHERE "+column+" IS NULL AND <primary key in batch>;")
+				if a.Column.NotNull {
+					plan = append(plan, "set NOT NULL via a CHECK constraint NOT VALID and VALIDATE CONSTRAINT, which --safe-constraints generates")
+				}
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableRewrite,
+					Severity:  SeverityError,
+					Statement: statementString(stmt),
+					Message:   "ADD COLUMN " + reason + " rewrites the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes",
+					Plan:      plan,
+				})
+			case *ddlpg.ValidateConstraint:
+				if expr, ok := notValidChecks[stmt.Name.StringForDiff()+"."+a.Name.StringForDiff()]; ok {
+					validChecks[stmt.Name.StringForDiff()+"."+expr] = true
+				}
+			case *ddlpg.AlterColumnSetNotNull:
+				// MEMO: PostgreSQL 12 and later skips the scan of SET NOT NULL if a valid CHECK constraint proves it, which --safe-constraints generates.
+				if v.atLeast(12) && validChecks[stmt.Name.StringForDiff()+"."+pgNormalizeExpr(a.Name.StringForDiff()+" IS NOT NULL")] {
+					continue
+				}
+				column := a.Name.String()
+				check := suffixed(stmt.Name.Name.Name+"_"+a.Name.Name+"_not_null", a.Name.QuotationMark, "")
+				plan := []string{
+					"ALTER TABLE " + table + " ADD CONSTRAINT " + check + " CHECK (" + column + " IS NOT NULL) NOT VALID;",
+					"ALTER TABLE " + table + " VALIDATE CONSTRAINT " + check + "; which does not block the writes",
+				}
+				if v.atLeast(12) {
+					plan = append(plan,
+						"ALTER TABLE "+table+" ALTER COLUMN "+column+" SET NOT NULL; which uses the CHECK constraint instead of scanning",
+						"ALTER TABLE "+table+" DROP CONSTRAINT "+check+";",
+					)
+				}
+				findings = append(findings, &SafetyFinding{
+					RuleID:    SafetyTableScan,
+					Severity:  SeverityWarning,
+					Statement: statementString(stmt),
+					Message:   "SET NOT NULL scans the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes",
+					Plan:      plan,
+				})
+			case *ddlpg.AddConstraint:
+				switch c := a.Constraint.(type) {
+				case *ddlpg.ForeignKeyConstraint, *ddlpg.CheckConstraint:
+					if a.NotValid {
+						if check, ok := c.(*ddlpg.CheckConstraint); ok {
+							notValidChecks[stmt.Name.StringForDiff()+"."+check.Name.StringForDiff()] = pgNormalizeExpr(check.Expr.String())
+						}
+						continue
+					}
+					name := c.GetName().String()
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyTableScan,
+						Severity:  SeverityWarning,
+						Statement: statementString(stmt),
+						Message:   "ADD CONSTRAINT " + name + " validates all the existing rows while blocking the writes",
+						Plan: []string{
+							"ALTER TABLE " + table + " ADD " + c.String() + " NOT VALID;",
+							"ALTER TABLE " + table + " VALIDATE CONSTRAINT " + name + "; which does not block the writes",
+							"alternatively, use --safe-constraints, which generates the above",
+						},
+					})
+				case *ddlpg.PrimaryKeyConstraint, *ddlpg.UniqueConstraint:
+					kind, columns := "UNIQUE", ""
+					if pk, ok := c.(*ddlpg.PrimaryKeyConstraint); ok {
+						kind, columns = "PRIMARY KEY", pgColumnList(pk.Columns)
+					} else {
+						columns = pgColumnList(c.(*ddlpg.UniqueConstraint).Columns) //nolint:forcetypeassert
+					}
+					name := c.GetName().String()
+					index := suffixed(c.GetName().Name+"_idx", c.GetName().QuotationMark, "")
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyBlockingLock,
+						Severity:  SeverityWarning,
+						Statement: statementString(stmt),
+						Message:   "ADD " + kind + " builds the index under ACCESS EXCLUSIVE lock, which blocks the reads and the writes",
+						Plan: []string{
+							"CREATE UNIQUE INDEX CONCURRENTLY " + index + " ON " + table + " (" + columns + "); outside of a transaction",
+							"ALTER TABLE " + table + " ADD CONSTRAINT " + name + " " + kind + " USING INDEX " + index + ";",
+						},
+					})
+				}
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func pgCreatedTables(d *ddlpg.DDL) map[string]bool {
+	created := make(map[string]bool)
+	for _, stmt := range d.Stmts {
+		if stmt, ok := stmt.(*ddlpg.CreateTableStmt); ok {
+			created[stmt.GetNameForDiff()] = true
+		}
+	}
+	return created
+}
+
+// pgBeforeColumn returns the column of the table in before, or nil if not found.
+func pgBeforeColumn(before *ddlpg.DDL, table *ddlpg.ObjectName, column *ddlpg.Ident) *ddlpg.Column {
+	if before == nil {
+		return nil
+	}
+	for _, stmt := range before.Stmts {
+		if stmt, ok := stmt.(*ddlpg.CreateTableStmt); ok && stmt.GetNameForDiff() == table.StringForDiff() {
+			for _, c := range stmt.Columns {
+				if c.Name.StringForDiff() == column.StringForDiff() {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func pgColumnList(columns []*ddlpg.ColumnIdent) string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Ident.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// pgIsBinaryCoercible reports whether the data type can be changed without rewriting the table,
+// such as VARCHAR(10) to VARCHAR(20) or TEXT, or NUMERIC(10, 2) to NUMERIC(12, 2).
+func pgIsBinaryCoercible(before, after string) bool {
+	beforeName, beforeParams := splitDataType(before)
+	afterName, afterParams := splitDataType(after)
+	switch {
+	case (beforeName == "VARCHAR" || beforeName == "TEXT") && afterName == "TEXT":
+		return true
+	case beforeName == "VARCHAR" && afterName == "VARCHAR",
+		beforeName == "VARBIT" && afterName == "VARBIT":
+		return isWidening(beforeParams, afterParams)
+	case beforeName == "NUMERIC" && afterName == "NUMERIC":
+		// MEMO: the scale must not change, because the stored values would be rounded.
+		if len(afterParams) == 2 && (len(beforeParams) != 2 || beforeParams[1] != afterParams[1]) {
+			return false
+		}
+		return isWidening(beforeParams, afterParams)
+	case beforeName == "CIDR" && afterName == "INET":
+		return true
+	default:
+		return false
+	}
+}
+
+// pgAddColumnRewriteReason returns why ADD COLUMN rewrites the table, or empty if it does not.
+func pgAddColumnRewriteReason(v engineVersion, c *ddlpg.Column) string {
+	dataType, _ := splitDataType(c.DataType.String())
+	switch {
+	case c.Identity != nil:
+		return "with GENERATED AS IDENTITY"
+	case isSerial(dataType):
+		return "with " + dataType
+	case c.Default == nil:
+		return ""
+	// MEMO: PostgreSQL 11 and later stores a non-volatile default in the catalog, instead of rewriting the table.
+	case !v.atLeast(11):
+		return "with DEFAULT before PostgreSQL 11"
+	case pgIsVolatile(c.Default.Value.String()):
+		return "with the volatile DEFAULT " + c.Default.Value.String()
+	default:
+		return ""
+	}
+}
+
+// pgIsVolatile reports whether expr calls a well-known volatile function, whose value differs per row.
+func pgIsVolatile(expr string) bool {
+	expr = strings.ToLower(strings.ReplaceAll(expr, " ", ""))
+	for _, fn := range []string{"random(", "clock_timestamp(", "timeofday(", "gen_random_uuid(", "uuid_generate_v", "nextval("} {
+		if strings.Contains(expr, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// pgNormalizeExpr returns expr in lower case without the redundant spaces and the outermost parentheses, to compare the expressions.
+func pgNormalizeExpr(expr string) string {
+	expr = strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	for strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	return expr
+}
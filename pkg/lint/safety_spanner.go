@@ -0,0 +1,138 @@
+package lint
+
+import (
+	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+// CheckSafetySpanner checks the statements in result, which is the result of Diff from before, against the validations and the backfills of Spanner.
+// version is accepted for consistency with the other dialects, because Spanner has no engine version.
+//
+//nolint:cyclop,funlen
+func CheckSafetySpanner(before, result *ddlspanner.DDL, version string) ([]*SafetyFinding, error) {
+	if _, err := parseEngineVersion(version); err != nil {
+		return nil, apperr.Errorf("parseEngineVersion: %w", err)
+	}
+
+	findings := make([]*SafetyFinding, 0)
+	if result == nil {
+		return findings, nil
+	}
+	created := spannerCreatedTables(result)
+
+	for _, stmt := range result.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlspanner.CreateIndexStmt:
+			if created[stmt.TableName.StringForDiff()] {
+				continue
+			}
+			findings = append(findings, &SafetyFinding{
+				RuleID:    SafetyBackfill,
+				Severity:  SeverityNote,
+				Statement: statementString(stmt),
+				Message:   "CREATE INDEX backfills the index from all the existing rows of " + stmt.TableName.String() + ", which is a long-running operation",
+			})
+		case *ddlspanner.AlterTableStmt:
+			table := stmt.Name.String()
+			switch a := stmt.Action.(type) {
+			case *ddlspanner.AlterColumnDataType:
+				bc := spannerBeforeColumn(before, stmt.Name, a.Name)
+				column := a.Name.String()
+				switch {
+				case bc != nil && !spannerIsAllowedTypeChange(bc.DataType.String(), a.DataType.String()):
+					newColumn := suffixed(a.Name.Name, a.Name.QuotationMark, "_new")
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyUnsupported,
+						Severity:  SeverityError,
+						Statement: statementString(stmt),
+						Message:   "Spanner does not support changing the data type from " + bc.DataType.String() + " to " + a.DataType.String(),
+						Plan:      expandContractPlan(table, column, newColumn, a.DataType.String(), "CAST("+column+" AS "+a.DataType.String()+")"),
+					})
+				case bc == nil || bc.DataType.StringForDiff() != a.DataType.StringForDiff():
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyValidation,
+						Severity:  SeverityWarning,
+						Statement: statementString(stmt),
+						Message:   "changing the data type validates all the existing values of " + column + ", which is a long-running operation and fails if a value does not fit",
+						Plan:      []string{"check that no existing value exceeds the new length before applying"},
+					})
+				case a.NotNull && !bc.NotNull:
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyValidation,
+						Severity:  SeverityWarning,
+						Statement: statementString(stmt),
+						Message:   "adding NOT NULL validates all the existing rows of " + table + ", which is a long-running operation and fails if a NULL exists",
+						Plan: []string{
+							"backfill the NULLs in batches: UPDATE " + table + " SET " + column + " = ... WHERE " + column + " IS NULL; or by a Partitioned DML",
+							"then apply the statement",
+						},
+					})
+				}
+			case *ddlspanner.AddConstraint:
+				switch a.Constraint.(type) {
+				case *ddlspanner.ForeignKeyConstraint:
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyValidation,
+						Severity:  SeverityWarning,
+						Statement: statementString(stmt),
+						Message:   "ADD FOREIGN KEY validates all the existing rows of " + table + " and backfills the backing indexes, which is a long-running operation and fails if a row violates it",
+					})
+				case *ddlspanner.CheckConstraint:
+					findings = append(findings, &SafetyFinding{
+						RuleID:    SafetyValidation,
+						Severity:  SeverityWarning,
+						Statement: statementString(stmt),
+						Message:   "ADD CHECK validates all the existing rows of " + table + ", which is a long-running operation and fails if a row violates it",
+					})
+				}
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func spannerCreatedTables(d *ddlspanner.DDL) map[string]bool {
+	created := make(map[string]bool)
+	for _, stmt := range d.Stmts {
+		if stmt, ok := stmt.(*ddlspanner.CreateTableStmt); ok {
+			created[stmt.GetNameForDiff()] = true
+		}
+	}
+	return created
+}
+
+// spannerBeforeColumn returns the column of the table in before, or nil if not found.
+func spannerBeforeColumn(before *ddlspanner.DDL, table *ddlspanner.ObjectName, column *ddlspanner.Ident) *ddlspanner.Column {
+	if before == nil {
+		return nil
+	}
+	for _, stmt := range before.Stmts {
+		if stmt, ok := stmt.(*ddlspanner.CreateTableStmt); ok && stmt.GetNameForDiff() == table.StringForDiff() {
+			for _, c := range stmt.Columns {
+				if c.Name.StringForDiff() == column.StringForDiff() {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// spannerIsAllowedTypeChange reports whether Spanner supports the change of the data type,
+// which is between STRING and BYTES, or of the length of them, including in ARRAY.
+func spannerIsAllowedTypeChange(before, after string) bool {
+	beforeName, _ := splitDataType(before)
+	afterName, _ := splitDataType(after)
+	if beforeName == afterName {
+		return true
+	}
+	switch beforeName {
+	case "STRING", "BYTES":
+		return afterName == "STRING" || afterName == "BYTES"
+	case "ARRAY<STRING", "ARRAY<BYTES":
+		return afterName == "ARRAY<STRING" || afterName == "ARRAY<BYTES"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,393 @@
+//nolint:testpackage
+package lint
+
+import (
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+func safetyFindingsString(findings []*SafetyFinding) string {
+	var str string
+	for _, f := range findings {
+		str += f.String() + "\n"
+	}
+	return str
+}
+
+func TestCheckSafetyPostgres(t *testing.T) {
+	t.Parallel()
+
+	const beforeDDL = `CREATE TABLE users (
+    id UUID NOT NULL PRIMARY KEY,
+    name VARCHAR(10) NOT NULL,
+    age INT,
+    group_id UUID
+);
+CREATE TABLE groups (id UUID NOT NULL PRIMARY KEY);
+`
+	const afterDDL = `CREATE TABLE users (
+    id UUID NOT NULL PRIMARY KEY,
+    name VARCHAR(20) NOT NULL,
+    age BIGINT NOT NULL,
+    group_id UUID REFERENCES groups (id),
+    token UUID DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+CREATE INDEX users_name_idx ON users (name);
+CREATE TABLE groups (id UUID NOT NULL PRIMARY KEY);
+CREATE TABLE logs (id UUID NOT NULL PRIMARY KEY, message TEXT);
+CREATE INDEX logs_message_idx ON logs (message);
+`
+
+	diff := func(t *testing.T, opts ...ddlpg.DiffOption) (before, result *ddlpg.DDL) {
+		t.Helper()
+		before, err := ddlpg.NewParser(ddlpg.NewLexer(beforeDDL)).Parse()
+		require.NoError(t, err)
+		after, err := ddlpg.NewParser(ddlpg.NewLexer(afterDDL)).Parse()
+		require.NoError(t, err)
+		result, err = ddlpg.Diff(before, after, opts...)
+		require.NoError(t, err)
+		return before, result
+	}
+
+	t.Run("success,latest", func(t *testing.T) {
+		t.Parallel()
+
+		before, result := diff(t)
+		expected := `warning: CREATE INDEX users_name_idx ON users (name)
+    CREATE INDEX blocks the writes to users until the index is built (blocking-lock)
+    safer plan:
+      1. CREATE INDEX CONCURRENTLY outside of a transaction, which --index-concurrently generates
+error: ALTER TABLE users ALTER COLUMN age SET DATA TYPE BIGINT
+    SET DATA TYPE rewrites the whole table and its indexes under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN age_new BIGINT;
+      2. keep age_new in sync with age on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET age_new = age::BIGINT WHERE age_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to age_new
+      5. ALTER TABLE users DROP COLUMN age; and rename age_new to age if needed
+warning: ALTER TABLE users ALTER COLUMN age SET NOT NULL
+    SET NOT NULL scans the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-scan)
+    safer plan:
+      1. ALTER TABLE users ADD CONSTRAINT users_age_not_null CHECK (age IS NOT NULL) NOT VALID;
+      2. ALTER TABLE users VALIDATE CONSTRAINT users_age_not_null; which does not block the writes
+      3. ALTER TABLE users ALTER COLUMN age SET NOT NULL; which uses the CHECK constraint instead of scanning
+      4. ALTER TABLE users DROP CONSTRAINT users_age_not_null;
+error: ALTER TABLE users ADD COLUMN token UUID DEFAULT gen_random_uuid()
+    ADD COLUMN with the volatile DEFAULT gen_random_uuid() rewrites the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN token UUID;
+      2. ALTER TABLE users ALTER COLUMN token SET DEFAULT gen_random_uuid(); which applies to the new rows only
+      3. backfill the existing rows in batches: UPDATE users SET token = ... WHERE token IS NULL AND <primary key in batch>;
+warning: ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id)
+    ADD CONSTRAINT users_group_id_fkey validates all the existing rows while blocking the writes (table-scan)
+    safer plan:
+      1. ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) NOT VALID;
+      2. ALTER TABLE users VALIDATE CONSTRAINT users_group_id_fkey; which does not block the writes
+      3. alternatively, use --safe-constraints, which generates the above
+`
+		actual, err := CheckSafetyPostgres(before, result, "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+		assert.Equal(t, true, HasSafetyErrors(actual))
+	})
+
+	t.Run("success,10", func(t *testing.T) {
+		t.Parallel()
+
+		before, result := diff(t)
+		expected := `warning: CREATE INDEX users_name_idx ON users (name)
+    CREATE INDEX blocks the writes to users until the index is built (blocking-lock)
+    safer plan:
+      1. CREATE INDEX CONCURRENTLY outside of a transaction, which --index-concurrently generates
+error: ALTER TABLE users ALTER COLUMN age SET DATA TYPE BIGINT
+    SET DATA TYPE rewrites the whole table and its indexes under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN age_new BIGINT;
+      2. keep age_new in sync with age on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET age_new = age::BIGINT WHERE age_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to age_new
+      5. ALTER TABLE users DROP COLUMN age; and rename age_new to age if needed
+warning: ALTER TABLE users ALTER COLUMN age SET NOT NULL
+    SET NOT NULL scans the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-scan)
+    safer plan:
+      1. ALTER TABLE users ADD CONSTRAINT users_age_not_null CHECK (age IS NOT NULL) NOT VALID;
+      2. ALTER TABLE users VALIDATE CONSTRAINT users_age_not_null; which does not block the writes
+error: ALTER TABLE users ADD COLUMN token UUID DEFAULT gen_random_uuid()
+    ADD COLUMN with DEFAULT before PostgreSQL 11 rewrites the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN token UUID;
+      2. ALTER TABLE users ALTER COLUMN token SET DEFAULT gen_random_uuid(); which applies to the new rows only
+      3. backfill the existing rows in batches: UPDATE users SET token = ... WHERE token IS NULL AND <primary key in batch>;
+error: ALTER TABLE users ADD COLUMN created_at TIMESTAMPTZ DEFAULT now()
+    ADD COLUMN with DEFAULT before PostgreSQL 11 rewrites the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN created_at TIMESTAMPTZ;
+      2. ALTER TABLE users ALTER COLUMN created_at SET DEFAULT now(); which applies to the new rows only
+      3. backfill the existing rows in batches: UPDATE users SET created_at = ... WHERE created_at IS NULL AND <primary key in batch>;
+warning: ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id)
+    ADD CONSTRAINT users_group_id_fkey validates all the existing rows while blocking the writes (table-scan)
+    safer plan:
+      1. ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) NOT VALID;
+      2. ALTER TABLE users VALIDATE CONSTRAINT users_group_id_fkey; which does not block the writes
+      3. alternatively, use --safe-constraints, which generates the above
+`
+		actual, err := CheckSafetyPostgres(before, result, "10.5")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+	})
+
+	t.Run("success,safe_options", func(t *testing.T) {
+		t.Parallel()
+
+		before, result := diff(t, ddlpg.DiffUseIndexConcurrently(true), ddlpg.DiffUseSafeConstraints(true))
+		expected := `error: ALTER TABLE users ALTER COLUMN age SET DATA TYPE BIGINT
+    SET DATA TYPE rewrites the whole table and its indexes under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN age_new BIGINT;
+      2. keep age_new in sync with age on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET age_new = age::BIGINT WHERE age_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to age_new
+      5. ALTER TABLE users DROP COLUMN age; and rename age_new to age if needed
+error: ALTER TABLE users ADD COLUMN token UUID DEFAULT gen_random_uuid()
+    ADD COLUMN with the volatile DEFAULT gen_random_uuid() rewrites the whole table under ACCESS EXCLUSIVE lock, which blocks the reads and the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN token UUID;
+      2. ALTER TABLE users ALTER COLUMN token SET DEFAULT gen_random_uuid(); which applies to the new rows only
+      3. backfill the existing rows in batches: UPDATE users SET token = ... WHERE token IS NULL AND <primary key in batch>;
+`
+		actual, err := CheckSafetyPostgres(before, result, "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+	})
+
+	t.Run("failure,version", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CheckSafetyPostgres(nil, nil, "sixteen")
+		require.ErrorIs(t, err, ErrInvalidEngineVersion)
+	})
+}
+
+func TestCheckSafetyCockroachDB(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(`CREATE TABLE users (
+    id INT8 NOT NULL,
+    email STRING NOT NULL,
+    age INT4,
+    score INT8,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := ddlcrdb.NewParser(ddlcrdb.NewLexer(`CREATE TABLE users (
+    id INT8 NOT NULL,
+    email STRING NOT NULL,
+    age INT8,
+    score STRING,
+    active BOOL NOT NULL DEFAULT true,
+    CONSTRAINT users_pkey PRIMARY KEY (email, id)
+);
+CREATE INDEX users_age_idx ON users (age);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := ddlcrdb.Diff(before, after)
+		require.NoError(t, err)
+
+		expected := `note: CREATE INDEX users_age_idx ON users (age)
+    CREATE INDEX backfills the index from all the existing rows of users, which is online but long-running (backfill)
+error: ALTER TABLE users ALTER COLUMN score SET DATA TYPE STRING
+    SET DATA TYPE that rewrites the column is experimental, requires SET enable_experimental_alter_column_type_general = true, and cannot run in an explicit transaction (unsupported-change)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN score_new STRING;
+      2. keep score_new in sync with score on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET score_new = score::STRING WHERE score_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to score_new
+      5. ALTER TABLE users DROP COLUMN score; and rename score_new to score if needed
+note: ALTER TABLE users ADD COLUMN active BOOL NOT NULL DEFAULT true
+    ADD COLUMN with a DEFAULT, a computed value or NOT NULL backfills the primary index of users, which is online but long-running (backfill)
+warning: ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (email, id)
+    changing the primary key rewrites the primary index and all the secondary indexes of users by a backfill, which is long-running and doubles the storage until it completes (backfill)
+    safer plan:
+      1. run it when the traffic is low, and check the progress by SHOW JOBS
+      2. ALTER TABLE users ALTER PRIMARY KEY USING COLUMNS (email, id); instead of DROP CONSTRAINT and ADD CONSTRAINT, which keeps the old primary key as a unique index
+`
+		actual, err := CheckSafetyCockroachDB(before, result, "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+
+		expected = `note: CREATE INDEX users_age_idx ON users (age)
+    CREATE INDEX backfills the index from all the existing rows of users, which is online but long-running (backfill)
+error: ALTER TABLE users ALTER COLUMN score SET DATA TYPE STRING
+    SET DATA TYPE that rewrites the column is experimental, requires SET enable_experimental_alter_column_type_general = true, and cannot run in an explicit transaction (unsupported-change)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN score_new STRING;
+      2. keep score_new in sync with score on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET score_new = score::STRING WHERE score_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to score_new
+      5. ALTER TABLE users DROP COLUMN score; and rename score_new to score if needed
+note: ALTER TABLE users ADD COLUMN active BOOL NOT NULL DEFAULT true
+    ADD COLUMN with a DEFAULT, a computed value or NOT NULL backfills the primary index of users, which is online but long-running (backfill)
+error: ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (email, id)
+    changing the primary key is not supported before CockroachDB 20.1 (unsupported-change)
+`
+		actual, err = CheckSafetyCockroachDB(before, result, "19.2")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+	})
+}
+
+func TestCheckSafetyMySQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := ddlmysql.NewParser(ddlmysql.NewLexer("CREATE TABLE users (\n" +
+			"    id INT NOT NULL,\n" +
+			"    name VARCHAR(30) NOT NULL,\n" +
+			"    email VARCHAR(100),\n" +
+			"    age INT,\n" +
+			"    nickname VARCHAR(10),\n" +
+			"    group_id INT,\n" +
+			"    PRIMARY KEY (id)\n" +
+			");\n")).Parse()
+		require.NoError(t, err)
+		after, err := ddlmysql.NewParser(ddlmysql.NewLexer("CREATE TABLE users (\n" +
+			"    id INT NOT NULL,\n" +
+			"    name VARCHAR(60) NOT NULL,\n" +
+			"    email VARCHAR(200),\n" +
+			"    age BIGINT,\n" +
+			"    group_id INT NOT NULL,\n" +
+			"    created_at DATETIME,\n" +
+			"    PRIMARY KEY (id),\n" +
+			"    CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES user_groups (id)\n" +
+			");\n")).Parse()
+		require.NoError(t, err)
+		result, err := ddlmysql.Diff(before, after)
+		require.NoError(t, err)
+
+		expected := `error: ALTER TABLE users MODIFY age BIGINT NULL
+    MODIFY that changes the data type or the collation requires ALGORITHM=COPY, which copies the whole table and blocks the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN age_new BIGINT;
+      2. keep age_new in sync with age on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET age_new = CAST(age AS BIGINT) WHERE age_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to age_new
+      5. ALTER TABLE users DROP COLUMN age; and rename age_new to age if needed
+      6. alternatively, use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes
+warning: ALTER TABLE users MODIFY group_id INT NOT NULL
+    MODIFY that changes NULL or NOT NULL rebuilds the whole table in place, which allows the writes but is long-running (table-rewrite)
+    safer plan:
+      1. use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes
+error: ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES user_groups (id)
+    ADD FOREIGN KEY requires ALGORITHM=COPY while foreign_key_checks is enabled, which copies the whole table and blocks the writes (table-rewrite)
+    safer plan:
+      1. SET foreign_key_checks = 0; in the same session, which allows ALGORITHM=INPLACE but does not validate the existing rows
+      2. ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES user_groups (id), ALGORITHM=INPLACE, LOCK=NONE;
+      3. SET foreign_key_checks = 1;
+      4. check that no existing row violates the foreign key
+`
+		actual, err := CheckSafetyMySQL(before, result, "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+
+		expected = `error: ALTER TABLE users MODIFY age BIGINT NULL
+    MODIFY that changes the data type or the collation requires ALGORITHM=COPY, which copies the whole table and blocks the writes (table-rewrite)
+    safer plan:
+      1. ALTER TABLE users ADD COLUMN age_new BIGINT;
+      2. keep age_new in sync with age on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE users SET age_new = CAST(age AS BIGINT) WHERE age_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to age_new
+      5. ALTER TABLE users DROP COLUMN age; and rename age_new to age if needed
+      6. alternatively, use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes
+warning: ALTER TABLE users DROP COLUMN nickname
+    DROP COLUMN rebuilds the whole table in place before MySQL 8.0.29, which allows the writes but is long-running (table-rewrite)
+    safer plan:
+      1. use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes
+warning: ALTER TABLE users MODIFY group_id INT NOT NULL
+    MODIFY that changes NULL or NOT NULL rebuilds the whole table in place, which allows the writes but is long-running (table-rewrite)
+    safer plan:
+      1. use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes
+warning: ALTER TABLE users ADD COLUMN created_at DATETIME NULL
+    ADD COLUMN rebuilds the whole table in place before MySQL 8.0.12, which allows the writes but is long-running (table-rewrite)
+    safer plan:
+      1. upgrade to MySQL 8.0.12 or later, which adds the column by ALGORITHM=INSTANT
+      2. alternatively, use an online schema change tool such as gh-ost or pt-online-schema-change, which copies the table without blocking the writes
+error: ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES user_groups (id)
+    ADD FOREIGN KEY requires ALGORITHM=COPY while foreign_key_checks is enabled, which copies the whole table and blocks the writes (table-rewrite)
+    safer plan:
+      1. SET foreign_key_checks = 0; in the same session, which allows ALGORITHM=INPLACE but does not validate the existing rows
+      2. ALTER TABLE users ADD CONSTRAINT users_group_id_fkey FOREIGN KEY (group_id) REFERENCES user_groups (id), ALGORITHM=INPLACE, LOCK=NONE;
+      3. SET foreign_key_checks = 1;
+      4. check that no existing row violates the foreign key
+`
+		actual, err = CheckSafetyMySQL(before, result, "5.7")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+	})
+}
+
+func TestCheckSafetySpanner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+
+		before, err := ddlspanner.NewParser(ddlspanner.NewLexer(`CREATE TABLE Users (
+    UserId STRING(36) NOT NULL,
+    Name STRING(100),
+    Age INT64,
+    GroupId STRING(36)
+) PRIMARY KEY (UserId);
+CREATE TABLE Groups (GroupId STRING(36) NOT NULL) PRIMARY KEY (GroupId);
+`)).Parse()
+		require.NoError(t, err)
+		after, err := ddlspanner.NewParser(ddlspanner.NewLexer(`CREATE TABLE Users (
+    UserId STRING(36) NOT NULL,
+    Name STRING(50) NOT NULL,
+    Age STRING(MAX),
+    GroupId STRING(36),
+    CONSTRAINT FK_UsersGroups FOREIGN KEY (GroupId) REFERENCES Groups (GroupId)
+) PRIMARY KEY (UserId);
+CREATE INDEX UsersByName ON Users (Name);
+CREATE TABLE Groups (GroupId STRING(36) NOT NULL) PRIMARY KEY (GroupId);
+`)).Parse()
+		require.NoError(t, err)
+		result, err := ddlspanner.Diff(before, after)
+		require.NoError(t, err)
+
+		expected := `note: CREATE INDEX UsersByName ON Users (Name)
+    CREATE INDEX backfills the index from all the existing rows of Users, which is a long-running operation (backfill)
+warning: ALTER TABLE Users ALTER COLUMN Name STRING(50) NOT NULL
+    changing the data type validates all the existing values of Name, which is a long-running operation and fails if a value does not fit (validation)
+    safer plan:
+      1. check that no existing value exceeds the new length before applying
+error: ALTER TABLE Users ALTER COLUMN Age STRING(MAX)
+    Spanner does not support changing the data type from INT64 to STRING(MAX) (unsupported-change)
+    safer plan:
+      1. ALTER TABLE Users ADD COLUMN Age_new STRING(MAX);
+      2. keep Age_new in sync with Age on write, by the application or a trigger
+      3. backfill the existing rows in batches: UPDATE Users SET Age_new = CAST(Age AS STRING(MAX)) WHERE Age_new IS NULL AND <primary key in batch>;
+      4. switch the reads and the writes of the application to Age_new
+      5. ALTER TABLE Users DROP COLUMN Age; and rename Age_new to Age if needed
+warning: ALTER TABLE Users ADD CONSTRAINT FK_UsersGroups FOREIGN KEY (GroupId) REFERENCES Groups (GroupId)
+    ADD FOREIGN KEY validates all the existing rows of Users and backfills the backing indexes, which is a long-running operation and fails if a row violates it (validation)
+`
+		actual, err := CheckSafetySpanner(before, result, "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, safetyFindingsString(actual))
+		assert.Equal(t, true, HasSafetyErrors(actual))
+	})
+}
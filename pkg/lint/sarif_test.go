@@ -0,0 +1,50 @@
+//nolint:testpackage
+package lint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	assert "github.com/hakadoriya/z.go/testingz/assertz"
+	require "github.com/hakadoriya/z.go/testingz/requirez"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		findings := []*Finding{
+			{RuleID: "no-primary-key", Severity: SeverityError, Message: `table "logs" has no primary key`, Filename: "db/schema.sql", Pos: ddl.Position{Offset: 10, Line: 2, Column: 1}},
+			{RuleID: "reserved-word", Severity: SeverityWarning, Message: `column "order" is a reserved word in postgres`},
+		}
+
+		b := new(strings.Builder)
+		require.NoError(t, WriteSARIF(b, findings, "v1.0.0"))
+
+		var actual sarifLog
+		require.NoError(t, json.Unmarshal([]byte(b.String()), &actual))
+		assert.Equal(t, "2.1.0", actual.Version)
+		require.Equal(t, 1, len(actual.Runs))
+		run := actual.Runs[0]
+		assert.Equal(t, "ddlctl", run.Tool.Driver.Name)
+		assert.Equal(t, "v1.0.0", run.Tool.Driver.Version)
+		assert.Equal(t, len(Rules()), len(run.Tool.Driver.Rules))
+		require.Equal(t, 2, len(run.Results))
+
+		assert.Equal(t, "no-primary-key", run.Results[0].RuleID)
+		assert.Equal(t, "no-primary-key", run.Tool.Driver.Rules[run.Results[0].RuleIndex].ID)
+		assert.Equal(t, "error", run.Results[0].Level)
+		require.Equal(t, 1, len(run.Results[0].Locations))
+		assert.Equal(t, "db/schema.sql", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, &sarifRegion{StartLine: 2, StartColumn: 1}, run.Results[0].Locations[0].PhysicalLocation.Region)
+
+		assert.Equal(t, "reserved-word", run.Tool.Driver.Rules[run.Results[1].RuleIndex].ID)
+		assert.Equal(t, "warning", run.Results[1].Level)
+		assert.Equal(t, 0, len(run.Results[1].Locations))
+	})
+}
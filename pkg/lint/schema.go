@@ -0,0 +1,147 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/hakadoriya/ddlctl/pkg/ddl"
+)
+
+// Schema is the dialect independent model of DDL that the rules are run over.
+type Schema struct {
+	Dialect string
+	Tables  []*Table
+	Indexes []*Index
+	// AddedColumns is the columns added to the existing tables, which is taken from the result of Diff.
+	AddedColumns []*AddedColumn
+}
+
+// Table represents CREATE TABLE.
+type Table struct {
+	Schema      string
+	Name        *Name
+	Pos         ddl.Position
+	Columns     []*Column
+	Constraints []*Constraint
+}
+
+// QualifiedName returns the table name qualified with the schema name, if any.
+func (t *Table) QualifiedName() string {
+	if t.Schema == "" {
+		return t.Name.Name
+	}
+	return t.Schema + "." + t.Name.Name
+}
+
+// Column returns the column named name, or nil if not found.
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// PrimaryKey returns the PRIMARY KEY constraint of the table, or nil if not found.
+func (t *Table) PrimaryKey() *Constraint {
+	for _, c := range t.Constraints {
+		if c.Kind == KindPrimaryKey {
+			return c
+		}
+	}
+	return nil
+}
+
+// Name is an identifier, without the quotation marks.
+type Name struct {
+	Name   string
+	Quoted bool
+}
+
+func (n *Name) String() string {
+	return n.Name
+}
+
+// Column represents a column definition.
+type Column struct {
+	Name *Name
+	Pos  ddl.Position
+	// DataType is the data type name in upper case, without the parameters such as (255).
+	DataType string
+	NotNull  bool
+	// Default is the DEFAULT expression, or empty if the column has no default.
+	Default string
+	// Generated reports whether the value is generated by the database, such as IDENTITY, AUTO_INCREMENT or AS (...) STORED.
+	Generated bool
+	// Options is the column options, such as OPTIONS (allow_commit_timestamp = true) of Spanner.
+	Options string
+}
+
+// Kind is the kind of a constraint or an index, which is also used as the option key of naming-convention.
+type Kind string
+
+const (
+	KindTable      Kind = "table"
+	KindColumn     Kind = "column"
+	KindIndex      Kind = "index"
+	KindPrimaryKey Kind = "primary_key"
+	KindForeignKey Kind = "foreign_key"
+	KindUnique     Kind = "unique"
+	KindCheck      Kind = "check"
+)
+
+func (k Kind) String() string {
+	return strings.ReplaceAll(string(k), "_", " ")
+}
+
+// Constraint represents a table constraint or a column constraint.
+type Constraint struct {
+	Kind Kind
+	Name *Name
+	// Pos is the position of the table constraint, or the position of the column for a column constraint.
+	Pos        ddl.Position
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// Index represents CREATE INDEX.
+type Index struct {
+	Name    *Name
+	Pos     ddl.Position
+	Table   string
+	Columns []string
+	Unique  bool
+}
+
+// AddedColumn represents ALTER TABLE ... ADD COLUMN.
+type AddedColumn struct {
+	Table  string
+	Column *Column
+}
+
+// constraintPos returns pos, or the position of the first column of the constraint if pos is zero.
+func constraintPos(pos ddl.Position, t *Table, columns []string) ddl.Position {
+	if pos != (ddl.Position{}) {
+		return pos
+	}
+	if len(columns) > 0 {
+		if c := t.Column(columns[0]); c != nil {
+			return c.Pos
+		}
+	}
+	return t.Pos
+}
+
+// dataTypeName returns the data type name in upper case, without the parameters.
+func dataTypeName(name string) string {
+	if i := strings.Index(name, "("); i >= 0 {
+		name = name[:i]
+	}
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// isSerial reports whether dataType is SERIAL, BIGSERIAL or SMALLSERIAL, which has an implicit DEFAULT nextval(...).
+func isSerial(dataType string) bool {
+	return strings.HasSuffix(dataType, "SERIAL")
+}
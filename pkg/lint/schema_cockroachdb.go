@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"strings"
+
+	ddlcrdb "github.com/hakadoriya/ddlctl/pkg/ddl/cockroachdb"
+)
+
+// FromCockroachDB converts the DDL of CockroachDB into Schema.
+// ALTER TABLE ... ADD COLUMN in d, such as in the result of Diff, is converted into AddedColumns.
+//
+//nolint:cyclop,funlen
+func FromCockroachDB(d *ddlcrdb.DDL) *Schema {
+	s := &Schema{Dialect: ddlcrdb.Dialect}
+	if d == nil {
+		return s
+	}
+
+	for _, stmt := range d.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlcrdb.CreateTableStmt:
+			t := &Table{
+				Schema: crdbObjectSchema(stmt.Name),
+				Name:   crdbName(stmt.Name.Name),
+				Pos:    stmt.Pos,
+			}
+			for _, c := range stmt.Columns {
+				t.Columns = append(t.Columns, crdbColumn(c))
+			}
+			for _, c := range stmt.Constraints {
+				switch c := c.(type) {
+				case *ddlcrdb.PrimaryKeyConstraint:
+					columns := crdbColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindPrimaryKey, Name: crdbName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns})
+				case *ddlcrdb.ForeignKeyConstraint:
+					columns := crdbColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindForeignKey, Name: crdbName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns, RefTable: c.Ref.Name, RefColumns: crdbColumnNames(c.RefColumns)})
+				case *ddlcrdb.IndexConstraint:
+					kind := KindIndex
+					if c.Unique {
+						kind = KindUnique
+					}
+					columns := crdbColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: kind, Name: crdbName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns})
+				case *ddlcrdb.CheckConstraint:
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindCheck, Name: crdbName(c.Name), Pos: constraintPos(c.Pos, t, nil)})
+				}
+			}
+			s.Tables = append(s.Tables, t)
+		case *ddlcrdb.CreateIndexStmt:
+			s.Indexes = append(s.Indexes, &Index{
+				Name:    crdbName(stmt.Name),
+				Pos:     stmt.Pos,
+				Table:   crdbObjectQualifiedName(stmt.TableName),
+				Columns: crdbColumnNames(stmt.Columns),
+				Unique:  stmt.Unique,
+			})
+		case *ddlcrdb.AlterTableStmt:
+			if action, ok := stmt.Action.(*ddlcrdb.AddColumn); ok {
+				s.AddedColumns = append(s.AddedColumns, &AddedColumn{
+					Table:  crdbObjectQualifiedName(stmt.Name),
+					Column: crdbColumn(action.Column),
+				})
+			}
+		}
+	}
+
+	return s
+}
+
+func crdbName(i *ddlcrdb.Ident) *Name {
+	if i == nil {
+		return nil
+	}
+	// MEMO: unquoted identifiers are folded to lower case.
+	if i.QuotationMark == "" {
+		return &Name{Name: strings.ToLower(i.Name)}
+	}
+	return &Name{Name: i.Name, Quoted: true}
+}
+
+func crdbObjectSchema(o *ddlcrdb.ObjectName) string {
+	if o.Schema == nil {
+		return ""
+	}
+	return o.Schema.Name
+}
+
+func crdbObjectQualifiedName(o *ddlcrdb.ObjectName) string {
+	if o.Schema == nil {
+		return o.Name.Name
+	}
+	return o.Schema.Name + "." + o.Name.Name
+}
+
+func crdbColumnNames(idents []*ddlcrdb.ColumnIdent) []string {
+	names := make([]string, 0, len(idents))
+	for _, i := range idents {
+		names = append(names, i.Ident.Name)
+	}
+	return names
+}
+
+func crdbColumn(c *ddlcrdb.Column) *Column {
+	dataType := dataTypeName(c.DataType.String())
+	var def string
+	if c.Default != nil {
+		def = c.Default.Value.String()
+	}
+	return &Column{
+		Name:      crdbName(c.Name),
+		Pos:       c.Pos,
+		DataType:  dataType,
+		NotNull:   c.NotNull,
+		Default:   def,
+		Generated: c.As != nil || isSerial(dataType),
+	}
+}
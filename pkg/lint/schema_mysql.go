@@ -0,0 +1,112 @@
+package lint
+
+import (
+	ddlmysql "github.com/hakadoriya/ddlctl/pkg/ddl/mysql"
+)
+
+// FromMySQL converts the DDL of MySQL into Schema.
+// ALTER TABLE ... ADD COLUMN in d, such as in the result of Diff, is converted into AddedColumns.
+//
+//nolint:cyclop,funlen
+func FromMySQL(d *ddlmysql.DDL) *Schema {
+	s := &Schema{Dialect: ddlmysql.Dialect}
+	if d == nil {
+		return s
+	}
+
+	for _, stmt := range d.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlmysql.CreateTableStmt:
+			t := &Table{
+				Schema: mysqlObjectSchema(stmt.Name),
+				Name:   mysqlName(stmt.Name.Name),
+				Pos:    stmt.Pos,
+			}
+			for _, c := range stmt.Columns {
+				t.Columns = append(t.Columns, mysqlColumn(c))
+			}
+			for _, c := range stmt.Constraints {
+				switch c := c.(type) {
+				case *ddlmysql.PrimaryKeyConstraint:
+					columns := mysqlColumnNames(c.Columns)
+					// MEMO: the name of PRIMARY KEY is always PRIMARY in MySQL.
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindPrimaryKey, Pos: constraintPos(c.Pos, t, columns), Columns: columns})
+				case *ddlmysql.ForeignKeyConstraint:
+					columns := mysqlColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindForeignKey, Name: mysqlName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns, RefTable: c.Ref.Name, RefColumns: mysqlColumnNames(c.RefColumns)})
+				case *ddlmysql.IndexConstraint:
+					kind := KindIndex
+					if c.Unique {
+						kind = KindUnique
+					}
+					columns := mysqlColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: kind, Name: mysqlName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns})
+				case *ddlmysql.CheckConstraint:
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindCheck, Name: mysqlName(c.Name), Pos: constraintPos(c.Pos, t, nil)})
+				}
+			}
+			s.Tables = append(s.Tables, t)
+		case *ddlmysql.CreateIndexStmt:
+			s.Indexes = append(s.Indexes, &Index{
+				Name:    mysqlName(stmt.Name.Name),
+				Pos:     stmt.Pos,
+				Table:   mysqlObjectQualifiedName(stmt.TableName),
+				Columns: mysqlColumnNames(stmt.Columns),
+				Unique:  stmt.Unique,
+			})
+		case *ddlmysql.AlterTableStmt:
+			if action, ok := stmt.Action.(*ddlmysql.AddColumn); ok {
+				s.AddedColumns = append(s.AddedColumns, &AddedColumn{
+					Table:  mysqlObjectQualifiedName(stmt.Name),
+					Column: mysqlColumn(action.Column),
+				})
+			}
+		}
+	}
+
+	return s
+}
+
+func mysqlName(i *ddlmysql.Ident) *Name {
+	if i == nil {
+		return nil
+	}
+	return &Name{Name: i.Name, Quoted: i.QuotationMark != ""}
+}
+
+func mysqlObjectSchema(o *ddlmysql.ObjectName) string {
+	if o.Schema == nil {
+		return ""
+	}
+	return o.Schema.Name
+}
+
+func mysqlObjectQualifiedName(o *ddlmysql.ObjectName) string {
+	if o.Schema == nil {
+		return o.Name.Name
+	}
+	return o.Schema.Name + "." + o.Name.Name
+}
+
+func mysqlColumnNames(idents []*ddlmysql.ColumnIdent) []string {
+	names := make([]string, 0, len(idents))
+	for _, i := range idents {
+		names = append(names, i.Ident.Name)
+	}
+	return names
+}
+
+func mysqlColumn(c *ddlmysql.Column) *Column {
+	var def string
+	if c.Default != nil {
+		def = c.Default.Value.String()
+	}
+	return &Column{
+		Name:      mysqlName(c.Name),
+		Pos:       c.Pos,
+		DataType:  dataTypeName(c.DataType.String()),
+		NotNull:   c.NotNull,
+		Default:   def,
+		Generated: c.AutoIncrement,
+	}
+}
@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"strings"
+
+	ddlpg "github.com/hakadoriya/ddlctl/pkg/ddl/postgres"
+)
+
+// FromPostgres converts the DDL of PostgreSQL into Schema.
+// ALTER TABLE ... ADD COLUMN in d, such as in the result of Diff, is converted into AddedColumns.
+//
+//nolint:cyclop,funlen
+func FromPostgres(d *ddlpg.DDL) *Schema {
+	s := &Schema{Dialect: ddlpg.Dialect}
+	if d == nil {
+		return s
+	}
+
+	for _, stmt := range d.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlpg.CreateTableStmt:
+			t := &Table{
+				Schema: pgObjectSchema(stmt.Name),
+				Name:   pgName(stmt.Name.Name),
+				Pos:    stmt.Pos,
+			}
+			for _, c := range stmt.Columns {
+				t.Columns = append(t.Columns, pgColumn(c))
+			}
+			for _, c := range stmt.Constraints {
+				switch c := c.(type) {
+				case *ddlpg.PrimaryKeyConstraint:
+					columns := pgColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindPrimaryKey, Name: pgName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns})
+				case *ddlpg.ForeignKeyConstraint:
+					columns := pgColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindForeignKey, Name: pgName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns, RefTable: c.Ref.Name, RefColumns: pgColumnNames(c.RefColumns)})
+				case *ddlpg.UniqueConstraint:
+					columns := pgColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindUnique, Name: pgName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns})
+				case *ddlpg.CheckConstraint:
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindCheck, Name: pgName(c.Name), Pos: constraintPos(c.Pos, t, nil)})
+				}
+			}
+			s.Tables = append(s.Tables, t)
+		case *ddlpg.CreateIndexStmt:
+			s.Indexes = append(s.Indexes, &Index{
+				Name:    pgName(stmt.Name),
+				Pos:     stmt.Pos,
+				Table:   pgObjectQualifiedName(stmt.TableName),
+				Columns: pgColumnNames(stmt.Columns),
+				Unique:  stmt.Unique,
+			})
+		case *ddlpg.AlterTableStmt:
+			if action, ok := stmt.Action.(*ddlpg.AddColumn); ok {
+				s.AddedColumns = append(s.AddedColumns, &AddedColumn{
+					Table:  pgObjectQualifiedName(stmt.Name),
+					Column: pgColumn(action.Column),
+				})
+			}
+		}
+	}
+
+	return s
+}
+
+func pgName(i *ddlpg.Ident) *Name {
+	if i == nil {
+		return nil
+	}
+	// MEMO: unquoted identifiers are folded to lower case.
+	if i.QuotationMark == "" {
+		return &Name{Name: strings.ToLower(i.Name)}
+	}
+	return &Name{Name: i.Name, Quoted: true}
+}
+
+func pgObjectSchema(o *ddlpg.ObjectName) string {
+	if o.Schema == nil {
+		return ""
+	}
+	return o.Schema.Name
+}
+
+func pgObjectQualifiedName(o *ddlpg.ObjectName) string {
+	if o.Schema == nil {
+		return o.Name.Name
+	}
+	return o.Schema.Name + "." + o.Name.Name
+}
+
+func pgColumnNames(idents []*ddlpg.ColumnIdent) []string {
+	names := make([]string, 0, len(idents))
+	for _, i := range idents {
+		names = append(names, i.Ident.Name)
+	}
+	return names
+}
+
+func pgColumn(c *ddlpg.Column) *Column {
+	dataType := dataTypeName(c.DataType.String())
+	var def string
+	if c.Default != nil {
+		def = c.Default.Value.String()
+	}
+	return &Column{
+		Name:      pgName(c.Name),
+		Pos:       c.Pos,
+		DataType:  dataType,
+		NotNull:   c.NotNull,
+		Default:   def,
+		Generated: c.Identity != nil || isSerial(dataType),
+	}
+}
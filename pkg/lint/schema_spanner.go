@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"strings"
+
+	ddlspanner "github.com/hakadoriya/ddlctl/pkg/ddl/spanner"
+)
+
+// FromSpanner converts the DDL of Spanner into Schema.
+// ALTER TABLE ... ADD COLUMN in d, such as in the result of Diff, is converted into AddedColumns.
+//
+//nolint:cyclop,funlen
+func FromSpanner(d *ddlspanner.DDL) *Schema {
+	s := &Schema{Dialect: ddlspanner.Dialect}
+	if d == nil {
+		return s
+	}
+
+	for _, stmt := range d.Stmts {
+		switch stmt := stmt.(type) {
+		case *ddlspanner.CreateTableStmt:
+			t := &Table{
+				Schema: spannerObjectSchema(stmt.Name),
+				Name:   spannerName(stmt.Name.Name),
+				Pos:    stmt.Pos,
+			}
+			for _, c := range stmt.Columns {
+				t.Columns = append(t.Columns, spannerColumn(c))
+			}
+			// MEMO: Spanner has no name for PRIMARY KEY, which is a table option.
+			for _, o := range stmt.Options {
+				if o.Name == "PRIMARY KEY" {
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindPrimaryKey, Pos: t.Pos, Columns: spannerKeyNames(o.Value)})
+				}
+			}
+			for _, c := range stmt.Constraints {
+				switch c := c.(type) {
+				case *ddlspanner.ForeignKeyConstraint:
+					columns := spannerColumnNames(c.Columns)
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindForeignKey, Name: spannerName(c.Name), Pos: constraintPos(c.Pos, t, columns), Columns: columns, RefTable: c.Ref.Name, RefColumns: spannerColumnNames(c.RefColumns)})
+				case *ddlspanner.CheckConstraint:
+					t.Constraints = append(t.Constraints, &Constraint{Kind: KindCheck, Name: spannerName(c.Name), Pos: constraintPos(c.Pos, t, nil)})
+				}
+			}
+			s.Tables = append(s.Tables, t)
+		case *ddlspanner.CreateIndexStmt:
+			s.Indexes = append(s.Indexes, &Index{
+				Name:    spannerName(stmt.Name.Name),
+				Pos:     stmt.Pos,
+				Table:   spannerObjectQualifiedName(stmt.TableName),
+				Columns: spannerColumnNames(stmt.Columns),
+				Unique:  stmt.Unique,
+			})
+		case *ddlspanner.AlterTableStmt:
+			if action, ok := stmt.Action.(*ddlspanner.AddColumn); ok {
+				s.AddedColumns = append(s.AddedColumns, &AddedColumn{
+					Table:  spannerObjectQualifiedName(stmt.Name),
+					Column: spannerColumn(action.Column),
+				})
+			}
+		}
+	}
+
+	return s
+}
+
+func spannerName(i *ddlspanner.Ident) *Name {
+	if i == nil {
+		return nil
+	}
+	return &Name{Name: i.Name, Quoted: i.QuotationMark != ""}
+}
+
+func spannerObjectSchema(o *ddlspanner.ObjectName) string {
+	if o.Schema == nil {
+		return ""
+	}
+	return o.Schema.Name
+}
+
+func spannerObjectQualifiedName(o *ddlspanner.ObjectName) string {
+	if o.Schema == nil {
+		return o.Name.Name
+	}
+	return o.Schema.Name + "." + o.Name.Name
+}
+
+func spannerColumnNames(idents []*ddlspanner.ColumnIdent) []string {
+	names := make([]string, 0, len(idents))
+	for _, i := range idents {
+		names = append(names, i.Ident.Name)
+	}
+	return names
+}
+
+// spannerKeyNames returns the column names in the PRIMARY KEY expression such as (`Id`, CreatedAt DESC).
+func spannerKeyNames(expr *ddlspanner.Expr) []string {
+	if expr == nil {
+		return nil
+	}
+	names := make([]string, 0, len(expr.Idents))
+	for _, i := range expr.Idents {
+		switch strings.ToUpper(i.Raw) {
+		case "(", ")", ",", "ASC", "DESC":
+			continue
+		}
+		names = append(names, i.Name)
+	}
+	return names
+}
+
+func spannerColumn(c *ddlspanner.Column) *Column {
+	var def string
+	if c.Default != nil {
+		def = c.Default.Value.String()
+	}
+	return &Column{
+		Name:     spannerName(c.Name),
+		Pos:      c.Pos,
+		DataType: dataTypeName(c.DataType.String()),
+		NotNull:  c.NotNull,
+		Default:  def,
+		Options:  c.Options.String(),
+	}
+}
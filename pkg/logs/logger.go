@@ -8,6 +8,8 @@ import (
 	"os"
 
 	ioz "github.com/hakadoriya/z.go/ioz"
+
+	"github.com/hakadoriya/ddlctl/pkg/internal/redact"
 )
 
 //nolint:gochecknoglobals
@@ -43,9 +45,13 @@ type DefaultLogger struct {
 	*log.Logger
 }
 
-func (l *DefaultLogger) Print(v ...interface{}) { _ = l.Output(callerSkip, fmt.Sprint(v...)) }
+// MEMO: the messages are redacted, because they may have the DSNs, such as the config in debug mode.
+func (l *DefaultLogger) Print(v ...interface{}) {
+	_ = l.Output(callerSkip, redact.String(fmt.Sprint(v...)))
+}
+
 func (l *DefaultLogger) Printf(format string, v ...interface{}) {
-	_ = l.Output(callerSkip, fmt.Sprintf(format, v...))
+	_ = l.Output(callerSkip, redact.String(fmt.Sprintf(format, v...)))
 }
 
 func (l *DefaultLogger) Write(p []byte) (n int, err error) {
@@ -57,7 +63,7 @@ func (l *DefaultLogger) LineWriter(prefix string) io.Writer {
 	return ioz.WriteFunc(func(p []byte) (n int, err error) {
 		lines := bytes.Split(p, []byte("\n"))
 		for _, line := range lines {
-			_ = l.Output(1, prefix+string(line))
+			_ = l.Output(1, redact.String(prefix+string(line)))
 		}
 
 		return len(p), nil
@@ -3,10 +3,12 @@ package cockroachdb
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/hakadoriya/z.go/databasez/sqlz"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
 )
 
 type sqlQueryerContext = interface {
@@ -14,6 +16,10 @@ type sqlQueryerContext = interface {
 }
 
 const (
+	queryShowCreateAllTypes = `-- CREATE TYPE
+SHOW CREATE ALL TYPES
+;
+`
 	queryShowCreateAllTables = `-- CREATE TABLE
 SHOW CREATE ALL TABLES
 ;
@@ -27,13 +33,36 @@ func ShowCreateAllTables(ctx context.Context, db sqlQueryerContext) (query strin
 		CreateStatement string `db:"create_statement"`
 	}
 
+	// MEMO: types must be created before the tables that use them.
+	createTypeStmts := new([]*CreateStatement)
+	if err := dbz.QueryContext(ctx, createTypeStmts, queryShowCreateAllTypes); err != nil {
+		return "", apperr.Errorf("dbz.QueryContext: %w", err)
+	}
+	for _, stmt := range *createTypeStmts {
+		query += stmt.CreateStatement + "\n"
+	}
+
 	createTableStmts := new([]*CreateStatement)
 	if err := dbz.QueryContext(ctx, createTableStmts, queryShowCreateAllTables); err != nil {
 		return "", apperr.Errorf("dbz.QueryContext: %w", err)
 	}
 	for _, stmt := range *createTableStmts {
+		// MEMO: the lock table of ddlctl apply is not a part of the schema.
+		if isLockTable(stmt.CreateStatement) {
+			continue
+		}
 		query += stmt.CreateStatement + "\n"
 	}
 
 	return query, nil
 }
+
+// isLockTable reports whether createStatement is CREATE TABLE of consts.LockTableName.
+func isLockTable(createStatement string) bool {
+	for _, prefix := range []string{"CREATE TABLE " + consts.LockTableName + " (", "CREATE TABLE public." + consts.LockTableName + " ("} {
+		if strings.HasPrefix(createStatement, prefix) {
+			return true
+		}
+	}
+	return false
+}
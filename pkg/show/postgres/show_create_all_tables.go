@@ -15,6 +15,91 @@ type sqlQueryerContext = interface {
 }
 
 const (
+	formatShowCreateAllExtensions = `-- CREATE EXTENSION
+SELECT
+    'CREATE EXTENSION ' || quote_ident(e.extname) || ' WITH SCHEMA ' || n.nspname || ' VERSION ' || quote_literal(e.extversion) || ';' AS create_statement
+FROM
+    pg_extension e
+JOIN
+    pg_namespace n ON n.oid = e.extnamespace
+WHERE
+    n.nspname = '%s' AND e.extname <> 'plpgsql'
+ORDER BY
+    e.extname
+;
+`
+	formatShowCreateAllTypes = `-- CREATE TYPE
+SELECT
+    'CREATE TYPE ' || n.nspname || '.' || t.typname || ' AS ENUM (' ||
+    string_agg(quote_literal(e.enumlabel), ', ' ORDER BY e.enumsortorder) || ');' AS create_statement
+FROM
+    pg_type t
+JOIN
+    pg_namespace n ON n.oid = t.typnamespace
+JOIN
+    pg_enum e ON e.enumtypid = t.oid
+WHERE
+    n.nspname = '%s'
+GROUP BY
+    n.nspname, t.typname
+ORDER BY
+    t.typname
+;
+`
+	formatShowCreateAllDomains = `-- CREATE DOMAIN
+SELECT
+    'CREATE DOMAIN ' || n.nspname || '.' || t.typname || ' AS ' || format_type(t.typbasetype, t.typtypmod) ||
+    (CASE WHEN t.typdefault IS NOT NULL THEN ' DEFAULT ' || t.typdefault ELSE '' END) ||
+    (CASE WHEN t.typnotnull THEN ' NOT NULL' ELSE '' END) ||
+    COALESCE((
+        SELECT string_agg(' CONSTRAINT ' || c.conname || ' ' || pg_get_constraintdef(c.oid), '' ORDER BY c.conname)
+        FROM pg_constraint c
+        WHERE c.contypid = t.oid AND c.contype = 'c'
+    ), '') || ';' AS create_statement
+FROM
+    pg_type t
+JOIN
+    pg_namespace n ON n.oid = t.typnamespace
+WHERE
+    n.nspname = '%s' AND t.typtype = 'd'
+ORDER BY
+    t.typname
+;
+`
+	// MEMO: sequences implicitly created for IDENTITY columns are excluded because they are part of the column definition.
+	formatShowCreateAllSequences = `-- CREATE SEQUENCE
+SELECT
+    'CREATE SEQUENCE ' || s.schemaname || '.' || s.sequencename || ' AS ' || format_type(s.data_type, NULL) ||
+    ' INCREMENT BY ' || s.increment_by ||
+    ' MINVALUE ' || s.min_value ||
+    ' MAXVALUE ' || s.max_value ||
+    ' START WITH ' || s.start_value ||
+    ' CACHE ' || s.cache_size ||
+    (CASE WHEN s.cycle THEN ' CYCLE' ELSE ' NO CYCLE' END) ||
+    COALESCE((
+        SELECT ' OWNED BY ' || tn.nspname || '.' || t.relname || '.' || a.attname
+        FROM pg_depend d
+        JOIN pg_class t ON t.oid = d.refobjid
+        JOIN pg_namespace tn ON tn.oid = t.relnamespace
+        JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+        WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'a'
+    ), '') || ';' AS create_statement
+FROM
+    pg_sequences s
+JOIN
+    pg_namespace n ON n.nspname = s.schemaname
+JOIN
+    pg_class c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+WHERE
+    s.schemaname = '%s' AND NOT EXISTS (
+        SELECT 1
+        FROM pg_depend d
+        WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'i'
+    )
+ORDER BY
+    s.sequencename
+;
+`
 	formatShowCreateAllTables = `-- CREATE TABLE
 SELECT
     'CREATE TABLE ' || clmn.table_schema || '.' || clmn.table_name || ' (' || E'\n' || '  ' ||
@@ -27,10 +112,16 @@ FROM
             c.table_schema,
             c.table_name,
             string_agg(
-                c.column_name || ' ' || c.data_type ||
-                (CASE WHEN c.character_maximum_length IS NOT NULL THEN '(' || c.character_maximum_length || ')' ELSE '' END) ||
+                c.column_name || ' ' ||
+                (CASE
+                    WHEN c.domain_name IS NOT NULL THEN c.domain_schema || '.' || c.domain_name
+                    WHEN c.data_type = 'USER-DEFINED' THEN c.udt_schema || '.' || c.udt_name
+                    ELSE c.data_type
+                END) ||
+                (CASE WHEN c.character_maximum_length IS NOT NULL AND c.domain_name IS NULL THEN '(' || c.character_maximum_length || ')' ELSE '' END) ||
                 (CASE WHEN c.is_nullable = 'NO' THEN ' NOT NULL' ELSE '' END) ||
-                (CASE WHEN c.column_default IS NOT NULL THEN ' DEFAULT ' || c.column_default ELSE '' END),
+                (CASE WHEN c.column_default IS NOT NULL THEN ' DEFAULT ' || c.column_default ELSE '' END) ||
+                (CASE WHEN c.is_identity = 'YES' THEN ' GENERATED ' || c.identity_generation || ' AS IDENTITY' ELSE '' END),
                 ',' || E'\n' || '  ' ORDER BY c.ordinal_position
             ) AS column_defs
         FROM
@@ -104,6 +195,29 @@ WHERE
         WHERE table_schema = '%s'
     )
 ;
+`
+	// MEMO: definition of pg_views and pg_matviews ends with a semicolon.
+	formatShowCreateAllViews = `-- CREATE VIEW
+SELECT
+    'CREATE VIEW ' || schemaname || '.' || viewname || ' AS' || E'\n' || definition AS create_statement
+FROM
+    pg_views
+WHERE
+    schemaname = '%s'
+ORDER BY
+    viewname
+;
+`
+	formatShowCreateAllMaterializedViews = `-- CREATE MATERIALIZED VIEW
+SELECT
+    'CREATE MATERIALIZED VIEW ' || schemaname || '.' || matviewname || ' AS' || E'\n' || definition AS create_statement
+FROM
+    pg_matviews
+WHERE
+    schemaname = '%s'
+ORDER BY
+    matviewname
+;
 `
 )
 
@@ -139,6 +253,17 @@ func ShowCreateAllTables(ctx context.Context, db sqlQueryerContext, opts ...Show
 		CreateStatement string `db:"create_statement"`
 	}
 
+	// MEMO: extensions, types, domains and sequences must be created before the tables that use them.
+	for _, format := range []string{formatShowCreateAllExtensions, formatShowCreateAllTypes, formatShowCreateAllDomains, formatShowCreateAllSequences} {
+		createStmts := new([]*CreateStatement)
+		if err := dbz.QueryContext(ctx, createStmts, fmt.Sprintf(format, cfg.schema)); err != nil {
+			return "", apperr.Errorf("dbz.QueryContext: %w", err)
+		}
+		for _, stmt := range *createStmts {
+			query += stmt.CreateStatement + "\n"
+		}
+	}
+
 	createTableStmts := new([]*CreateStatement)
 	if err := dbz.QueryContext(ctx, createTableStmts, fmt.Sprintf(formatShowCreateAllTables, cfg.schema)); err != nil {
 		return "", apperr.Errorf("dbz.QueryContext: %w", err)
@@ -155,5 +280,16 @@ func ShowCreateAllTables(ctx context.Context, db sqlQueryerContext, opts ...Show
 		query += stmt.CreateStatement + ";\n"
 	}
 
+	// MEMO: views are created after the tables they depend on.
+	for _, format := range []string{formatShowCreateAllViews, formatShowCreateAllMaterializedViews} {
+		createStmts := new([]*CreateStatement)
+		if err := dbz.QueryContext(ctx, createStmts, fmt.Sprintf(format, cfg.schema)); err != nil {
+			return "", apperr.Errorf("dbz.QueryContext: %w", err)
+		}
+		for _, stmt := range *createStmts {
+			query += stmt.CreateStatement + "\n"
+		}
+	}
+
 	return query, nil
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 
 	"github.com/hakadoriya/z.go/databasez/sqlz"
 
 	"github.com/hakadoriya/ddlctl/pkg/apperr"
+	"github.com/hakadoriya/ddlctl/pkg/internal/consts"
 	"github.com/hakadoriya/ddlctl/pkg/logs"
 )
 
@@ -182,6 +184,9 @@ func ShowCreateAllTables(ctx context.Context, db sqlQueryerContext, opts ...Show
 		return "", apperr.Errorf("dbz.QueryContext: %w", err)
 	}
 
+	// MEMO: the lock table of ddlctl apply is not a part of the schema.
+	tables = slices.DeleteFunc(tables, func(tbl *informationSchemaTable) bool { return tbl.TableName == consts.LockTableName })
+
 	tablesLastIndex := len(tables) - 1
 	for tblIdx, tbl := range tables {
 		// TABLE